@@ -20,3 +20,19 @@ var (
 	NokiaFoodGreen = color.RGBA{208, 232, 205, 1}
 	NokiaBorder    = color.RGBA{33, 52, 0, 1}
 )
+
+//Blend Returns a color part way between from and to, amount 0 is from and 1 is to
+func Blend(from color.RGBA, to color.RGBA, amount float64) color.RGBA {
+
+	if amount < 0 {
+		amount = 0
+	} else if amount > 1 {
+		amount = 1
+	}
+
+	mix := func(a uint8, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*amount)
+	}
+
+	return color.RGBA{mix(from.R, to.R), mix(from.G, to.G), mix(from.B, to.B), from.A}
+}
@@ -0,0 +1,217 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopherlife/colors"
+	"gopherlife/geometry"
+	"gopherlife/renderers"
+	"gopherlife/world"
+	"image/color"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var (
+	antGroundColor = color.RGBA{235, 220, 190, 1}
+	antNestColor   = color.RGBA{120, 80, 40, 1}
+	antColor       = colors.Black
+	antFoodColor   = colors.Green
+)
+
+//antSparkline the characters drawn for each bar of the deliveries chart, from lowest to highest
+var antSparkline = []rune("▁▂▃▄▅▆▇█")
+
+//AntColonyController shows an AntColony with the food and home pheromone optionally drawn over the ground
+type AntColonyController struct {
+	NoPlayerInput
+	world.AntColonySettings
+	*world.AntColony
+	*renderers.GridRenderer
+	ShowFoodPheromone bool
+	ShowHomePheromone bool
+	//foodPheromoneMax and homePheromoneMax the strongest scent in the frame being drawn, so the overlays are scaled
+	//to the strongest trail
+	foodPheromoneMax float64
+	homePheromoneMax float64
+	//ants the ant on each tile in the frame being drawn
+	ants map[geometry.Coordinates]*world.Ant
+}
+
+//NewAntColonyController Returns a Controller for an AntColony with both pheromone overlays shown
+func NewAntColonyController() AntColonyController {
+
+	settings := world.AntColonySettings{
+		Dimensions:   world.Dimensions{Width: 100, Height: 100},
+		NumberOfAnts: 100,
+		NumberOfFood: 300,
+		NestRadius:   3,
+		Evaporation:  0.01,
+		Diffusion:    0.05,
+	}
+
+	renderer := renderers.NewRenderer(100, 100)
+
+	return AntColonyController{
+		AntColonySettings: settings,
+		GridRenderer:      &renderer,
+		ShowFoodPheromone: true,
+		ShowHomePheromone: true,
+	}
+}
+
+func (controller *AntColonyController) Start() {
+	if controller.AntColony == nil {
+		antColony := world.NewAntColony(controller.AntColonySettings)
+		controller.AntColony = &antColony
+	}
+}
+
+//Click Drops a piece of food on the clicked tile
+func (controller *AntColonyController) Click(x int, y int) {
+	controller.AddFood(x, y)
+}
+
+func (controller *AntColonyController) MarshalJSON() ([]byte, error) {
+
+	controller.foodPheromoneMax = controller.FoodPheromone.Max()
+	controller.homePheromoneMax = controller.HomePheromone.Max()
+
+	controller.ants = make(map[geometry.Coordinates]*world.Ant, len(controller.Ants))
+	for _, ant := range controller.Ants {
+		controller.ants[ant.Position] = ant
+	}
+
+	controller.GridRenderer.Surface = controller.AntColony.Surface()
+	render := controller.GridRenderer.Draw(controller)
+
+	render.TextBelowCanvas += fmt.Sprintf("<span>Tick: %d Food Delivered: %d (%.1f per 100 ticks)</span><br />",
+		controller.Tick, controller.FoodDelivered, controller.DeliveryRate()*100)
+	render.TextBelowCanvas += fmt.Sprintf("<span>Deliveries: %s</span><br />", controller.deliveryChart(40))
+
+	return json.Marshal(render)
+}
+
+//deliveryChart Returns a bar for each of the last samples of the History, as high as the food delivered since the
+//sample before it
+func (controller *AntColonyController) deliveryChart(samples int) string {
+
+	history := controller.History
+
+	if len(history) > samples+1 {
+		history = history[len(history)-samples-1:]
+	}
+
+	if len(history) < 2 {
+		return ""
+	}
+
+	deliveries := make([]int, len(history)-1)
+	most := 1
+
+	for i := range deliveries {
+		deliveries[i] = history[i+1].Delivered - history[i].Delivered
+		if deliveries[i] > most {
+			most = deliveries[i]
+		}
+	}
+
+	var chart strings.Builder
+
+	for _, delivered := range deliveries {
+		chart.WriteRune(antSparkline[delivered*(len(antSparkline)-1)/most])
+	}
+
+	return chart.String()
+}
+
+func (controller *AntColonyController) RenderTile(x int, y int) color.RGBA {
+
+	if !controller.AntColony.Surface().Contains(x, y) {
+		return offGridColor
+	}
+
+	if ant, ok := controller.ants[geometry.NewCoordinate(x, y)]; ok {
+		if ant.CarryingFood {
+			return antFoodColor
+		}
+		return antColor
+	}
+
+	if controller.HasFood(x, y) {
+		return antFoodColor
+	}
+
+	if controller.IsNest(x, y) {
+		return antNestColor
+	}
+
+	tileColor := antGroundColor
+
+	if controller.ShowHomePheromone && controller.homePheromoneMax > 0 {
+		strength := math.Sqrt(controller.HomePheromone.Value(x, y) / controller.homePheromoneMax)
+		tileColor = colors.Blend(tileColor, colors.Purple, strength)
+	}
+
+	if controller.ShowFoodPheromone && controller.foodPheromoneMax > 0 {
+		strength := math.Sqrt(controller.FoodPheromone.Value(x, y) / controller.foodPheromoneMax)
+		tileColor = colors.Blend(tileColor, colors.Blue, strength)
+	}
+
+	return tileColor
+}
+
+func (controller *AntColonyController) PageLayout() WorldPageData {
+
+	settings := controller.AntColonySettings
+
+	return WorldPageData{
+		FormData: []FormData{
+			FormDataWidth(settings.Width, 2),
+			FormDataHeight(settings.Height, 2),
+			FormDataAnts(settings.NumberOfAnts, 2),
+			FormDataFood(settings.NumberOfFood, 2),
+			FormDataNestRadius(settings.NestRadius, 2),
+			FormDataEvaporation(settings.Evaporation, 1),
+			FormDataDiffusion(settings.Diffusion, 1),
+			FormDataShowFoodPheromone(controller.ShowFoodPheromone, 2),
+			FormDataShowHomePheromone(controller.ShowHomePheromone, 2),
+		},
+	}
+}
+
+func (controller *AntColonyController) HandleForm(values url.Values) bool {
+
+	fd := FormDataAnts(0, 0)
+	if strings.Contains(values.Encode(), fd.Name) {
+
+		width, _ := strconv.ParseInt(values.Get(FormDataWidth(0, 0).Name), 10, 64)
+		height, _ := strconv.ParseInt(values.Get(FormDataHeight(0, 0).Name), 10, 64)
+		ants, _ := strconv.ParseInt(values.Get(fd.Name), 10, 64)
+		food, _ := strconv.ParseInt(values.Get(FormDataFood(0, 0).Name), 10, 64)
+		nestRadius, _ := strconv.ParseInt(values.Get(FormDataNestRadius(0, 0).Name), 10, 64)
+		evaporation, _ := strconv.ParseFloat(values.Get(FormDataEvaporation(0, 0).Name), 64)
+		diffusion, _ := strconv.ParseFloat(values.Get(FormDataDiffusion(0, 0).Name), 64)
+		showFood, _ := strconv.ParseInt(values.Get(FormDataShowFoodPheromone(false, 0).Name), 10, 64)
+		showHome, _ := strconv.ParseInt(values.Get(FormDataShowHomePheromone(false, 0).Name), 10, 64)
+
+		controller.AntColonySettings = world.AntColonySettings{
+			Dimensions:   world.Dimensions{Width: int(width), Height: int(height)},
+			NumberOfAnts: int(ants),
+			NumberOfFood: int(food),
+			NestRadius:   int(nestRadius),
+			Evaporation:  evaporation,
+			Diffusion:    diffusion,
+		}
+
+		controller.ShowFoodPheromone = showFood != 0
+		controller.ShowHomePheromone = showHome != 0
+	}
+
+	controller.AntColony = nil
+	controller.Start()
+
+	return true
+}
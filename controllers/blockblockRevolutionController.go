@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"gopherlife/colors"
+	"gopherlife/highscores"
 	"gopherlife/renderers"
 	"gopherlife/world"
 	"image/color"
@@ -12,13 +13,19 @@ import (
 	"strings"
 )
 
+const blockBlockRevolutionGameName = "Block Block Revolution"
+
 type BlockBlockRevolutionController struct {
 	world.BlockBlockRevolutionSettings
+	PlayerName string
 	*world.BlockBlockRevolutionWorld
 	*renderers.GridRenderer
+	highScores highScoreTracker
+	recorder   InputRecorder
 }
 
-func NewBlockBlockRevolutionController() BlockBlockRevolutionController {
+//NewBlockBlockRevolutionController Returns a Controller with a BlockBlockRevolutionWorld. Finished games are recorded in the given high-score Store, if not nil
+func NewBlockBlockRevolutionController(scores *highscores.Store) BlockBlockRevolutionController {
 
 	d := world.Dimensions{
 		Width:  10,
@@ -36,6 +43,7 @@ func NewBlockBlockRevolutionController() BlockBlockRevolutionController {
 			BlockSpeedReduction: 5,
 		},
 		GridRenderer: &renderer,
+		highScores:   newHighScoreTracker(scores, blockBlockRevolutionGameName),
 	}
 
 }
@@ -44,6 +52,9 @@ func (controller *BlockBlockRevolutionController) Start() {
 	if controller.BlockBlockRevolutionWorld == nil {
 		sMap := world.NewBlockBlockRevolutionWorld(controller.BlockBlockRevolutionSettings)
 		controller.BlockBlockRevolutionWorld = &sMap
+		controller.highScores.begin()
+		controller.recorder.Stop()
+		controller.recorder.Begin(blockBlockRevolutionGameName, sMap.Seed, sMap.BlockBlockRevolutionSettings)
 	}
 }
 
@@ -52,8 +63,13 @@ func (controller *BlockBlockRevolutionController) MarshalJSON() ([]byte, error)
 	render := controller.GridRenderer.Draw(controller)
 	render.TextBelowCanvas += fmt.Sprintf("<span>Score: %d </span><br />", controller.Score)
 
+	if controller.recorder.IsPlaying() {
+		render.TextBelowCanvas += fmt.Sprintf("<span>Replaying</span><br />")
+	}
+
 	if controller.IsGameOver {
 		render.TextBelowCanvas += fmt.Sprintf("<span>Game Over!</span><br />")
+		render.TextBelowCanvas += controller.highScores.tableHTML(controller.highScoreSettings())
 	}
 
 	return json.Marshal(render)
@@ -77,6 +93,7 @@ func (controller *BlockBlockRevolutionController) PageLayout() WorldPageData {
 		PageTitle: "B L O C K B L O C K R E V O L U T I O N",
 		FormData: []FormData{
 			FormDataBlockSpeedReductionSlowDown(controller.BlockBlockRevolutionSettings.BlockSpeedReduction, 3),
+			FormDataPlayerName(controller.PlayerName, 3),
 		},
 	}
 }
@@ -87,20 +104,30 @@ func (controller *BlockBlockRevolutionController) HandleForm(values url.Values)
 	if strings.Contains(values.Encode(), fd.Name) {
 		speed, _ := strconv.ParseInt(values.Get(fd.Name), 10, 64)
 		controller.BlockBlockRevolutionSettings.BlockSpeedReduction = int(speed)
-		bbrm := world.NewBlockBlockRevolutionWorld(controller.BlockBlockRevolutionSettings)
-		controller.BlockBlockRevolutionWorld = &bbrm
+		controller.PlayerName = values.Get(FormDataPlayerName("", 0).Name)
+		controller.BlockBlockRevolutionWorld = nil
+		controller.Start()
 	}
 
 	return true
 }
 
+//KeyPress records the key press, it is applied at the start of the next tick
 func (controller *BlockBlockRevolutionController) KeyPress(key Keys) {
+	controller.recorder.KeyPress(key)
+}
 
-	if controller.IsGameOver {
+func (controller *BlockBlockRevolutionController) Update() bool {
+	return updateGame(controller, &controller.recorder)
+}
+
+func (controller *BlockBlockRevolutionController) applyInput(event InputEvent) {
+
+	if controller.IsGameOver || event.Type != KeyPressInput {
 		return
 	}
 
-	switch key {
+	switch event.Key {
 	case LeftArrow:
 		controller.Add(func() {
 			controller.BlockBlockRevolutionWorld.MoveCurrentTetrominoLeft()
@@ -118,14 +145,84 @@ func (controller *BlockBlockRevolutionController) KeyPress(key Keys) {
 	}
 }
 
-func (controller *BlockBlockRevolutionController) Update() bool {
-	return controller.BlockBlockRevolutionWorld.Update()
+func (controller *BlockBlockRevolutionController) updateWorld() bool {
+
+	updated := controller.BlockBlockRevolutionWorld.Update()
+
+	if controller.IsGameOver && !controller.recorder.IsPlaying() {
+		controller.highScores.finish(controller.PlayerName, controller.highScoreSettings(), controller.Score)
+		controller.recorder.Finish(controller.Tick, controller.Score)
+	}
+
+	return updated
+}
+
+func (controller *BlockBlockRevolutionController) loadReplay(replay Replay) error {
+
+	if err := checkReplayGame(replay, blockBlockRevolutionGameName); err != nil {
+		return err
+	}
+
+	var settings world.BlockBlockRevolutionSettings
+
+	if err := json.Unmarshal(replay.Settings, &settings); err != nil {
+		return err
+	}
+
+	settings.Seed = replay.Seed
+
+	bbrm := world.NewBlockBlockRevolutionWorld(settings)
+	controller.BlockBlockRevolutionWorld = &bbrm
+
+	return nil
+}
+
+func (controller *BlockBlockRevolutionController) tick() int {
+	return controller.Tick
+}
+
+func (controller *BlockBlockRevolutionController) score() int {
+	return controller.Score
+}
+
+func (controller *BlockBlockRevolutionController) isGameOver() bool {
+	return controller.IsGameOver
+}
+
+//SaveReplay Saves the current game, finished or not, so it can be played again
+func (controller *BlockBlockRevolutionController) SaveReplay(name string) error {
+	controller.recorder.Finish(controller.Tick, controller.Score)
+	return controller.recorder.Save(name)
+}
+
+//PlayReplay Replaces the current game with the named replay
+func (controller *BlockBlockRevolutionController) PlayReplay(name string, fast bool) error {
+	return playReplay(controller, &controller.recorder, name, fast)
+}
+
+//VerifyReplay Plays the named replay in the background and checks it reaches the recorded score
+func (controller *BlockBlockRevolutionController) VerifyReplay(name string) (ReplayResult, error) {
+	verifier := NewBlockBlockRevolutionController(nil)
+	return verifyReplay(&verifier, name)
+}
+
+//HighScores Returns the high-score table for the current settings
+func (controller *BlockBlockRevolutionController) HighScores() highscores.Table {
+	return controller.highScores.table(controller.highScoreSettings())
+}
+
+//highScoreSettings High-scores are kept separately for each speed and board size
+func (controller *BlockBlockRevolutionController) highScoreSettings() string {
+	settings := controller.BlockBlockRevolutionWorld.BlockBlockRevolutionSettings
+	return fmt.Sprintf("Block Speed Reduction %d, %dx%d", settings.BlockSpeedReduction, settings.Width, settings.Height)
 }
 
-//Click selects the tile on the gopher map and runs the SelectEntity method
+//Click starts a new game if the game is over, otherwise the click is recorded
 func (controller *BlockBlockRevolutionController) Click(x int, y int) {
 	if controller.IsGameOver {
 		controller.BlockBlockRevolutionWorld = nil
 		controller.Start()
+	} else {
+		controller.recorder.Click(x, y)
 	}
 }
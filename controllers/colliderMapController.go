@@ -2,28 +2,40 @@ package controllers
 
 import (
 	"encoding/json"
+	"fmt"
+	"gopherlife/geometry"
 	"gopherlife/renderers"
 	"gopherlife/world"
 	"image/color"
+	"log"
 	"net/url"
+	"path/filepath"
 	"strconv"
 	"strings"
 )
 
+//collisionLayoutDirectory the directory CollisionWorld layouts are loaded from, relative to where the server is run
+const collisionLayoutDirectory = "levels/collision"
+
+var obstacleColor = color.RGBA{128, 128, 128, 1}
+
 type CollisionWorldController struct {
 	NoPlayerInput
 	world.CollisionWorldSettings
 	*world.CollisionWorld
 	*renderers.GridRenderer
-	CreateNew func(world.CollisionWorldSettings) world.CollisionWorld
+	CreateNew func(string, world.CollisionWorldSettings) world.CollisionWorld
+	//Name the name the world's metrics are kept under
+	Name string
 }
 
 func NewCollisionWorldController() CollisionWorldController {
 
 	settings := world.CollisionWorldSettings{
-		Dimensions: world.Dimensions{Width: 75, Height: 75},
-		Population: world.Population{InitialPopulation: 500},
-		IsDiagonal: false,
+		Dimensions:  world.Dimensions{Width: 75, Height: 75},
+		Population:  world.Population{InitialPopulation: 500},
+		IsDiagonal:  false,
+		Temperature: 0.5,
 	}
 
 	renderer := renderers.NewRenderer(100, 100)
@@ -31,17 +43,19 @@ func NewCollisionWorldController() CollisionWorldController {
 
 	return CollisionWorldController{
 		CollisionWorldSettings: settings,
-		GridRenderer:         &renderer,
-		CreateNew:            world.NewCollisionWorld,
+		GridRenderer:           &renderer,
+		CreateNew:              world.NewCollisionWorld,
+		Name:                   "collision",
 	}
 }
 
 func NewDiagonalCollisionWorldController() CollisionWorldController {
 
 	settings := world.CollisionWorldSettings{
-		Dimensions: world.Dimensions{Width: 75, Height: 75},
-		Population: world.Population{InitialPopulation: 500},
-		IsDiagonal: true,
+		Dimensions:  world.Dimensions{Width: 75, Height: 75},
+		Population:  world.Population{InitialPopulation: 500},
+		IsDiagonal:  true,
+		Temperature: 0.5,
 	}
 
 	renderer := renderers.NewRenderer(100, 100)
@@ -50,20 +64,96 @@ func NewDiagonalCollisionWorldController() CollisionWorldController {
 
 	return CollisionWorldController{
 		CollisionWorldSettings: settings,
-		GridRenderer:         &renderer,
-		CreateNew:            world.NewCollisionWorld,
+		GridRenderer:           &renderer,
+		CreateNew:              world.NewCollisionWorld,
+		Name:                   "collision-diagonal",
+	}
+}
+
+//NewElasticCollisionWorldController Returns a Controller for a CollisionWorld where Colliders have mass and collide elastically
+func NewElasticCollisionWorldController() CollisionWorldController {
+
+	settings := world.CollisionWorldSettings{
+		Dimensions:        world.Dimensions{Width: 75, Height: 75},
+		Population:        world.Population{InitialPopulation: 500},
+		IsElastic:         true,
+		MaxMass:           3,
+		Temperature:       0.5,
+		SpeedDistribution: world.MaxwellBoltzmannSpeed,
+	}
+
+	renderer := renderers.NewRenderer(100, 100)
+	renderer.Shift(settings.Width/2-renderer.Width/2, settings.Height/2-renderer.Height/2)
+
+	return CollisionWorldController{
+		CollisionWorldSettings: settings,
+		GridRenderer:           &renderer,
+		CreateNew:              world.NewCollisionWorld,
+		Name:                   "collision-elastic",
+	}
+}
+
+//NewSlitCollisionWorldController Returns a Controller for an elastic CollisionWorld where every Collider starts left
+//of a wall and spreads out through a gap in the middle of it
+func NewSlitCollisionWorldController() CollisionWorldController {
+
+	d := world.Dimensions{Width: 75, Height: 75}
+	layout := world.NewSlitLayout(d, 5)
+
+	settings := world.CollisionWorldSettings{
+		Dimensions:        d,
+		Population:        world.Population{InitialPopulation: 500},
+		IsElastic:         true,
+		MaxMass:           1,
+		Temperature:       0.5,
+		SpeedDistribution: world.MaxwellBoltzmannSpeed,
+		Layout:            &layout,
+		StartOnLeft:       true,
+	}
+
+	renderer := renderers.NewRenderer(100, 100)
+	renderer.Shift(settings.Width/2-renderer.Width/2, settings.Height/2-renderer.Height/2)
+
+	return CollisionWorldController{
+		CollisionWorldSettings: settings,
+		GridRenderer:           &renderer,
+		CreateNew:              world.NewCollisionWorld,
+		Name:                   "collision-slit",
 	}
 }
 
 func (controller *CollisionWorldController) Start() {
 	if controller.CollisionWorld == nil {
-		sMap := controller.CreateNew(controller.CollisionWorldSettings)
+		sMap := controller.CreateNew(controller.Name, controller.CollisionWorldSettings)
 		controller.CollisionWorld = &sMap
 	}
 }
 
 func (controller *CollisionWorldController) MarshalJSON() ([]byte, error) {
-	return json.Marshal(controller.GridRenderer.Draw(controller))
+
+	controller.GridRenderer.Surface = controller.CollisionWorld.Surface()
+	render := controller.GridRenderer.Draw(controller)
+
+	if controller.CollisionWorld.Layout != nil || controller.CollisionWorld.StartOnLeft {
+		count := controller.SideCounts[len(controller.SideCounts)-1]
+		render.TextBelowCanvas += fmt.Sprintf("<span>Tick: %d Left: %d Right: %d</span><br />", count.Tick, count.Left, count.Right)
+	}
+
+	if controller.CollisionWorld.IsElastic {
+		report := controller.LastReport
+		render.TextBelowCanvas += fmt.Sprintf("<span>Momentum: (%.3f, %.3f) Energy: %.3f</span><br />",
+			report.MomentumAfter.X, report.MomentumAfter.Y, report.EnergyAfter)
+		render.TextBelowCanvas += fmt.Sprintf("<span>Collisions: %d Wall Impulse: (%.3f, %.3f)</span><br />",
+			report.Collisions, report.WallImpulse.X, report.WallImpulse.Y)
+
+		if err := report.Check(world.ConservationTolerance); err != nil {
+			render.TextBelowCanvas += fmt.Sprintf("<span>Conservation Failed: %v</span><br />", err)
+		} else {
+			render.TextBelowCanvas += fmt.Sprintf("<span>Momentum and Energy Conserved</span><br />")
+		}
+	}
+
+	return json.Marshal(render)
 }
 
 func (controller *CollisionWorldController) RenderTile(x int, y int) color.RGBA {
@@ -71,6 +161,8 @@ func (controller *CollisionWorldController) RenderTile(x int, y int) color.RGBA
 	if controller.Contains(x, y) {
 		if c, ok := controller.HasCollider(x, y); ok {
 			return c.Color
+		} else if controller.HasObstacle(x, y) {
+			return obstacleColor
 		} else {
 			return color.RGBA{0, 0, 0, 1}
 		}
@@ -86,8 +178,24 @@ func (controller *CollisionWorldController) PageLayout() WorldPageData {
 		FormDataWidth(settings.Width, 2),
 		FormDataHeight(settings.Height, 2),
 		FormDataInitialPopulation(settings.InitialPopulation, 2),
+		FormDataTemperature(settings.Temperature, 2),
+		FormDataSpeedDistribution(settings.SpeedDistribution, 3),
 	}
 
+	if settings.IsElastic {
+		formdataArray = append(formdataArray, FormDataMaxMass(settings.MaxMass, 2))
+	}
+
+	layoutName := ""
+	if settings.Layout != nil {
+		layoutName = settings.Layout.Name
+	}
+
+	formdataArray = append(formdataArray,
+		FormDataCollisionLayout(layoutName, 2),
+		FormDataStartOnLeft(settings.StartOnLeft, 2),
+		FormDataTopology(settings.Topology, 3))
+
 	return WorldPageData{
 		FormData: formdataArray,
 	}
@@ -105,9 +213,42 @@ func (controller *CollisionWorldController) HandleForm(values url.Values) bool {
 		controller.CollisionWorldSettings.Height = int(height)
 		controller.CollisionWorldSettings.InitialPopulation = int(initialPopulation)
 
-		gmc := world.NewCollisionWorld(controller.CollisionWorldSettings)
+		temperature, _ := strconv.ParseFloat(values.Get(FormDataTemperature(0, 0).Name), 64)
+		speedDistribution, _ := strconv.ParseInt(values.Get(FormDataSpeedDistribution(0, 0).Name), 10, 64)
+
+		controller.CollisionWorldSettings.Temperature = temperature
+		controller.CollisionWorldSettings.SpeedDistribution = world.SpeedDistribution(speedDistribution)
+
+		startOnLeft, _ := strconv.ParseInt(values.Get(FormDataStartOnLeft(false, 0).Name), 10, 64)
+		controller.CollisionWorldSettings.StartOnLeft = startOnLeft != 0
+		controller.CollisionWorldSettings.Layout = nil
+
+		topology, _ := strconv.ParseInt(values.Get(FormDataTopology(geometry.Bounded, 0).Name), 10, 64)
+		controller.CollisionWorldSettings.Topology = geometry.Topology(topology)
+
+		if layoutName := values.Get(FormDataCollisionLayout("", 0).Name); layoutName != "" {
+			path := filepath.Join(collisionLayoutDirectory, filepath.Base(layoutName)+".txt")
+			layout, err := world.LoadCollisionLayout(path, controller.CollisionWorldSettings.Dimensions)
+			if err == nil {
+				controller.CollisionWorldSettings.Layout = &layout
+			} else {
+				log.Printf("Unable to load collision layout: %v", err)
+			}
+		}
+
+		if controller.CollisionWorldSettings.IsElastic {
+			maxMass, _ := strconv.ParseInt(values.Get(FormDataMaxMass(0, 0).Name), 10, 64)
+			controller.CollisionWorldSettings.MaxMass = int(maxMass)
+		}
+
+		gmc := controller.CreateNew(controller.Name, controller.CollisionWorldSettings)
 		controller.CollisionWorld = &gmc
 
 	}
 	return true
 }
+
+//Stats Returns how many Colliders were on each side of the world for the last ticks
+func (controller *CollisionWorldController) Stats() interface{} {
+	return controller.SideCounts
+}
@@ -22,6 +22,12 @@ type KeyPresser interface {
 	KeyPress(key Keys)
 }
 
+//StatsReporter is implemented by controllers that keep statistics about their world, such as how many things were on
+//each side of it or in each state of a disease over the last ticks, or how many trees each fire burnt
+type StatsReporter interface {
+	Stats() interface{}
+}
+
 //Keys the number assigned to a keyboard 'key' when calling e.which in js
 type Keys int64
 
@@ -32,11 +38,24 @@ const (
 	UpArrow    Keys = 38
 	DownArrow  Keys = 40
 
+	IKey Keys = 73
 	PKey Keys = 80
 	QKey Keys = 81
+	SKey Keys = 83
 	WKey Keys = 87
+
+	ZeroKey Keys = 48
+	NineKey Keys = 57
 )
 
+//Number Returns the digit of a number key along the top of the keyboard, false if it is not a number key
+func (key Keys) Number() (int, bool) {
+	if key >= ZeroKey && key <= NineKey {
+		return int(key - ZeroKey), true
+	}
+	return 0, false
+}
+
 type NoPlayerInput struct{}
 
 func (controller *NoPlayerInput) Click(x int, y int) {
@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopherlife/colors"
+	"gopherlife/geometry"
+	"gopherlife/renderers"
+	"gopherlife/world"
+	"image/color"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+//flockTileColors the colour each FlockTile is drawn in
+var flockTileColors = map[world.FlockTile]color.RGBA{
+	world.EmptyFlockTile:    color.RGBA{10, 20, 50, 1},
+	world.BoidFlockTile:     colors.White,
+	world.PredatorFlockTile: colors.Red,
+	world.ObstacleFlockTile: color.RGBA{128, 128, 128, 1},
+}
+
+//FlockWorldController shows a FlockWorld. The FlockRules can be changed from the form without restarting the world
+type FlockWorldController struct {
+	NoPlayerInput
+	world.FlockWorldSettings
+	*world.FlockWorld
+	*renderers.GridRenderer
+}
+
+//NewFlockWorldController Returns a Controller with a flock of Boids, a few Predators and Obstacles on a torus
+func NewFlockWorldController() FlockWorldController {
+
+	settings := world.FlockWorldSettings{
+		Dimensions:        world.Dimensions{Width: 150, Height: 100},
+		Topology:          geometry.Torus,
+		FlockRules:        world.DefaultFlockRules,
+		NumberOfBoids:     300,
+		NumberOfPredators: 2,
+		NumberOfObstacles: 5,
+	}
+
+	renderer := renderers.NewRenderer(150, 100)
+
+	return FlockWorldController{
+		FlockWorldSettings: settings,
+		GridRenderer:       &renderer,
+	}
+}
+
+func (controller *FlockWorldController) Start() {
+	if controller.FlockWorld == nil {
+		flockWorld := world.NewFlockWorld(controller.FlockWorldSettings)
+		controller.FlockWorld = &flockWorld
+	}
+}
+
+func (controller *FlockWorldController) MarshalJSON() ([]byte, error) {
+
+	controller.GridRenderer.Surface = controller.FlockWorld.Surface()
+	render := controller.GridRenderer.Draw(controller)
+	render.TextBelowCanvas += fmt.Sprintf("<span>Tick: %d Boids: %d</span><br />", controller.Tick, len(controller.Boids))
+
+	return json.Marshal(render)
+}
+
+func (controller *FlockWorldController) RenderTile(x int, y int) color.RGBA {
+
+	if tile, ok := controller.Tile(x, y); ok {
+		return flockTileColors[tile]
+	}
+
+	return colors.Black
+}
+
+func (controller *FlockWorldController) PageLayout() WorldPageData {
+
+	settings := controller.FlockWorldSettings
+
+	return WorldPageData{
+		FormData: []FormData{
+			FormDataWidth(settings.Width, 2),
+			FormDataHeight(settings.Height, 2),
+			FormDataBoids(settings.NumberOfBoids, 2),
+			FormDataPredators(settings.NumberOfPredators, 2),
+			FormDataObstacles(settings.NumberOfObstacles, 2),
+			FormDataTopology(settings.Topology, 2),
+			FormDataSeparation(settings.Separation, 1),
+			FormDataAlignment(settings.Alignment, 1),
+			FormDataCohesion(settings.Cohesion, 1),
+			FormDataAvoidance(settings.Avoidance, 1),
+			FormDataViewRadius(settings.ViewRadius, 2),
+			FormDataSeparationRadius(settings.SeparationRadius, 2),
+			FormDataMaxSpeed(settings.MaxSpeed, 2),
+			FormDataMaxForce(settings.MaxForce, 2),
+		},
+	}
+}
+
+//HandleForm Applies the FlockRules to the running world. The world is only started again if its size, topology or
+//number of Boids, Predators or Obstacles changed
+func (controller *FlockWorldController) HandleForm(values url.Values) bool {
+
+	if !strings.Contains(values.Encode(), FormDataBoids(0, 0).Name) {
+		controller.FlockWorld = nil
+		controller.Start()
+		return true
+	}
+
+	decimal := func(fd FormData, current float64) float64 {
+		value, err := strconv.ParseFloat(values.Get(fd.Name), 64)
+		if err != nil {
+			return current
+		}
+		return value
+	}
+
+	number := func(fd FormData) int {
+		value, _ := strconv.ParseInt(values.Get(fd.Name), 10, 64)
+		return int(value)
+	}
+
+	previous := controller.FlockWorldSettings
+	settings := &controller.FlockWorldSettings
+
+	settings.Width = number(FormDataWidth(0, 0))
+	settings.Height = number(FormDataHeight(0, 0))
+	settings.NumberOfBoids = number(FormDataBoids(0, 0))
+	settings.NumberOfPredators = number(FormDataPredators(0, 0))
+	settings.NumberOfObstacles = number(FormDataObstacles(0, 0))
+	settings.Topology = geometry.Topology(number(FormDataTopology(geometry.Bounded, 0)))
+
+	settings.Separation = decimal(FormDataSeparation(0, 0), previous.Separation)
+	settings.Alignment = decimal(FormDataAlignment(0, 0), previous.Alignment)
+	settings.Cohesion = decimal(FormDataCohesion(0, 0), previous.Cohesion)
+	settings.Avoidance = decimal(FormDataAvoidance(0, 0), previous.Avoidance)
+	settings.ViewRadius = decimal(FormDataViewRadius(0, 0), previous.ViewRadius)
+	settings.SeparationRadius = decimal(FormDataSeparationRadius(0, 0), previous.SeparationRadius)
+	settings.MaxSpeed = decimal(FormDataMaxSpeed(0, 0), previous.MaxSpeed)
+	settings.MaxForce = decimal(FormDataMaxForce(0, 0), previous.MaxForce)
+
+	if controller.FlockWorld != nil && settings.Dimensions == previous.Dimensions && settings.Topology == previous.Topology &&
+		settings.NumberOfBoids == previous.NumberOfBoids && settings.NumberOfPredators == previous.NumberOfPredators &&
+		settings.NumberOfObstacles == previous.NumberOfObstacles {
+		controller.FlockWorld.FlockRules = settings.FlockRules
+		return true
+	}
+
+	controller.FlockWorld = nil
+	controller.Start()
+	return true
+}
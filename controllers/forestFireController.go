@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopherlife/colors"
+	"gopherlife/geometry"
+	"gopherlife/renderers"
+	"gopherlife/world"
+	"image/color"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+//forestTileColors the colour each ForestTile is drawn in
+var forestTileColors = map[world.ForestTile]color.RGBA{
+	world.NoTree:  color.RGBA{120, 90, 60, 1},
+	world.Tree:    color.RGBA{34, 139, 34, 1},
+	world.Burning: colors.Orange,
+	world.Burnt:   color.RGBA{60, 60, 60, 1},
+}
+
+//ForestFireController shows a ForestFire. Clicking a tree sets it alight, unless it is in Percolation mode
+type ForestFireController struct {
+	NoPlayerInput
+	world.ForestFireSettings
+	*world.ForestFire
+	*renderers.GridRenderer
+}
+
+//NewForestFireController Returns a Controller for a forest that grows and burns on a torus
+func NewForestFireController() ForestFireController {
+
+	settings := world.ForestFireSettings{
+		Dimensions:      world.Dimensions{Width: 150, Height: 100},
+		Topology:        geometry.Torus,
+		GrowthChance:    0.01,
+		LightningChance: 0.00001,
+		Density:         0.5,
+	}
+
+	renderer := renderers.NewRenderer(150, 100)
+
+	return ForestFireController{
+		ForestFireSettings: settings,
+		GridRenderer:       &renderer,
+	}
+}
+
+func (controller *ForestFireController) Start() {
+	if controller.ForestFire == nil {
+		forestFire := world.NewForestFire(controller.ForestFireSettings)
+		controller.ForestFire = &forestFire
+	}
+}
+
+//Click Sets the clicked tree alight. Clicks are ignored in Percolation mode, as they would spoil the trial
+func (controller *ForestFireController) Click(x int, y int) {
+	controller.Ignite(x, y)
+}
+
+func (controller *ForestFireController) MarshalJSON() ([]byte, error) {
+
+	controller.GridRenderer.Surface = controller.ForestFire.Surface()
+	render := controller.GridRenderer.Draw(controller)
+
+	stats := controller.ForestFireStats
+
+	render.TextBelowCanvas += fmt.Sprintf("<span>Tick: %d Trees: %d Burning: %d</span><br />",
+		controller.Tick, controller.Count(world.Tree), controller.Count(world.Burning))
+	render.TextBelowCanvas += fmt.Sprintf("<span>Fires: %d Trees Burnt: %d Cluster Sizes (by powers of 2): %v</span><br />",
+		stats.Fires, stats.TreesBurnt, stats.ClusterSizes)
+
+	if controller.ForestFire.Percolation {
+		render.TextBelowCanvas += fmt.Sprintf("<span>Density %v crossed %d of %d times (%.1f%%)</span><br />",
+			controller.ForestFire.Density, stats.Percolated, stats.PercolationTrials, stats.PercolationChance()*100)
+	}
+
+	return json.Marshal(render)
+}
+
+//Stats Returns how many trees the fires burnt, and how often fire crossed the forest in Percolation mode
+func (controller *ForestFireController) Stats() interface{} {
+	return controller.ForestFireStats
+}
+
+func (controller *ForestFireController) RenderTile(x int, y int) color.RGBA {
+
+	if tile, ok := controller.Tile(x, y); ok {
+		return forestTileColors[tile]
+	}
+
+	return offGridColor
+}
+
+func (controller *ForestFireController) PageLayout() WorldPageData {
+
+	settings := controller.ForestFireSettings
+
+	return WorldPageData{
+		FormData: []FormData{
+			FormDataWidth(settings.Width, 2),
+			FormDataHeight(settings.Height, 2),
+			FormDataGrowthChance(settings.GrowthChance, 2),
+			FormDataLightningChance(settings.LightningChance, 2),
+			FormDataTreeDensity(settings.Density, 1),
+			FormDataPercolation(settings.Percolation, 1),
+			FormDataTopology(settings.Topology, 2),
+		},
+	}
+}
+
+func (controller *ForestFireController) HandleForm(values url.Values) bool {
+
+	fd := FormDataGrowthChance(0, 0)
+	if strings.Contains(values.Encode(), fd.Name) {
+
+		width, _ := strconv.ParseInt(values.Get(FormDataWidth(0, 0).Name), 10, 64)
+		height, _ := strconv.ParseInt(values.Get(FormDataHeight(0, 0).Name), 10, 64)
+		growth, _ := strconv.ParseFloat(values.Get(fd.Name), 64)
+		lightning, _ := strconv.ParseFloat(values.Get(FormDataLightningChance(0, 0).Name), 64)
+		density, _ := strconv.ParseFloat(values.Get(FormDataTreeDensity(0, 0).Name), 64)
+		percolation, _ := strconv.ParseInt(values.Get(FormDataPercolation(false, 0).Name), 10, 64)
+		topology, _ := strconv.ParseInt(values.Get(FormDataTopology(geometry.Bounded, 0).Name), 10, 64)
+
+		controller.ForestFireSettings = world.ForestFireSettings{
+			Dimensions:      world.Dimensions{Width: int(width), Height: int(height)},
+			Topology:        geometry.Topology(topology),
+			GrowthChance:    growth,
+			LightningChance: lightning,
+			Density:         density,
+			Percolation:     percolation != 0,
+		}
+	}
+
+	controller.ForestFire = nil
+	controller.Start()
+
+	return true
+}
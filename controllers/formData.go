@@ -1,6 +1,11 @@
 package controllers
 
-import "strconv"
+import (
+	"gopherlife/geometry"
+	"gopherlife/renderers"
+	"gopherlife/world"
+	"strconv"
+)
 
 type FormData struct {
 	DisplayName        string
@@ -50,6 +55,51 @@ func FormDataMaxPopulation(maxPopulation int, bootstrapColumnWidth int) FormData
 	}
 }
 
+func FormDataMaxMass(maxMass int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Max Mass",
+		Type:               "Number",
+		Name:               "maxMass",
+		Value:              strconv.Itoa(maxMass),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+//FormDataTemperature A Text input so the Temperature can have a decimal point
+func FormDataTemperature(temperature float64, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Temperature",
+		Type:               "Text",
+		Name:               "temperature",
+		Value:              strconv.FormatFloat(temperature, 'f', -1, 64),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataSpeedDistribution(distribution world.SpeedDistribution, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Speeds (0 Constant, 1 Uniform, 2 Maxwell-Boltzmann)",
+		Type:               "Number",
+		Name:               "speedDistribution",
+		Value:              strconv.Itoa(int(distribution)),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataCollisionLayout(layout string, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Layout",
+		Type:               "Text",
+		Name:               "layout",
+		Value:              layout,
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataStartOnLeft(startOnLeft bool, bootstrapColumnWidth int) FormData {
+	return FormDataToggle("Start Left (0/1)", "startOnLeft", startOnLeft, bootstrapColumnWidth)
+}
+
 func FormDataSnakeSlowDown(slowdown int, bootstrapColumnWidth int) FormData {
 	return FormData{
 		DisplayName:        "Speed Reduction Level",
@@ -69,3 +119,479 @@ func FormDataBlockSpeedReductionSlowDown(speedReduction int, bootstrapColumnWidt
 		BootStrapFormWidth: bootstrapColumnWidth,
 	}
 }
+
+func FormDataSnakeLevel(level string, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Level",
+		Type:               "Text",
+		Name:               "level",
+		Value:              level,
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataTopology(topology geometry.Topology, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Edges (0 Walls, 1 Torus, 2 Horizontal Cylinder)",
+		Type:               "Number",
+		Name:               "topology",
+		Value:              strconv.Itoa(int(topology)),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataSnakePowerUps(powerUps bool, bootstrapColumnWidth int) FormData {
+	return FormDataToggle("Power-Ups (0/1)", "powerUps", powerUps, bootstrapColumnWidth)
+}
+
+//FormDataToggle A Number input used as an on/off switch, 1 is on and 0 is off
+func FormDataToggle(displayName string, name string, on bool, bootstrapColumnWidth int) FormData {
+
+	value := "0"
+	if on {
+		value = "1"
+	}
+
+	return FormData{
+		DisplayName:        displayName,
+		Type:               "Number",
+		Name:               name,
+		Value:              value,
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+//FormDataPlayerName the name recorded alongside high-scores
+func FormDataPlayerName(playerName string, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Player Name",
+		Type:               "Text",
+		Name:               "playerName",
+		Value:              playerName,
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataPartitionWidth(partitionWidth int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Partition Width",
+		Type:               "Number",
+		Name:               "partitionWidth",
+		Value:              strconv.Itoa(partitionWidth),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataPartitionHeight(partitionHeight int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Partition Height",
+		Type:               "Number",
+		Name:               "partitionHeight",
+		Value:              strconv.Itoa(partitionHeight),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataAdaptivePartitions(adaptive bool, bootstrapColumnWidth int) FormData {
+	return FormDataToggle("Adaptive Partitions (0/1)", "adaptivePartitions", adaptive, bootstrapColumnWidth)
+}
+
+//FormDataLineOfSight gophers only find what they can see when on
+func FormDataLineOfSight(lineOfSight bool, bootstrapColumnWidth int) FormData {
+	return FormDataToggle("Line of Sight (0/1)", "lineOfSight", lineOfSight, bootstrapColumnWidth)
+}
+
+func FormDataShowOccupancy(showOccupancy bool, bootstrapColumnWidth int) FormData {
+	return FormDataToggle("Occupancy Heatmap (0/1)", "showOccupancy", showOccupancy, bootstrapColumnWidth)
+}
+
+//FormDataLifeRule a rule in B/S notation, such as B3/S23, or the name of a rule, such as HighLife
+func FormDataLifeRule(rule string, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Rule (B/S or Name)",
+		Type:               "Text",
+		Name:               "lifeRule",
+		Value:              rule,
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+//FormDataLifePattern the name of an .rle or .cells file to start from, the world is filled randomly if it is empty
+func FormDataLifePattern(pattern string, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Pattern",
+		Type:               "Text",
+		Name:               "lifePattern",
+		Value:              pattern,
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+//FormDataDensity the percentage of cells that start alive
+func FormDataDensity(density int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Density (%)",
+		Type:               "Number",
+		Name:               "density",
+		Value:              strconv.Itoa(density),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+//FormDataTurmiteRule an ant rule such as RLR or a turmite transition table such as {{{1,2,0},{0,8,0}}}
+func FormDataTurmiteRule(rule string, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Rule (Ant or Table)",
+		Type:               "Text",
+		Name:               "turmiteRule",
+		Value:              rule,
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataTurmites(turmites int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Ants",
+		Type:               "Number",
+		Name:               "turmites",
+		Value:              strconv.Itoa(turmites),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataStepsPerUpdate(steps int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Steps Per Update",
+		Type:               "Number",
+		Name:               "stepsPerUpdate",
+		Value:              strconv.Itoa(steps),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+//FormDataDecimal A Text input so the value can have a decimal point
+func FormDataDecimal(displayName string, name string, value float64, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        displayName,
+		Type:               "Text",
+		Name:               name,
+		Value:              strconv.FormatFloat(value, 'f', -1, 64),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataBoids(boids int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Boids",
+		Type:               "Number",
+		Name:               "boids",
+		Value:              strconv.Itoa(boids),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataPredators(predators int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Predators",
+		Type:               "Number",
+		Name:               "predators",
+		Value:              strconv.Itoa(predators),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataObstacles(obstacles int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Obstacles",
+		Type:               "Number",
+		Name:               "obstacles",
+		Value:              strconv.Itoa(obstacles),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataSeparation(separation float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Separation", "separation", separation, bootstrapColumnWidth)
+}
+
+func FormDataAlignment(alignment float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Alignment", "alignment", alignment, bootstrapColumnWidth)
+}
+
+func FormDataCohesion(cohesion float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Cohesion", "cohesion", cohesion, bootstrapColumnWidth)
+}
+
+func FormDataAvoidance(avoidance float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Avoidance", "avoidance", avoidance, bootstrapColumnWidth)
+}
+
+func FormDataViewRadius(viewRadius float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("View Radius", "viewRadius", viewRadius, bootstrapColumnWidth)
+}
+
+func FormDataSeparationRadius(separationRadius float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Separation Radius", "separationRadius", separationRadius, bootstrapColumnWidth)
+}
+
+func FormDataMaxSpeed(maxSpeed float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Max Speed", "maxSpeed", maxSpeed, bootstrapColumnWidth)
+}
+
+func FormDataMaxForce(maxForce float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Max Force", "maxForce", maxForce, bootstrapColumnWidth)
+}
+
+func FormDataAnts(ants int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Ants",
+		Type:               "Number",
+		Name:               "ants",
+		Value:              strconv.Itoa(ants),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataFood(food int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Food",
+		Type:               "Number",
+		Name:               "food",
+		Value:              strconv.Itoa(food),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataNestRadius(radius int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Nest Radius",
+		Type:               "Number",
+		Name:               "nestRadius",
+		Value:              strconv.Itoa(radius),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+//FormDataEvaporation the fraction of pheromone that fades away each tick
+func FormDataEvaporation(evaporation float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Evaporation", "evaporation", evaporation, bootstrapColumnWidth)
+}
+
+//FormDataDiffusion the fraction of pheromone that spreads to the neighbouring tiles each tick
+func FormDataDiffusion(diffusion float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Diffusion", "diffusion", diffusion, bootstrapColumnWidth)
+}
+
+func FormDataShowFoodPheromone(show bool, bootstrapColumnWidth int) FormData {
+	return FormDataToggle("Food Pheromone (0/1)", "showFoodPheromone", show, bootstrapColumnWidth)
+}
+
+func FormDataShowHomePheromone(show bool, bootstrapColumnWidth int) FormData {
+	return FormDataToggle("Home Pheromone (0/1)", "showHomePheromone", show, bootstrapColumnWidth)
+}
+
+//FormDataDiseaseEnabled turns the disease on, gophers can only be infected while it is on
+func FormDataDiseaseEnabled(enabled bool, bootstrapColumnWidth int) FormData {
+	return FormDataToggle("Disease (0/1)", "diseaseEnabled", enabled, bootstrapColumnWidth)
+}
+
+func FormDataTransmissionRadius(radius int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Infection Radius",
+		Type:               "Number",
+		Name:               "transmissionRadius",
+		Value:              strconv.Itoa(radius),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+//FormDataTransmissionChance the chance out of 100 each tick that an infected gopher infects each gopher near it
+func FormDataTransmissionChance(chance int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Infection Chance %",
+		Type:               "Number",
+		Name:               "transmissionChance",
+		Value:              strconv.Itoa(chance),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataIncubationPeriod(ticks int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Incubation Period",
+		Type:               "Number",
+		Name:               "incubationPeriod",
+		Value:              strconv.Itoa(ticks),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataInfectiousPeriod(ticks int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Infectious Period",
+		Type:               "Number",
+		Name:               "infectiousPeriod",
+		Value:              strconv.Itoa(ticks),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+//FormDataMovementPenalty the chance out of 100 each tick that an infected gopher rests instead of moving
+func FormDataMovementPenalty(chance int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Illness Rest %",
+		Type:               "Number",
+		Name:               "movementPenalty",
+		Value:              strconv.Itoa(chance),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataExtraHunger(hunger int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Extra Hunger",
+		Type:               "Number",
+		Name:               "extraHunger",
+		Value:              strconv.Itoa(hunger),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+//FormDataDiseaseDeathChance the chance out of 1000 each tick that an infected gopher dies
+func FormDataDiseaseDeathChance(chance int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Death Chance (per 1000)",
+		Type:               "Number",
+		Name:               "deathChance",
+		Value:              strconv.Itoa(chance),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataMazeGenerator(generator world.MazeGenerator, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Generator (0 Backtracker, 1 Prim's, 2 Kruskal's, 3 Wilson's)",
+		Type:               "Number",
+		Name:               "mazeGenerator",
+		Value:              strconv.Itoa(int(generator)),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataMazeSolver(solver world.MazeSolver, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Solver (0 BFS, 1 DFS, 2 A*, 3 Wall Follower)",
+		Type:               "Number",
+		Name:               "mazeSolver",
+		Value:              strconv.Itoa(int(solver)),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+//FormDataGrowthChance the chance each tick that a tree grows on an empty tile
+func FormDataGrowthChance(chance float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Growth Chance (p)", "growthChance", chance, bootstrapColumnWidth)
+}
+
+//FormDataLightningChance the chance each tick that lightning strikes a tree
+func FormDataLightningChance(chance float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Lightning Chance (f)", "lightningChance", chance, bootstrapColumnWidth)
+}
+
+//FormDataTreeDensity the fraction of tiles planted with trees, between 0 and 1
+func FormDataTreeDensity(density float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Tree Density", "treeDensity", density, bootstrapColumnWidth)
+}
+
+func FormDataPercolation(percolation bool, bootstrapColumnWidth int) FormData {
+	return FormDataToggle("Percolation (0/1)", "percolation", percolation, bootstrapColumnWidth)
+}
+
+func FormDataLanes(lanes int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Lanes",
+		Type:               "Number",
+		Name:               "lanes",
+		Value:              strconv.Itoa(lanes),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataIntersections(intersections int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Intersections",
+		Type:               "Number",
+		Name:               "intersections",
+		Value:              strconv.Itoa(intersections),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+func FormDataMaxVelocity(velocity int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Max Velocity",
+		Type:               "Number",
+		Name:               "maxVelocity",
+		Value:              strconv.Itoa(velocity),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+//FormDataLightCycle the number of ticks each traffic light stays green for one road
+func FormDataLightCycle(ticks int, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Light Cycle",
+		Type:               "Number",
+		Name:               "lightCycle",
+		Value:              strconv.Itoa(ticks),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+//FormDataCarDensity the fraction of road tiles with a car on, between 0 and 1
+func FormDataCarDensity(density float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Car Density", "carDensity", density, bootstrapColumnWidth)
+}
+
+//FormDataSlowdownChance the chance each tick that a car slows down at random
+func FormDataSlowdownChance(chance float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Slowdown Chance", "slowdownChance", chance, bootstrapColumnWidth)
+}
+
+func FormDataGrayScottPreset(preset world.GrayScottPreset, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Preset (0 Custom, 1 Mitosis, 2 Coral, 3 Spots, 4 Worms, 5 Labyrinth, 6 Holes, 7 Chaos)",
+		Type:               "Number",
+		Name:               "grayScottPreset",
+		Value:              strconv.Itoa(int(preset)),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
+
+//FormDataFeedRate how quickly chemical A is added back to a reaction diffusion grid
+func FormDataFeedRate(feed float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Feed Rate (f)", "feedRate", feed, bootstrapColumnWidth)
+}
+
+//FormDataKillRate how quickly chemical B is removed from a reaction diffusion grid
+func FormDataKillRate(kill float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Kill Rate (k)", "killRate", kill, bootstrapColumnWidth)
+}
+
+func FormDataDiffusionA(diffusion float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Diffusion A", "diffusionA", diffusion, bootstrapColumnWidth)
+}
+
+func FormDataDiffusionB(diffusion float64, bootstrapColumnWidth int) FormData {
+	return FormDataDecimal("Diffusion B", "diffusionB", diffusion, bootstrapColumnWidth)
+}
+
+func FormDataColorMap(colorMap renderers.ColorMap, bootstrapColumnWidth int) FormData {
+	return FormData{
+		DisplayName:        "Colour Map (0 Viridis, 1 Magma, 2 Grayscale)",
+		Type:               "Number",
+		Name:               "colorMap",
+		Value:              strconv.Itoa(int(colorMap)),
+		BootStrapFormWidth: bootstrapColumnWidth,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"gopherlife/colors"
+	"gopherlife/geometry"
 	"gopherlife/renderers"
 	"gopherlife/world"
 	"image/color"
@@ -24,12 +25,33 @@ var (
 	foodColor          = color.RGBA{204, 112, 0, 1}
 	decayedGopherColor = color.RGBA{0, 0, 0, 1}
 	grassColor         = color.RGBA{65, 119, 15, 1}
+
+	infectedGopherColor = color.RGBA{220, 20, 60, 1}
+	exposedGopherColor  = color.RGBA{255, 120, 80, 1}
 )
 
+//GopherWorldController shows a GopherWorld. The GopherWorld is built from the GopherWorldSettings when it is started
 type GopherWorldController struct {
+	world.GopherWorldSettings
 	*world.GopherWorld
 	*renderers.GridRenderer
 	CreateNew func(world.GopherWorldSettings) *world.GopherWorld
+
+	//ShowOccupancy tints each partition of a partitioned world by how full it is
+	ShowOccupancy bool
+	//NoTopology leaves the Topology off the form for worlds whose containers have no edges that can be joined
+	NoTopology bool
+}
+
+//PartitionedContainer a container split into partitions that can say how full the partition holding x and y is
+type PartitionedContainer interface {
+	Occupancy(x int, y int) (float64, bool)
+	PartitionSize() (int, int)
+}
+
+//ChunkedContainer a container that creates chunks of tiles as they are needed
+type ChunkedContainer interface {
+	Chunks() int
 }
 
 //NewGopherWorldWithSpiralSearch Returns a Controller with a Gopher World. Where Gophers search for food using a Spiral To Nearest Search
@@ -40,40 +62,104 @@ func NewGopherWorldWithSpiralSearch() GopherWorldController {
 		Population:      world.Population{InitialPopulation: 5000, MaxPopulation: 1000000},
 		NumberOfFood:    1000000,
 		GopherBirthRate: 7,
+		Disease:         world.DefaultDisease,
 	}
 
-	gWorld := world.CreateGopherWorldSpiralSearch(settings)
 	renderer := renderers.NewRenderer(100, 100)
 	return GopherWorldController{
-		GopherWorld:  gWorld,
-		GridRenderer: &renderer,
-		CreateNew:    world.CreateGopherWorldSpiralSearch,
+		GopherWorldSettings: settings,
+		GridRenderer:        &renderer,
+		CreateNew:           world.CreateGopherWorldSpiralSearch,
 	}
 }
 
 //NewGopherWorldWithParitionGridAndSearch Returns a Controller with a Gopher Map. Where Gophers search for food using Grid Partition
 func NewGopherWorldWithParitionGridAndSearch() GopherWorldController {
 
+	settings := world.GopherWorldSettings{
+		Dimensions:      world.Dimensions{Width: 3000, Height: 3000},
+		Population:      world.Population{InitialPopulation: 5000, MaxPopulation: 1000000},
+		Partition:       world.Partition{PartitionWidth: world.DefaultPartitionSize, PartitionHeight: world.DefaultPartitionSize},
+		NumberOfFood:    1000000,
+		GopherBirthRate: 7,
+		Disease:         world.DefaultDisease,
+	}
+
+	renderer := renderers.NewRenderer(100, 100)
+	return GopherWorldController{
+		GopherWorldSettings: settings,
+		GridRenderer:        &renderer,
+		CreateNew:           world.CreateGopherWorldGridPartition,
+	}
+}
+
+//NewGopherWorldWithIndexedSearch Returns a Controller with a Gopher Map. Where Gophers search for food using a QuadTree index
+func NewGopherWorldWithIndexedSearch() GopherWorldController {
+
 	settings := world.GopherWorldSettings{
 		Dimensions:      world.Dimensions{Width: 3000, Height: 3000},
 		Population:      world.Population{InitialPopulation: 5000, MaxPopulation: 1000000},
 		NumberOfFood:    1000000,
 		GopherBirthRate: 7,
+		Disease:         world.DefaultDisease,
+	}
+
+	renderer := renderers.NewRenderer(100, 100)
+	return GopherWorldController{
+		GopherWorldSettings: settings,
+		GridRenderer:        &renderer,
+		CreateNew:           world.CreateGopherWorldIndexedSearch,
+	}
+}
+
+//NewGopherWorldInfinite Returns a Controller with a Gopher World that has no edges. Gophers start in the middle and can
+//spread outwards forever
+func NewGopherWorldInfinite() GopherWorldController {
+
+	settings := world.GopherWorldSettings{
+		Dimensions:      world.Dimensions{Width: 500, Height: 500},
+		Population:      world.Population{InitialPopulation: 2000, MaxPopulation: 1000000},
+		NumberOfFood:    50000,
+		GopherBirthRate: 7,
+		Disease:         world.DefaultDisease,
 	}
 
-	gWorld := world.CreateGopherWorldGridPartition(settings)
 	renderer := renderers.NewRenderer(100, 100)
+	renderer.Shift(settings.Width/2-renderer.Width/2, settings.Height/2-renderer.Height/2)
 	return GopherWorldController{
-		GopherWorld:  gWorld,
-		GridRenderer: &renderer,
-		CreateNew:    world.CreateGopherWorldGridPartition,
+		GopherWorldSettings: settings,
+		GridRenderer:        &renderer,
+		CreateNew:           world.CreateGopherWorldInfinite,
+		NoTopology:          true,
+	}
+}
+
+//NewGopherWorldHex Returns a Controller with a Gopher World made of hexagons. Where Gophers step onto any of the 6
+//hexagons around them
+func NewGopherWorldHex() GopherWorldController {
+
+	settings := world.GopherWorldSettings{
+		Dimensions:      world.Dimensions{Width: 1000, Height: 1000},
+		Population:      world.Population{InitialPopulation: 2000, MaxPopulation: 1000000},
+		NumberOfFood:    100000,
+		GopherBirthRate: 7,
+		Disease:         world.DefaultDisease,
+	}
+
+	renderer := renderers.NewRenderer(100, 100)
+	renderer.Hex = true
+	return GopherWorldController{
+		GopherWorldSettings: settings,
+		GridRenderer:        &renderer,
+		CreateNew:           world.CreateGopherWorldHex,
+		NoTopology:          true,
 	}
 }
 
 //Start Initiates the controller. If the Map does not exist. The Map will be built
 func (controller *GopherWorldController) Start() {
 	if controller.GopherWorld == nil {
-		controller.GopherWorld = controller.CreateNew(*controller.GopherWorldSettings)
+		controller.GopherWorld = controller.CreateNew(controller.GopherWorldSettings)
 	}
 }
 
@@ -81,7 +167,7 @@ func (controller *GopherWorldController) Start() {
 func (controller *GopherWorldController) Click(x int, y int) {
 
 	action := func() {
-		_, ok := controller.SelectEntity(x, y)
+		_, ok := controller.SelectEntity(controller.position(x, y))
 
 		if !ok {
 			controller.GridRenderer.StartX = x - controller.GridRenderer.Width/2
@@ -103,6 +189,12 @@ func (controller *GopherWorldController) KeyPress(key Keys) {
 		controller.GopherWorld.Add(func() {
 			controller.SelectRandomGopher()
 		})
+	case IKey:
+		controller.GopherWorld.Add(func() {
+			if gopher := controller.SelectedGopher; gopher != nil {
+				controller.InfectGopher(gopher.Position.GetX(), gopher.Position.GetY())
+			}
+		})
 	case PKey:
 		controller.TogglePause()
 	case LeftArrow:
@@ -123,6 +215,15 @@ func TileToColor(tile *world.GopherWorldTile, isSelected bool) color.RGBA {
 		return grassColor
 	case tile.Gopher != nil:
 
+		if !isSelected && !tile.Gopher.IsDead {
+			switch tile.Gopher.Infection {
+			case world.Infected:
+				return infectedGopherColor
+			case world.Exposed:
+				return exposedGopherColor
+			}
+		}
+
 		switch tile.Gopher.Gender {
 		case world.Male:
 			if isSelected {
@@ -156,6 +257,40 @@ func TileToColor(tile *world.GopherWorldTile, isSelected bool) color.RGBA {
 
 func (controller *GopherWorldController) RenderTile(x int, y int) color.RGBA {
 
+	tileColor := controller.renderTile(x, y)
+
+	if controller.ShowOccupancy {
+		if partitions, ok := controller.TileContainer.(PartitionedContainer); ok {
+			if occupancy, ok := partitions.Occupancy(x, y); ok {
+				return colors.Blend(tileColor, colors.Red, occupancy)
+			}
+		}
+	}
+
+	return tileColor
+}
+
+//position Returns the position in the world of the tile the renderer draws at x and y
+func (controller *GopherWorldController) position(x int, y int) (int, int) {
+	if controller.GridRenderer.Hex {
+		h := geometry.HexFromOffset(x, y)
+		return h.Q, h.R
+	}
+	return x, y
+}
+
+//offset Returns where the renderer draws the tile at the given position in the world
+func (controller *GopherWorldController) offset(position geometry.Coordinates) (int, int) {
+	if controller.GridRenderer.Hex {
+		return geometry.HexFromCoordinates(position).Offset()
+	}
+	return position.X, position.Y
+}
+
+func (controller *GopherWorldController) renderTile(x int, y int) color.RGBA {
+
+	x, y = controller.position(x, y)
+
 	if tile, ok := controller.Tile(x, y); ok {
 
 		switch {
@@ -187,10 +322,12 @@ type GopherWorldRender struct {
 func (controller *GopherWorldController) MarshalJSON() ([]byte, error) {
 
 	if controller.SelectedGopher != nil {
-		controller.GridRenderer.StartX = controller.SelectedGopher.Position.GetX() - controller.GridRenderer.Width/2
-		controller.GridRenderer.StartY = controller.SelectedGopher.Position.GetY() - controller.GridRenderer.Height/2
+		x, y := controller.offset(controller.SelectedGopher.Position)
+		controller.GridRenderer.StartX = x - controller.GridRenderer.Width/2
+		controller.GridRenderer.StartY = y - controller.GridRenderer.Height/2
 	}
 
+	controller.GridRenderer.Surface = controller.GopherWorld.Surface()
 	render := controller.GridRenderer.Draw(controller)
 
 	diagnostics := controller.Diagnostics()
@@ -199,10 +336,34 @@ func (controller *GopherWorldController) MarshalJSON() ([]byte, error) {
 	renderString += "<br />"
 	renderString += fmt.Sprintf("<span>Number of Gophers: %d </span><br />", controller.NumberOfGophers)
 	renderString += fmt.Sprintf("<span>Avg Processing Time (s): %s </span><br />", diagnostics.ProcessStopWatch.GetAverage().String())
+	renderString += fmt.Sprintf("<span>p95 / p99 / Max Processing Time (s): %s / %s / %s </span><br />",
+		diagnostics.ProcessStopWatch.GetPercentile(95).String(),
+		diagnostics.ProcessStopWatch.GetPercentile(99).String(),
+		diagnostics.ProcessStopWatch.GetMax().String())
+	renderString += fmt.Sprintf("<span>Avg / p99 Tick Time (s): %s / %s </span><br />",
+		diagnostics.TickStopWatch.GetAverage().String(),
+		diagnostics.TickStopWatch.GetPercentile(99).String())
 	renderString += fmt.Sprintf("<span>Avg Gopher Time (s): %s </span><br />", diagnostics.GopherStopWatch.GetAverage().String())
 	renderString += fmt.Sprintf("<span; >Avg Input Time (s): %s </span><br />", diagnostics.InputStopWatch.GetAverage().String())
 	renderString += fmt.Sprintf("<span>Total Elasped Time (s): %s </span><br />", diagnostics.GlobalStopWatch.GetCurrentElaspedTime().String())
 
+	if counts := controller.EpidemicCounts; len(counts) > 0 && controller.PatientZero != nil {
+		count := counts[len(counts)-1]
+		renderString += fmt.Sprintf("<span>Patient Zero: %s Susceptible: %d Exposed: %d Infected: %d Recovered: %d </span><br />",
+			controller.PatientZero.Name, count.Susceptible, count.Exposed, count.Infected, count.Recovered)
+	} else if controller.Disease.Enabled {
+		renderString += "<span>Press I to make the selected gopher patient zero</span><br />"
+	}
+
+	if partitions, ok := controller.TileContainer.(PartitionedContainer); ok {
+		partitionWidth, partitionHeight := partitions.PartitionSize()
+		renderString += fmt.Sprintf("<span>Partition Size: %dx%d </span><br />", partitionWidth, partitionHeight)
+	}
+
+	if chunks, ok := controller.TileContainer.(ChunkedContainer); ok {
+		renderString += fmt.Sprintf("<span>Chunks in Memory: %d </span><br />", chunks.Chunks())
+	}
+
 	render.TextBelowCanvas = renderString
 
 	gmr := GopherWorldRender{
@@ -220,12 +381,30 @@ func (controller *GopherWorldController) MarshalJSON() ([]byte, error) {
 func (controller *GopherWorldController) PageLayout() WorldPageData {
 
 	settings := controller.GopherWorldSettings
+	partitioned := settings.PartitionWidth > 0
+	partitionWidth, partitionHeight := settings.PartitionWidth, settings.PartitionHeight
+
+	//Once the world is built show the settings it is using, adaptive partitions change size as it runs
+	if controller.GopherWorld != nil {
+		settings = *controller.GopherWorld.GopherWorldSettings
+		partitions, ok := controller.TileContainer.(PartitionedContainer)
+		if partitioned = ok; ok {
+			partitionWidth, partitionHeight = partitions.PartitionSize()
+		}
+	}
 
 	formdataArray := []FormData{
 		FormDataWidth(settings.Width, 2),
 		FormDataHeight(settings.Height, 2),
 		FormDataInitialPopulation(settings.InitialPopulation, 2),
 		FormDataMaxPopulation(settings.MaxPopulation, 2),
+	}
+
+	if !controller.NoTopology {
+		formdataArray = append(formdataArray, FormDataTopology(settings.Topology, 3))
+	}
+
+	formdataArray = append(formdataArray,
 		FormData{
 			DisplayName:        "Birth Rate",
 			Type:               "Number",
@@ -240,6 +419,24 @@ func (controller *GopherWorldController) PageLayout() WorldPageData {
 			Value:              strconv.Itoa(settings.NumberOfFood),
 			BootStrapFormWidth: 2,
 		},
+		FormDataDiseaseEnabled(settings.Disease.Enabled, 2),
+		FormDataTransmissionRadius(settings.Disease.TransmissionRadius, 2),
+		FormDataTransmissionChance(settings.Disease.TransmissionChance, 2),
+		FormDataIncubationPeriod(settings.Disease.IncubationPeriod, 2),
+		FormDataInfectiousPeriod(settings.Disease.InfectiousPeriod, 2),
+		FormDataMovementPenalty(settings.Disease.MovementPenalty, 2),
+		FormDataExtraHunger(settings.Disease.ExtraHunger, 1),
+		FormDataDiseaseDeathChance(settings.Disease.DeathChance, 1),
+		FormDataLineOfSight(settings.LineOfSight, 2),
+	)
+
+	if partitioned {
+		formdataArray = append(formdataArray,
+			FormDataPartitionWidth(partitionWidth, 2),
+			FormDataPartitionHeight(partitionHeight, 2),
+			FormDataAdaptivePartitions(settings.AdaptivePartitions, 2),
+			FormDataShowOccupancy(controller.ShowOccupancy, 2),
+		)
 	}
 
 	return WorldPageData{
@@ -259,27 +456,67 @@ func (controller *GopherWorldController) HandleForm(values url.Values) bool {
 		numberOfFood, _ := strconv.ParseInt(values.Get("numberOfFood"), 10, 64)
 		birthRate, _ := strconv.ParseInt(values.Get("birthRate"), 10, 64)
 		maxPopulation, _ := strconv.ParseInt(values.Get("maxPopulation"), 10, 64)
+		partitionWidth, _ := strconv.ParseInt(values.Get(FormDataPartitionWidth(0, 0).Name), 10, 64)
+		partitionHeight, _ := strconv.ParseInt(values.Get(FormDataPartitionHeight(0, 0).Name), 10, 64)
+		adaptivePartitions, _ := strconv.ParseInt(values.Get(FormDataAdaptivePartitions(false, 0).Name), 10, 64)
+		showOccupancy, _ := strconv.ParseInt(values.Get(FormDataShowOccupancy(false, 0).Name), 10, 64)
+		topology, _ := strconv.ParseInt(values.Get(FormDataTopology(geometry.Bounded, 0).Name), 10, 64)
+		transmissionRadius, _ := strconv.ParseInt(values.Get(FormDataTransmissionRadius(0, 0).Name), 10, 64)
+		transmissionChance, _ := strconv.ParseInt(values.Get(FormDataTransmissionChance(0, 0).Name), 10, 64)
+		incubationPeriod, _ := strconv.ParseInt(values.Get(FormDataIncubationPeriod(0, 0).Name), 10, 64)
+		infectiousPeriod, _ := strconv.ParseInt(values.Get(FormDataInfectiousPeriod(0, 0).Name), 10, 64)
+		movementPenalty, _ := strconv.ParseInt(values.Get(FormDataMovementPenalty(0, 0).Name), 10, 64)
+		extraHunger, _ := strconv.ParseInt(values.Get(FormDataExtraHunger(0, 0).Name), 10, 64)
+		deathChance, _ := strconv.ParseInt(values.Get(FormDataDiseaseDeathChance(0, 0).Name), 10, 64)
+		lineOfSight, _ := strconv.ParseInt(values.Get(FormDataLineOfSight(false, 0).Name), 10, 64)
+		diseaseEnabled, _ := strconv.ParseInt(values.Get(FormDataDiseaseEnabled(false, 0).Name), 10, 64)
 
 		settings := world.GopherWorldSettings{
-			Dimensions:      world.Dimensions{Width: int(width), Height: int(height)},
-			Population:      world.Population{InitialPopulation: int(InitialPopulation), MaxPopulation: int(maxPopulation)},
+			Dimensions: world.Dimensions{Width: int(width), Height: int(height)},
+			Population: world.Population{InitialPopulation: int(InitialPopulation), MaxPopulation: int(maxPopulation)},
+			Partition: world.Partition{
+				PartitionWidth:     int(partitionWidth),
+				PartitionHeight:    int(partitionHeight),
+				AdaptivePartitions: adaptivePartitions != 0,
+			},
 			NumberOfFood:    int(numberOfFood),
 			GopherBirthRate: int(birthRate),
+			Topology:        geometry.Topology(topology),
+			Disease: world.Disease{
+				Enabled:            diseaseEnabled != 0,
+				TransmissionRadius: int(transmissionRadius),
+				TransmissionChance: int(transmissionChance),
+				IncubationPeriod:   int(incubationPeriod),
+				InfectiousPeriod:   int(infectiousPeriod),
+				MovementPenalty:    int(movementPenalty),
+				ExtraHunger:        int(extraHunger),
+				DeathChance:        int(deathChance),
+			},
+			LineOfSight: lineOfSight != 0,
 		}
 
-		gmc := controller.CreateNew(settings)
-		controller.GopherWorld = gmc
+		controller.ShowOccupancy = showOccupancy != 0
+
+		controller.GopherWorldSettings = settings
+		controller.GopherWorld = controller.CreateNew(settings)
 
 	}
 
 	return true
 }
 
+//Stats Returns how many gophers were susceptible, exposed, infected and recovered for the last ticks
+func (controller *GopherWorldController) Stats() interface{} {
+	return controller.EpidemicCounts
+}
+
 type SpiralWorldController struct {
 	NoPlayerInput
 	world.SpiralWorldSettings
 	*world.SpiralWorld
 	*renderers.GridRenderer
+	//Name the name the world's metrics are kept under
+	Name string
 }
 
 func NewSpiralWorldController() SpiralWorldController {
@@ -296,6 +533,7 @@ func NewSpiralWorldController() SpiralWorldController {
 	return SpiralWorldController{
 		GridRenderer:        &renderer,
 		SpiralWorldSettings: settings,
+		Name:                "spiral",
 	}
 }
 
@@ -313,12 +551,13 @@ func NewWeirdSpiralWorldController() SpiralWorldController {
 	return SpiralWorldController{
 		GridRenderer:        &renderer,
 		SpiralWorldSettings: settings,
+		Name:                "weird-spiral",
 	}
 }
 
 func (controller *SpiralWorldController) Start() {
 	if controller.SpiralWorld == nil {
-		sMap := world.NewSpiralWorld(controller.SpiralWorldSettings)
+		sMap := world.NewSpiralWorld(controller.Name, controller.SpiralWorldSettings)
 		controller.SpiralWorld = &sMap
 	}
 }
@@ -378,7 +617,7 @@ func NewFireWorksController() FireWorksController {
 
 func (controller *FireWorksController) Start() {
 	if controller.GopherWorld == nil {
-		controller.GopherWorld = world.CreateGopherWorldSpiralSearch(controller.GopherWorldSettings)
+		controller.GopherWorld = world.CreateGopherWorldFireWorks(controller.GopherWorldSettings)
 	}
 }
 
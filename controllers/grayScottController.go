@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopherlife/geometry"
+	"gopherlife/renderers"
+	"gopherlife/world"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+//GrayScottController shows the amount of B in a GrayScott as a heatmap. Clicking drops more B into the grid
+type GrayScottController struct {
+	NoPlayerInput
+	world.GrayScottSettings
+	*world.GrayScott
+	*renderers.GridRenderer
+	ColorMap renderers.ColorMap
+}
+
+//NewGrayScottController Returns a Controller for a reaction diffusion grid on a torus growing coral
+func NewGrayScottController() GrayScottController {
+
+	settings := world.GrayScottSettings{
+		Dimensions:     world.Dimensions{Width: 150, Height: 100},
+		Topology:       geometry.Torus,
+		Preset:         world.Coral,
+		DiffusionA:     1,
+		DiffusionB:     0.5,
+		StepsPerUpdate: 10,
+	}
+
+	renderer := renderers.NewRenderer(150, 100)
+
+	return GrayScottController{
+		GrayScottSettings: settings,
+		GridRenderer:      &renderer,
+		ColorMap:          renderers.Viridis,
+	}
+}
+
+func (controller *GrayScottController) Start() {
+	if controller.GrayScott == nil {
+		grayScott := world.NewGrayScott(controller.GrayScottSettings)
+		controller.GrayScott = &grayScott
+	}
+}
+
+//Click Drops B into the cells around the clicked cell
+func (controller *GrayScottController) Click(x int, y int) {
+	controller.AddB(x, y, 5)
+}
+
+func (controller *GrayScottController) MarshalJSON() ([]byte, error) {
+
+	min, max := controller.RangeB()
+
+	controller.GridRenderer.Surface = controller.GrayScott.Surface()
+	render := controller.GridRenderer.DrawHeatmap(renderers.Heatmap{
+		Field:    controller.GrayScott,
+		ColorMap: controller.ColorMap,
+		Min:      min,
+		Max:      max,
+		OffField: offGridColor,
+	})
+
+	grayScott := controller.GrayScott
+
+	render.TextBelowCanvas += fmt.Sprintf("<span>Step: %d Preset: %v Feed: %v Kill: %v</span><br />",
+		grayScott.Step, grayScott.Preset, grayScott.Feed, grayScott.Kill)
+	render.TextBelowCanvas += fmt.Sprintf("<span>B from %.3f to %.3f drawn in %v</span><br />",
+		min, max, controller.ColorMap)
+
+	return json.Marshal(render)
+}
+
+func (controller *GrayScottController) PageLayout() WorldPageData {
+
+	settings := controller.GrayScottSettings
+
+	//A preset replaces the feed and kill rates, show the ones being used
+	if controller.GrayScott != nil {
+		settings.Feed, settings.Kill = controller.GrayScott.Feed, controller.GrayScott.Kill
+	}
+
+	return WorldPageData{
+		FormData: []FormData{
+			FormDataWidth(settings.Width, 2),
+			FormDataHeight(settings.Height, 2),
+			FormDataGrayScottPreset(settings.Preset, 2),
+			FormDataFeedRate(settings.Feed, 1),
+			FormDataKillRate(settings.Kill, 1),
+			FormDataDiffusionA(settings.DiffusionA, 1),
+			FormDataDiffusionB(settings.DiffusionB, 1),
+			FormDataStepsPerUpdate(settings.StepsPerUpdate, 1),
+			FormDataColorMap(controller.ColorMap, 1),
+			FormDataTopology(settings.Topology, 2),
+		},
+	}
+}
+
+func (controller *GrayScottController) HandleForm(values url.Values) bool {
+
+	fd := FormDataGrayScottPreset(world.CustomPreset, 0)
+	if strings.Contains(values.Encode(), fd.Name) {
+
+		width, _ := strconv.ParseInt(values.Get(FormDataWidth(0, 0).Name), 10, 64)
+		height, _ := strconv.ParseInt(values.Get(FormDataHeight(0, 0).Name), 10, 64)
+		preset, _ := strconv.ParseInt(values.Get(fd.Name), 10, 64)
+		feed, _ := strconv.ParseFloat(values.Get(FormDataFeedRate(0, 0).Name), 64)
+		kill, _ := strconv.ParseFloat(values.Get(FormDataKillRate(0, 0).Name), 64)
+		diffusionA, _ := strconv.ParseFloat(values.Get(FormDataDiffusionA(0, 0).Name), 64)
+		diffusionB, _ := strconv.ParseFloat(values.Get(FormDataDiffusionB(0, 0).Name), 64)
+		steps, _ := strconv.ParseInt(values.Get(FormDataStepsPerUpdate(0, 0).Name), 10, 64)
+		colorMap, _ := strconv.ParseInt(values.Get(FormDataColorMap(renderers.Viridis, 0).Name), 10, 64)
+		topology, _ := strconv.ParseInt(values.Get(FormDataTopology(geometry.Bounded, 0).Name), 10, 64)
+
+		controller.GrayScottSettings = world.GrayScottSettings{
+			Dimensions:     world.Dimensions{Width: int(width), Height: int(height)},
+			Topology:       geometry.Topology(topology),
+			Preset:         world.GrayScottPreset(preset),
+			Feed:           feed,
+			Kill:           kill,
+			DiffusionA:     diffusionA,
+			DiffusionB:     diffusionB,
+			StepsPerUpdate: int(steps),
+		}
+
+		controller.ColorMap = renderers.ColorMap(colorMap)
+	}
+
+	controller.GrayScott = nil
+	controller.Start()
+
+	return true
+}
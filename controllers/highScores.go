@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"fmt"
+	"gopherlife/highscores"
+	"html"
+	"log"
+	"time"
+)
+
+//HighScoreRecorder is implemented by controllers of games that keep a high-score table
+type HighScoreRecorder interface {
+	HighScores() highscores.Table
+}
+
+//highScoreTracker records the score of a finished game into a high-score Store once per game
+type highScoreTracker struct {
+	store *highscores.Store
+	game  string
+
+	started  time.Time
+	recorded bool
+	rank     int
+	ranked   bool
+}
+
+func newHighScoreTracker(store *highscores.Store, game string) highScoreTracker {
+	return highScoreTracker{store: store, game: game}
+}
+
+//begin Marks the start of a new game
+func (tracker *highScoreTracker) begin() {
+	tracker.started = time.Now()
+	tracker.recorded = false
+	tracker.ranked = false
+}
+
+//finish Adds the score to the high-score table, if it has not already been added for this game
+func (tracker *highScoreTracker) finish(playerName string, settings string, score int) {
+
+	if tracker.store == nil || tracker.recorded {
+		return
+	}
+
+	tracker.recorded = true
+
+	if playerName == "" {
+		playerName = "Anonymous Gopher"
+	}
+
+	entry := highscores.Entry{
+		Name:   playerName,
+		Score:  score,
+		Date:   time.Now(),
+		Length: time.Since(tracker.started).Round(time.Second),
+	}
+
+	rank, ok, err := tracker.store.Add(tracker.game, settings, entry)
+
+	if err != nil {
+		log.Printf("Unable to save high-score: %v", err)
+	}
+
+	tracker.rank, tracker.ranked = rank, ok
+}
+
+//table Returns the high-score table for the given settings
+func (tracker *highScoreTracker) table(settings string) highscores.Table {
+
+	if tracker.store == nil {
+		return highscores.Table{Game: tracker.game, Settings: settings}
+	}
+
+	return tracker.store.Table(tracker.game, settings)
+}
+
+//tableHTML Renders the high-score table for the given settings to be shown below the canvas. The score from the
+//last finished game is highlighted
+func (tracker *highScoreTracker) tableHTML(settings string) string {
+
+	table := tracker.table(settings)
+
+	renderString := fmt.Sprintf("<span>High-Scores (%s)</span><br />", html.EscapeString(settings))
+	renderString += `<table class="table table-sm table-bordered w-auto mx-auto">`
+	renderString += "<thead><tr><th>#</th><th>Name</th><th>Score</th><th>Length</th><th>Date</th></tr></thead><tbody>"
+
+	for i, entry := range table.Entries {
+
+		rowClass := ""
+		if tracker.recorded && tracker.ranked && tracker.rank == i {
+			rowClass = ` class="table-success"`
+		}
+
+		renderString += fmt.Sprintf("<tr%s><td>%d</td><td>%s</td><td>%d</td><td>%s</td><td>%s</td></tr>",
+			rowClass, i+1, html.EscapeString(entry.Name), entry.Score, entry.Length, entry.Date.Format("2006-01-02"))
+	}
+
+	renderString += "</tbody></table>"
+
+	return renderString
+}
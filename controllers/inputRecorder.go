@@ -0,0 +1,338 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+//ReplayDirectory the directory replays are saved to, relative to where the server is run
+const ReplayDirectory = "replays"
+
+//fastReplayUpdates the number of world updates run for each controller Update when replaying at fast speed
+const fastReplayUpdates = 5
+
+//InputType the type of user input stored in a replay
+type InputType string
+
+//Types of user input that can be recorded
+const (
+	KeyPressInput InputType = "KeyPress"
+	ClickInput    InputType = "Click"
+)
+
+//InputEvent a user input and the world tick it was applied on
+type InputEvent struct {
+	Tick int
+	Type InputType
+	Key  Keys `json:",omitempty"`
+	X    int  `json:",omitempty"`
+	Y    int  `json:",omitempty"`
+}
+
+//Replay everything needed to play a game again: the settings, the seed of the world's random numbers and the
+//input applied on each tick
+type Replay struct {
+	Game       string
+	Seed       int64
+	Settings   json.RawMessage
+	Events     []InputEvent
+	Ticks      int
+	FinalScore int
+}
+
+//ReplayResult the outcome of verifying a Replay
+type ReplayResult struct {
+	ExpectedScore int
+	ActualScore   int
+	Ticks         int
+	Matches       bool
+}
+
+//ReplayController is implemented by controllers of games that can be recorded and played back
+type ReplayController interface {
+	SaveReplay(name string) error
+	PlayReplay(name string, fast bool) error
+	VerifyReplay(name string) (ReplayResult, error)
+}
+
+//replayableGame a game that can be driven tick by tick by an InputRecorder. loadReplay replaces the game's world
+//with a new world using the replay's settings and seed
+type replayableGame interface {
+	loadReplay(replay Replay) error
+	applyInput(event InputEvent)
+	updateWorld() bool
+	tick() int
+	score() int
+	isGameOver() bool
+}
+
+//InputRecorder buffers user input so that it is applied at the start of a world tick. Live games and replays
+//therefore go through exactly the same steps, which makes games with the same seed and input repeatable
+type InputRecorder struct {
+	mutex sync.Mutex
+
+	pending   []InputEvent
+	recording Replay
+
+	playback  *Replay
+	nextEvent int
+	fast      bool
+}
+
+//Begin Starts recording a new game
+func (recorder *InputRecorder) Begin(game string, seed int64, settings interface{}) {
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	data, _ := json.Marshal(settings)
+
+	recorder.pending = nil
+	recorder.recording = Replay{Game: game, Seed: seed, Settings: data}
+}
+
+//KeyPress Records a key press to be applied on the next tick. Input is ignored while a replay is playing
+func (recorder *InputRecorder) KeyPress(key Keys) {
+	recorder.add(InputEvent{Type: KeyPressInput, Key: key})
+}
+
+//Click Records a click to be applied on the next tick. Input is ignored while a replay is playing
+func (recorder *InputRecorder) Click(x int, y int) {
+	recorder.add(InputEvent{Type: ClickInput, X: x, Y: y})
+}
+
+func (recorder *InputRecorder) add(event InputEvent) {
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if recorder.playback == nil {
+		recorder.pending = append(recorder.pending, event)
+	}
+}
+
+//IsPlaying Returns true while a replay is being played back
+func (recorder *InputRecorder) IsPlaying() bool {
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return recorder.playback != nil
+}
+
+//UpdatesPerFrame Returns how many ticks the game should run for each controller Update
+func (recorder *InputRecorder) UpdatesPerFrame() int {
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if recorder.playback != nil && recorder.fast {
+		return fastReplayUpdates
+	}
+	return 1
+}
+
+//PlaybackFinished Returns true if a replay is playing and the given tick is the last tick of the replay
+func (recorder *InputRecorder) PlaybackFinished(tick int) bool {
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return recorder.playback != nil && tick >= recorder.playback.Ticks
+}
+
+//Events Returns the input to apply before the given tick. When recording these are the buffered user inputs,
+//which are stamped with the tick and added to the recording. When playing back they are the replay's inputs
+func (recorder *InputRecorder) Events(tick int) []InputEvent {
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if recorder.playback != nil {
+		return recorder.playback.eventsFrom(&recorder.nextEvent, tick)
+	}
+
+	events := recorder.pending
+	recorder.pending = nil
+
+	for i := range events {
+		events[i].Tick = tick
+	}
+
+	recorder.recording.Events = append(recorder.recording.Events, events...)
+
+	return events
+}
+
+//Finish Stores the final tick and score of the recorded game
+func (recorder *InputRecorder) Finish(ticks int, score int) {
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if recorder.playback == nil {
+		recorder.recording.Ticks = ticks
+		recorder.recording.FinalScore = score
+	}
+}
+
+//Save Writes the recording to ReplayDirectory. The recording can be saved before the game has finished
+func (recorder *InputRecorder) Save(name string) error {
+
+	recorder.mutex.Lock()
+	data, err := json.MarshalIndent(recorder.recording, "", "  ")
+	recorder.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(ReplayDirectory, 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(replayPath(name), data, 0644)
+}
+
+//Play Starts playing back the given replay
+func (recorder *InputRecorder) Play(replay Replay, fast bool) {
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.pending = nil
+	recorder.playback = &replay
+	recorder.nextEvent = 0
+	recorder.fast = fast
+}
+
+//Stop Stops playing back a replay, input is recorded again
+func (recorder *InputRecorder) Stop() {
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.playback = nil
+	recorder.fast = false
+}
+
+//LoadReplay Reads a replay saved in ReplayDirectory
+func LoadReplay(name string) (Replay, error) {
+
+	data, err := ioutil.ReadFile(replayPath(name))
+
+	if err != nil {
+		return Replay{}, err
+	}
+
+	var replay Replay
+	err = json.Unmarshal(data, &replay)
+
+	return replay, err
+}
+
+func replayPath(name string) string {
+	return filepath.Join(ReplayDirectory, filepath.Base(name)+".json")
+}
+
+//eventsFrom Returns the events for the given tick, starting at index next. next is moved past the returned events
+func (replay *Replay) eventsFrom(next *int, tick int) []InputEvent {
+
+	start := *next
+
+	for *next < len(replay.Events) && replay.Events[*next].Tick <= tick {
+		*next++
+	}
+
+	return replay.Events[start:*next]
+}
+
+//stepGame Applies the recorder's input for the current tick and then updates the world
+func stepGame(game replayableGame, recorder *InputRecorder) bool {
+
+	for _, event := range recorder.Events(game.tick()) {
+		game.applyInput(event)
+	}
+
+	return game.updateWorld()
+}
+
+//updateGame Runs the game for one controller Update. While a fast replay is playing several ticks are run
+func updateGame(game replayableGame, recorder *InputRecorder) bool {
+
+	updated := false
+
+	for i := 0; i < recorder.UpdatesPerFrame(); i++ {
+
+		if recorder.PlaybackFinished(game.tick()) {
+			break
+		}
+
+		updated = stepGame(game, recorder)
+	}
+
+	return updated
+}
+
+//playReplay Loads the named replay into the game and starts playing it back
+func playReplay(game replayableGame, recorder *InputRecorder, name string, fast bool) error {
+
+	replay, err := LoadReplay(name)
+
+	if err != nil {
+		return err
+	}
+
+	if err := game.loadReplay(replay); err != nil {
+		return err
+	}
+
+	recorder.Play(replay, fast)
+
+	return nil
+}
+
+//verifyReplay Plays the named replay on the given game as fast as possible and checks the final score matches the recorded score
+func verifyReplay(game replayableGame, name string) (ReplayResult, error) {
+
+	replay, err := LoadReplay(name)
+
+	if err != nil {
+		return ReplayResult{}, err
+	}
+
+	if err := game.loadReplay(replay); err != nil {
+		return ReplayResult{}, err
+	}
+
+	recorder := InputRecorder{}
+	recorder.Play(replay, true)
+
+	//Games that are waiting for input do not tick, so the number of steps is limited by the ticks and the inputs
+	maxSteps := replay.Ticks + len(replay.Events) + 1
+
+	for i := 0; i < maxSteps && game.tick() < replay.Ticks && !game.isGameOver(); i++ {
+		stepGame(game, &recorder)
+	}
+
+	result := ReplayResult{
+		ExpectedScore: replay.FinalScore,
+		ActualScore:   game.score(),
+		Ticks:         game.tick(),
+	}
+	result.Matches = result.ExpectedScore == result.ActualScore && result.Ticks == replay.Ticks
+
+	return result, nil
+}
+
+//checkReplayGame Returns an error if the replay was recorded in a different game
+func checkReplayGame(replay Replay, game string) error {
+	if replay.Game != game {
+		return fmt.Errorf("replay is for %q not %q", replay.Game, game)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"encoding/json"
+	"gopherlife/geometry"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+//inReplayDirectory Runs the test from a temporary directory so replays are saved there
+func inReplayDirectory(t *testing.T) func() {
+
+	dir, err := ioutil.TempDir("", "replays")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		os.Chdir(wd)
+		os.RemoveAll(dir)
+	}
+}
+
+//playSnake Plays a game of Snake with a fixed seed on a torus, turning at random, until it is over
+func playSnake(t *testing.T) *SnakeWorldController {
+
+	controller := NewSnakeWorldController(nil)
+	controller.SnakeWorldSettings.Seed = 3
+	controller.SnakeWorldSettings.Topology = geometry.Torus
+	controller.Start()
+	controller.Click(0, 0)
+
+	random := rand.New(rand.NewSource(1))
+	keys := []Keys{LeftArrow, RightArrow, UpArrow, DownArrow}
+
+	for i := 0; i < 5000 && !controller.IsGameOver; i++ {
+		if random.Intn(5) == 0 {
+			controller.KeyPress(keys[random.Intn(len(keys))])
+		}
+		controller.Update()
+	}
+
+	if !controller.IsGameOver || controller.Score == 0 {
+		t.Fatalf("The recorded game finished %v with a score of %d, want it to be over with some food eaten",
+			controller.IsGameOver, controller.Score)
+	}
+
+	return &controller
+}
+
+func TestInputRecorder_SaveAndReplay(t *testing.T) {
+
+	defer inReplayDirectory(t)()
+
+	recorded := playSnake(t)
+
+	if err := recorded.SaveReplay("game"); err != nil {
+		t.Fatalf("SaveReplay() error = %v", err)
+	}
+
+	replayed := NewSnakeWorldController(nil)
+	replayed.Start()
+
+	if err := replayed.PlayReplay("game", true); err != nil {
+		t.Fatalf("PlayReplay() error = %v", err)
+	}
+
+	for i := 0; i < 5000 && !replayed.IsGameOver; i++ {
+		replayed.Update()
+	}
+
+	if replayed.Score != recorded.Score || replayed.Tick != recorded.Tick {
+		t.Errorf("Replay reached score %d on tick %d, want score %d on tick %d",
+			replayed.Score, replayed.Tick, recorded.Score, recorded.Tick)
+	}
+
+	result, err := recorded.VerifyReplay("game")
+
+	if err != nil || !result.Matches {
+		t.Errorf("VerifyReplay() = %+v, %v, want the replay to match", result, err)
+	}
+}
+
+func TestInputRecorder_TamperedReplay(t *testing.T) {
+
+	defer inReplayDirectory(t)()
+
+	recorded := playSnake(t)
+
+	if err := recorded.SaveReplay("game"); err != nil {
+		t.Fatalf("SaveReplay() error = %v", err)
+	}
+
+	replay, err := LoadReplay("game")
+	if err != nil {
+		t.Fatalf("LoadReplay() error = %v", err)
+	}
+
+	replay.FinalScore += 10
+
+	data, err := json.Marshal(replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(replayPath("tampered"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := recorded.VerifyReplay("tampered")
+
+	if err != nil || result.Matches {
+		t.Errorf("VerifyReplay() of a tampered replay = %+v, %v, want it not to match", result, err)
+	}
+}
+
+//playBlockBlock Plays a game of Block Block Revolution with a fixed seed, pressing keys at random, until it is over
+func playBlockBlock(t *testing.T) *BlockBlockRevolutionController {
+
+	controller := NewBlockBlockRevolutionController(nil)
+	controller.BlockBlockRevolutionSettings.Seed = 3
+	controller.Start()
+
+	random := rand.New(rand.NewSource(1))
+	keys := []Keys{LeftArrow, RightArrow, UpArrow, DownArrow}
+
+	for i := 0; i < 20000 && !controller.IsGameOver; i++ {
+		if random.Intn(3) == 0 {
+			controller.KeyPress(keys[random.Intn(len(keys))])
+		}
+		controller.Update()
+	}
+
+	if !controller.IsGameOver {
+		t.Fatalf("The recorded game was not over after %d ticks", controller.Tick)
+	}
+
+	return &controller
+}
+
+//blockBlockBoard Returns which tiles of the board hold a block, top row first
+func blockBlockBoard(controller *BlockBlockRevolutionController) string {
+
+	board := ""
+	settings := controller.BlockBlockRevolutionWorld.BlockBlockRevolutionSettings
+
+	for y := settings.Height - 1; y >= 0; y-- {
+		for x := 0; x < settings.Width; x++ {
+			if _, ok := controller.ContainsBlock(x, y); ok {
+				board += "#"
+			} else {
+				board += "."
+			}
+		}
+		board += "\n"
+	}
+
+	return board
+}
+
+func TestInputRecorder_SaveAndReplayBlockBlock(t *testing.T) {
+
+	defer inReplayDirectory(t)()
+
+	recorded := playBlockBlock(t)
+
+	if err := recorded.SaveReplay("game"); err != nil {
+		t.Fatalf("SaveReplay() error = %v", err)
+	}
+
+	replayed := NewBlockBlockRevolutionController(nil)
+	replayed.Start()
+
+	if err := replayed.PlayReplay("game", true); err != nil {
+		t.Fatalf("PlayReplay() error = %v", err)
+	}
+
+	for i := 0; i < 20000 && !replayed.IsGameOver; i++ {
+		replayed.Update()
+	}
+
+	if replayed.Score != recorded.Score || replayed.Tick != recorded.Tick {
+		t.Errorf("Replay reached score %d on tick %d, want score %d on tick %d",
+			replayed.Score, replayed.Tick, recorded.Score, recorded.Tick)
+	}
+
+	if got, want := blockBlockBoard(&replayed), blockBlockBoard(recorded); got != want {
+		t.Errorf("Replay finished with the board\n%v\nwant\n%v", got, want)
+	}
+
+	result, err := recorded.VerifyReplay("game")
+
+	if err != nil || !result.Matches {
+		t.Errorf("VerifyReplay() = %+v, %v, want the replay to match", result, err)
+	}
+}
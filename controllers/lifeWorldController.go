@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopherlife/colors"
+	"gopherlife/geometry"
+	"gopherlife/renderers"
+	"gopherlife/world"
+	"image/color"
+	"log"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//lifePatternDirectory the directory Life patterns are loaded from, relative to where the server is run
+const lifePatternDirectory = "levels/life"
+
+var (
+	aliveCellColor = colors.Black
+	deadCellColor  = colors.White
+	offGridColor   = color.RGBA{128, 128, 128, 1}
+)
+
+//LifeWorldController shows a LifeWorld. Clicking a cell toggles it, 'P' pauses and 'S' steps one generation
+type LifeWorldController struct {
+	world.LifeWorldSettings
+	*world.LifeWorld
+	*renderers.GridRenderer
+	IsPaused bool
+	//patternName the name of the pattern file the world was started from
+	patternName string
+}
+
+//NewLifeWorldController Returns a Controller for Conway's Game of Life on a randomly filled torus
+func NewLifeWorldController() LifeWorldController {
+
+	settings := world.LifeWorldSettings{
+		Dimensions: world.Dimensions{Width: 100, Height: 100},
+		Rule:       world.Life,
+		Topology:   geometry.Torus,
+		Density:    30,
+	}
+
+	renderer := renderers.NewRenderer(100, 100)
+	renderer.Shift(settings.Width/2-renderer.Width/2, settings.Height/2-renderer.Height/2)
+
+	return LifeWorldController{
+		LifeWorldSettings: settings,
+		GridRenderer:      &renderer,
+	}
+}
+
+func (controller *LifeWorldController) Start() {
+	if controller.LifeWorld == nil {
+		lifeWorld := world.NewLifeWorld(controller.LifeWorldSettings)
+		controller.LifeWorld = &lifeWorld
+	}
+}
+
+//Update Moves the world on by one generation unless it is paused
+func (controller *LifeWorldController) Update() bool {
+
+	if controller.IsPaused {
+		return false
+	}
+
+	return controller.LifeWorld.Update()
+}
+
+//Click Toggles the clicked cell
+func (controller *LifeWorldController) Click(x int, y int) {
+	controller.Toggle(x, y)
+}
+
+//KeyPress 'P' pauses, 'S' steps one generation and the arrows move the view
+func (controller *LifeWorldController) KeyPress(key Keys) {
+
+	switch key {
+	case PKey:
+		controller.IsPaused = !controller.IsPaused
+	case SKey:
+		controller.LifeWorld.Update()
+	case LeftArrow:
+		controller.Shift(-1, 0)
+	case RightArrow:
+		controller.Shift(1, 0)
+	case UpArrow:
+		controller.Shift(0, -1)
+	case DownArrow:
+		controller.Shift(0, 1)
+	}
+}
+
+func (controller *LifeWorldController) MarshalJSON() ([]byte, error) {
+
+	controller.GridRenderer.Surface = controller.LifeWorld.Surface()
+	render := controller.GridRenderer.Draw(controller)
+
+	render.TextBelowCanvas += fmt.Sprintf("<span>Rule: %s (%s)</span><br />", controller.Rule.Name, controller.Rule.String())
+	render.TextBelowCanvas += fmt.Sprintf("<span>Generation: %d Population: %d</span><br />", controller.Generation, controller.Population)
+
+	if controller.IsPaused {
+		render.TextBelowCanvas += fmt.Sprintf("<span>Paused, press 'P' to continue or 'S' to step</span><br />")
+	}
+
+	return json.Marshal(render)
+}
+
+func (controller *LifeWorldController) RenderTile(x int, y int) color.RGBA {
+
+	if state, ok := controller.Cell(x, y); ok {
+		if state != 0 {
+			return aliveCellColor
+		}
+		return deadCellColor
+	}
+
+	return offGridColor
+}
+
+func (controller *LifeWorldController) PageLayout() WorldPageData {
+
+	settings := controller.LifeWorldSettings
+
+	return WorldPageData{
+		PageTitle: "G O P H E R L I F E",
+		FormData: []FormData{
+			FormDataWidth(settings.Width, 2),
+			FormDataHeight(settings.Height, 2),
+			FormDataLifeRule(settings.Rule.Name, 2),
+			FormDataDensity(settings.Density, 2),
+			FormDataLifePattern(controller.patternName, 2),
+			FormDataTopology(settings.Topology, 2),
+		},
+	}
+}
+
+func (controller *LifeWorldController) HandleForm(values url.Values) bool {
+
+	fd := FormDataLifeRule("", 0)
+	if strings.Contains(values.Encode(), fd.Name) {
+
+		width, _ := strconv.ParseInt(values.Get(FormDataWidth(0, 0).Name), 10, 64)
+		height, _ := strconv.ParseInt(values.Get(FormDataHeight(0, 0).Name), 10, 64)
+		density, _ := strconv.ParseInt(values.Get(FormDataDensity(0, 0).Name), 10, 64)
+		topology, _ := strconv.ParseInt(values.Get(FormDataTopology(geometry.Bounded, 0).Name), 10, 64)
+
+		controller.LifeWorldSettings.Width = int(width)
+		controller.LifeWorldSettings.Height = int(height)
+		controller.LifeWorldSettings.Density = int(density)
+		controller.LifeWorldSettings.Topology = geometry.Topology(topology)
+		controller.LifeWorldSettings.Pattern = nil
+		controller.patternName = ""
+
+		ruleText := values.Get(fd.Name)
+
+		if patternName := values.Get(FormDataLifePattern("", 0).Name); patternName != "" {
+			pattern, err := loadLifePattern(patternName)
+			if err == nil {
+				controller.LifeWorldSettings.Pattern = &pattern
+				controller.patternName = patternName
+				if pattern.Rule != "" && (ruleText == "" || ruleText == controller.Rule.Name) {
+					ruleText = pattern.Rule
+				}
+			} else {
+				log.Printf("Unable to load life pattern: %v", err)
+			}
+		}
+
+		if ruleText != "" {
+			rule, err := world.ParseLifeRule(ruleText)
+			if err == nil {
+				controller.LifeWorldSettings.Rule = rule
+			} else {
+				log.Printf("Unable to read life rule: %v", err)
+			}
+		}
+
+		controller.LifeWorld = nil
+		controller.Start()
+	}
+
+	return true
+}
+
+//loadLifePattern Loads the named pattern from an .rle file, or a .cells file if there is no .rle file
+func loadLifePattern(name string) (world.LifePattern, error) {
+
+	name = filepath.Base(name)
+
+	if ext := filepath.Ext(name); ext == ".rle" || ext == ".cells" {
+		return world.LoadLifePattern(filepath.Join(lifePatternDirectory, name))
+	}
+
+	pattern, err := world.LoadLifePattern(filepath.Join(lifePatternDirectory, name+".rle"))
+
+	if err != nil {
+		return world.LoadLifePattern(filepath.Join(lifePatternDirectory, name+".cells"))
+	}
+
+	return pattern, nil
+}
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopherlife/colors"
+	"gopherlife/renderers"
+	"gopherlife/world"
+	"image/color"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+//mazeTileColors the colour each MazeTile is drawn in
+var mazeTileColors = map[world.MazeTile]color.RGBA{
+	world.MazeWall:     color.RGBA{30, 30, 40, 1},
+	world.MazeUncarved: color.RGBA{70, 70, 80, 1},
+	world.MazePassage:  colors.White,
+	world.MazeCarving:  colors.Orange,
+	world.MazeFrontier: colors.Cyan,
+	world.MazeVisited:  color.RGBA{170, 200, 255, 1},
+	world.MazePath:     colors.Yellow,
+	world.MazeStart:    colors.Green,
+	world.MazeEnd:      colors.Red,
+}
+
+//MazeWorldController shows a MazeWorld being carved and then solved. The arrows move the view around large mazes
+type MazeWorldController struct {
+	NoPlayerInput
+	world.MazeWorldSettings
+	*world.MazeWorld
+	*renderers.GridRenderer
+}
+
+//NewMazeWorldController Returns a Controller that carves a maze with the recursive backtracker and solves it with A*
+func NewMazeWorldController() MazeWorldController {
+
+	settings := world.MazeWorldSettings{
+		Dimensions:     world.Dimensions{Width: 50, Height: 50},
+		Generator:      world.RecursiveBacktracker,
+		Solver:         world.AStar,
+		StepsPerUpdate: 5,
+	}
+
+	renderer := renderers.NewRenderer(settings.Width*2+1, settings.Height*2+1)
+
+	return MazeWorldController{
+		MazeWorldSettings: settings,
+		GridRenderer:      &renderer,
+	}
+}
+
+func (controller *MazeWorldController) Start() {
+	if controller.MazeWorld == nil {
+		controller.MazeWorld = world.NewMazeWorld(controller.MazeWorldSettings)
+	}
+}
+
+func (controller *MazeWorldController) KeyPress(key Keys) {
+
+	switch key {
+	case LeftArrow:
+		controller.Shift(-1, 0)
+	case RightArrow:
+		controller.Shift(1, 0)
+	case UpArrow:
+		controller.Shift(0, -1)
+	case DownArrow:
+		controller.Shift(0, 1)
+	}
+}
+
+func (controller *MazeWorldController) MarshalJSON() ([]byte, error) {
+
+	controller.GridRenderer.Surface = controller.MazeWorld.Surface()
+	render := controller.GridRenderer.Draw(controller)
+
+	render.TextBelowCanvas += fmt.Sprintf("<span>Generator: %s Solver: %s</span><br />", controller.Generator, controller.Solver)
+	render.TextBelowCanvas += fmt.Sprintf("<span>%s Steps: %d</span><br />", controller.Phase, controller.Steps)
+
+	if controller.Phase == world.Solved {
+		render.TextBelowCanvas += fmt.Sprintf("<span>Path Length: %d cells</span><br />", len(controller.Path))
+	}
+
+	return json.Marshal(render)
+}
+
+func (controller *MazeWorldController) RenderTile(x int, y int) color.RGBA {
+
+	if tile, ok := controller.Tile(x, y); ok {
+		return mazeTileColors[tile]
+	}
+
+	return offGridColor
+}
+
+func (controller *MazeWorldController) PageLayout() WorldPageData {
+
+	settings := controller.MazeWorldSettings
+
+	return WorldPageData{
+		FormData: []FormData{
+			FormDataWidth(settings.Width, 2),
+			FormDataHeight(settings.Height, 2),
+			FormDataMazeGenerator(settings.Generator, 3),
+			FormDataMazeSolver(settings.Solver, 3),
+			FormDataStepsPerUpdate(settings.StepsPerUpdate, 2),
+		},
+	}
+}
+
+func (controller *MazeWorldController) HandleForm(values url.Values) bool {
+
+	fd := FormDataMazeGenerator(world.RecursiveBacktracker, 0)
+	if strings.Contains(values.Encode(), fd.Name) {
+
+		width, _ := strconv.ParseInt(values.Get(FormDataWidth(0, 0).Name), 10, 64)
+		height, _ := strconv.ParseInt(values.Get(FormDataHeight(0, 0).Name), 10, 64)
+		generator, _ := strconv.ParseInt(values.Get(fd.Name), 10, 64)
+		solver, _ := strconv.ParseInt(values.Get(FormDataMazeSolver(world.BreadthFirst, 0).Name), 10, 64)
+		steps, _ := strconv.ParseInt(values.Get(FormDataStepsPerUpdate(0, 0).Name), 10, 64)
+
+		controller.MazeWorldSettings = world.MazeWorldSettings{
+			Dimensions:     world.Dimensions{Width: int(width), Height: int(height)},
+			Generator:      world.MazeGenerator(generator),
+			Solver:         world.MazeSolver(solver),
+			StepsPerUpdate: int(steps),
+		}
+	}
+
+	controller.MazeWorld = nil
+	controller.Start()
+
+	return true
+}
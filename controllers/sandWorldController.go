@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopherlife/colors"
+	"gopherlife/renderers"
+	"gopherlife/world"
+	"image/color"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+//maxBrushSize the largest radius the brush can be scrolled up to
+const maxBrushSize = 15
+
+//materialColors the colour each Material is drawn in before it is shaded
+var materialColors = map[world.Material]color.RGBA{
+	world.Empty: colors.Black,
+	world.Sand:  color.RGBA{220, 190, 110, 1},
+	world.Water: color.RGBA{40, 90, 220, 1},
+	world.Stone: color.RGBA{120, 120, 120, 1},
+	world.Fire:  color.RGBA{255, 90, 20, 1},
+	world.Smoke: color.RGBA{70, 70, 70, 1},
+	world.Wood:  color.RGBA{110, 70, 30, 1},
+	world.Oil:   color.RGBA{60, 40, 50, 1},
+}
+
+//SandWorldController shows a SandWorld. Clicking and dragging paints the brush Material, the number keys pick the
+//Material and scrolling changes the size of the brush
+type SandWorldController struct {
+	world.SandWorldSettings
+	*world.SandWorld
+	*renderers.GridRenderer
+	Brush     world.Material
+	BrushSize int
+}
+
+//NewSandWorldController Returns a Controller with an empty SandWorld and a brush of Sand
+func NewSandWorldController() SandWorldController {
+
+	settings := world.SandWorldSettings{
+		Dimensions: world.Dimensions{Width: 150, Height: 100},
+	}
+
+	renderer := renderers.NewRenderer(150, 100)
+
+	return SandWorldController{
+		SandWorldSettings: settings,
+		GridRenderer:      &renderer,
+		Brush:             world.Sand,
+		BrushSize:         3,
+	}
+}
+
+func (controller *SandWorldController) Start() {
+	if controller.SandWorld == nil {
+		sandWorld := world.NewSandWorld(controller.SandWorldSettings)
+		controller.SandWorld = &sandWorld
+	}
+}
+
+//Click Paints the brush Material around the clicked tile
+func (controller *SandWorldController) Click(x int, y int) {
+	controller.Paint(x, y, controller.BrushSize, controller.Brush)
+}
+
+//KeyPress The number keys pick the Material of the brush, 0 erases
+func (controller *SandWorldController) KeyPress(key Keys) {
+	if number, ok := key.Number(); ok && number < len(world.Materials) {
+		controller.Brush = world.Materials[number]
+	}
+}
+
+//Scroll Scrolling up makes the brush bigger and scrolling down makes it smaller
+func (controller *SandWorldController) Scroll(deltaY int) {
+
+	if deltaY < 0 {
+		controller.BrushSize++
+	} else {
+		controller.BrushSize--
+	}
+
+	if controller.BrushSize < 0 {
+		controller.BrushSize = 0
+	} else if controller.BrushSize > maxBrushSize {
+		controller.BrushSize = maxBrushSize
+	}
+}
+
+func (controller *SandWorldController) MarshalJSON() ([]byte, error) {
+
+	render := controller.GridRenderer.Draw(controller)
+
+	materials := make([]string, len(world.Materials))
+	for i, material := range world.Materials {
+		materials[i] = fmt.Sprintf("%d %s", i, material)
+	}
+
+	render.TextBelowCanvas += fmt.Sprintf("<span>Brush: %s Size: %d</span><br />", controller.Brush, controller.BrushSize)
+	render.TextBelowCanvas += fmt.Sprintf("<span>Keys: %s</span><br />", strings.Join(materials, ", "))
+
+	return json.Marshal(render)
+}
+
+func (controller *SandWorldController) RenderTile(x int, y int) color.RGBA {
+
+	if tile, ok := controller.Tile(x, y); ok {
+
+		if tile.Material == world.Empty {
+			return materialColors[world.Empty]
+		}
+
+		return colors.Blend(materialColors[tile.Material], colors.Black, float64(tile.Shade)/255)
+	}
+
+	return colors.White
+}
+
+func (controller *SandWorldController) PageLayout() WorldPageData {
+
+	settings := controller.SandWorldSettings
+
+	return WorldPageData{
+		FormData: []FormData{
+			FormDataWidth(settings.Width, 2),
+			FormDataHeight(settings.Height, 2),
+		},
+	}
+}
+
+func (controller *SandWorldController) HandleForm(values url.Values) bool {
+
+	if strings.Contains(values.Encode(), FormDataWidth(0, 0).Name) {
+
+		width, _ := strconv.ParseInt(values.Get(FormDataWidth(0, 0).Name), 10, 64)
+		height, _ := strconv.ParseInt(values.Get(FormDataHeight(0, 0).Name), 10, 64)
+
+		controller.SandWorldSettings.Width = int(width)
+		controller.SandWorldSettings.Height = int(height)
+	}
+
+	controller.SandWorld = nil
+	controller.Start()
+	return true
+}
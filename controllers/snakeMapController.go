@@ -5,22 +5,43 @@ import (
 	"fmt"
 	"gopherlife/colors"
 	"gopherlife/geometry"
+	"gopherlife/highscores"
 	"gopherlife/renderers"
 	"gopherlife/world"
 	"image/color"
+	"log"
 	"net/url"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 )
 
+//snakeLevelDirectory the directory Snake levels are loaded from, relative to where the server is run
+const snakeLevelDirectory = "levels/snake"
+
+var (
+	speedBoostFoodColor = colors.Orange
+	slowDownFoodColor   = colors.MingBlue
+	shrinkFoodColor     = colors.Pink
+	ghostFoodColor      = colors.White
+	ghostSnakeColor     = color.RGBA{120, 150, 60, 1}
+)
+
+const snakeGameName = "Elongating Gopher"
+
 type SnakeWorldController struct {
 	world.SnakeWorldSettings
 	ClickToBegin bool
+	PlayerName   string
 	*world.SnakeWorld
 	*renderers.GridRenderer
+	highScores highScoreTracker
+	recorder   InputRecorder
 }
 
-func NewSnakeWorldController() SnakeWorldController {
+//NewSnakeWorldController Returns a Controller with a SnakeWorld. Finished games are recorded in the given high-score Store, if not nil
+func NewSnakeWorldController(scores *highscores.Store) SnakeWorldController {
 
 	d := world.Dimensions{
 		Width:  35,
@@ -33,8 +54,9 @@ func NewSnakeWorldController() SnakeWorldController {
 	renderer.TileHeight = 10
 
 	return SnakeWorldController{
-		SnakeWorldSettings: world.SnakeWorldSettings{d, 5},
-		GridRenderer:     &renderer,
+		SnakeWorldSettings: world.SnakeWorldSettings{Dimensions: d, SpeedReduction: 5},
+		GridRenderer:       &renderer,
+		highScores:         newHighScoreTracker(scores, snakeGameName),
 	}
 }
 
@@ -42,16 +64,36 @@ func (controller *SnakeWorldController) Start() {
 	if controller.SnakeWorld == nil {
 		sMap := world.NewSnakeWorld(controller.SnakeWorldSettings)
 		controller.SnakeWorld = &sMap
+		controller.ClickToBegin = false
+		controller.highScores.begin()
+		controller.recorder.Stop()
+		controller.recorder.Begin(snakeGameName, sMap.Seed, sMap.SnakeWorldSettings)
 	}
 }
 
 func (controller *SnakeWorldController) MarshalJSON() ([]byte, error) {
 
+	controller.GridRenderer.Surface = controller.SnakeWorld.Surface()
 	render := controller.GridRenderer.Draw(controller)
 	render.TextBelowCanvas += fmt.Sprintf("<span>Score: %d </span><br />", controller.Score)
 
+	powerUps := make([]string, 0, len(controller.ActivePowerUps))
+	for foodType, ticks := range controller.ActivePowerUps {
+		powerUps = append(powerUps, fmt.Sprintf("%s (%d)", foodType, ticks))
+	}
+	sort.Strings(powerUps)
+
+	if len(powerUps) > 0 {
+		render.TextBelowCanvas += fmt.Sprintf("<span>Power-Ups: %s </span><br />", strings.Join(powerUps, ", "))
+	}
+
+	if controller.recorder.IsPlaying() {
+		render.TextBelowCanvas += fmt.Sprintf("<span>Replaying</span><br />")
+	}
+
 	if controller.SnakeWorld.IsGameOver {
 		render.TextBelowCanvas += fmt.Sprintf("<span>Game Over!</span><br />")
+		render.TextBelowCanvas += controller.highScores.tableHTML(controller.highScoreSettings())
 	} else if !controller.ClickToBegin {
 		render.TextBelowCanvas += fmt.Sprintf("<span>Click to Begin")
 	}
@@ -66,11 +108,24 @@ func (controller *SnakeWorldController) RenderTile(x int, y int) color.RGBA {
 		case sp.SnakePart != nil:
 			if sp.SnakePart.HasPartInStomach() {
 				return colors.NokiaFoodGreen
+			} else if controller.HasPowerUp(world.GhostFood) {
+				return ghostSnakeColor
 			} else {
 				return colors.NokiaBorder
 			}
 		case sp.SnakeFood != nil:
-			return colors.NokiaBorder
+			switch sp.SnakeFood.Type {
+			case world.SpeedBoostFood:
+				return speedBoostFoodColor
+			case world.SlowDownFood:
+				return slowDownFoodColor
+			case world.ShrinkFood:
+				return shrinkFoodColor
+			case world.GhostFood:
+				return ghostFoodColor
+			default:
+				return colors.NokiaBorder
+			}
 		case sp.SnakeWall != nil:
 			return colors.NokiaBorder
 		default:
@@ -81,10 +136,22 @@ func (controller *SnakeWorldController) RenderTile(x int, y int) color.RGBA {
 }
 
 func (controller *SnakeWorldController) PageLayout() WorldPageData {
+
+	settings := controller.SnakeWorldSettings
+
+	levelName := ""
+	if settings.Level != nil {
+		levelName = settings.Level.Name
+	}
+
 	return WorldPageData{
 		PageTitle: "E L O N G A T I N G G O P H E R L I F E",
 		FormData: []FormData{
-			FormDataSnakeSlowDown(controller.SnakeWorldSettings.SpeedReduction, 3),
+			FormDataSnakeSlowDown(settings.SpeedReduction, 3),
+			FormDataSnakeLevel(levelName, 3),
+			FormDataTopology(settings.Topology, 3),
+			FormDataSnakePowerUps(settings.PowerUps, 3),
+			FormDataPlayerName(controller.PlayerName, 3),
 		},
 	}
 }
@@ -94,7 +161,24 @@ func (controller *SnakeWorldController) HandleForm(values url.Values) bool {
 	fd := FormDataSnakeSlowDown(0, 0)
 	if strings.Contains(values.Encode(), fd.Name) {
 		speedReduction, _ := strconv.ParseInt(values.Get(fd.Name), 10, 64)
+		topology, _ := strconv.ParseInt(values.Get(FormDataTopology(geometry.Bounded, 0).Name), 10, 64)
+		powerUps, _ := strconv.ParseInt(values.Get(FormDataSnakePowerUps(false, 0).Name), 10, 64)
+
 		controller.SnakeWorldSettings.SpeedReduction = int(speedReduction)
+		controller.SnakeWorldSettings.Topology = geometry.Topology(topology)
+		controller.SnakeWorldSettings.PowerUps = powerUps != 0
+		controller.SnakeWorldSettings.Level = nil
+		controller.PlayerName = values.Get(FormDataPlayerName("", 0).Name)
+
+		if levelName := values.Get(FormDataSnakeLevel("", 0).Name); levelName != "" {
+			level, err := world.LoadSnakeLevel(filepath.Join(snakeLevelDirectory, filepath.Base(levelName)+".txt"))
+			if err == nil {
+				controller.SnakeWorldSettings.Level = &level
+			} else {
+				log.Printf("Unable to load snake level: %v", err)
+			}
+		}
+
 		controller.SnakeWorld = nil
 		controller.Start()
 	}
@@ -102,31 +186,116 @@ func (controller *SnakeWorldController) HandleForm(values url.Values) bool {
 	return true
 }
 
+//KeyPress records the key press, it is applied at the start of the next tick
 func (controller *SnakeWorldController) KeyPress(key Keys) {
-	switch key {
-	case LeftArrow:
-		controller.SnakeWorld.ChangeDirection(geometry.Left)
-	case RightArrow:
-		controller.SnakeWorld.ChangeDirection(geometry.Right)
-	case UpArrow:
-		controller.SnakeWorld.ChangeDirection(geometry.Up)
-	case DownArrow:
-		controller.SnakeWorld.ChangeDirection(geometry.Down)
-	}
+	controller.recorder.KeyPress(key)
+}
+
+//Click records the click, it is applied at the start of the next tick
+func (controller *SnakeWorldController) Click(x int, y int) {
+	controller.recorder.Click(x, y)
 }
 
 func (controller *SnakeWorldController) Update() bool {
+	return updateGame(controller, &controller.recorder)
+}
+
+func (controller *SnakeWorldController) applyInput(event InputEvent) {
+
+	switch event.Type {
+	case KeyPressInput:
+		switch event.Key {
+		case LeftArrow:
+			controller.SnakeWorld.ChangeDirection(geometry.Left)
+		case RightArrow:
+			controller.SnakeWorld.ChangeDirection(geometry.Right)
+		case UpArrow:
+			controller.SnakeWorld.ChangeDirection(geometry.Up)
+		case DownArrow:
+			controller.SnakeWorld.ChangeDirection(geometry.Down)
+		}
+	case ClickInput:
+		if !controller.ClickToBegin && !controller.IsGameOver {
+			controller.highScores.begin()
+		}
+		controller.ClickToBegin = true
+	}
+}
+
+func (controller *SnakeWorldController) updateWorld() bool {
 	if controller.ClickToBegin {
 		if controller.IsGameOver {
 			controller.ClickToBegin = false
 		}
-		return controller.SnakeWorld.Update()
+		updated := controller.SnakeWorld.Update()
+
+		if controller.IsGameOver && !controller.recorder.IsPlaying() {
+			controller.highScores.finish(controller.PlayerName, controller.highScoreSettings(), controller.Score)
+			controller.recorder.Finish(controller.Tick, controller.Score)
+		}
+
+		return updated
 	}
 
 	return true
 }
 
-//Click selects the tile on the gopher map and runs the SelectEntity method
-func (controller *SnakeWorldController) Click(x int, y int) {
-	controller.ClickToBegin = true
+func (controller *SnakeWorldController) loadReplay(replay Replay) error {
+
+	if err := checkReplayGame(replay, snakeGameName); err != nil {
+		return err
+	}
+
+	var settings world.SnakeWorldSettings
+
+	if err := json.Unmarshal(replay.Settings, &settings); err != nil {
+		return err
+	}
+
+	settings.Seed = replay.Seed
+
+	sMap := world.NewSnakeWorld(settings)
+	controller.SnakeWorld = &sMap
+	controller.ClickToBegin = false
+
+	return nil
+}
+
+func (controller *SnakeWorldController) tick() int {
+	return controller.Tick
+}
+
+func (controller *SnakeWorldController) score() int {
+	return controller.Score
+}
+
+func (controller *SnakeWorldController) isGameOver() bool {
+	return controller.IsGameOver
+}
+
+//SaveReplay Saves the current game, finished or not, so it can be played again
+func (controller *SnakeWorldController) SaveReplay(name string) error {
+	controller.recorder.Finish(controller.Tick, controller.Score)
+	return controller.recorder.Save(name)
+}
+
+//PlayReplay Replaces the current game with the named replay
+func (controller *SnakeWorldController) PlayReplay(name string, fast bool) error {
+	return playReplay(controller, &controller.recorder, name, fast)
+}
+
+//VerifyReplay Plays the named replay in the background and checks it reaches the recorded score
+func (controller *SnakeWorldController) VerifyReplay(name string) (ReplayResult, error) {
+	verifier := NewSnakeWorldController(nil)
+	return verifyReplay(&verifier, name)
+}
+
+//HighScores Returns the high-score table for the current settings
+func (controller *SnakeWorldController) HighScores() highscores.Table {
+	return controller.highScores.table(controller.highScoreSettings())
+}
+
+//highScoreSettings High-scores are kept separately for each speed and board size
+func (controller *SnakeWorldController) highScoreSettings() string {
+	return fmt.Sprintf("Speed Reduction %d, %dx%d", controller.SnakeWorld.SpeedReduction, controller.SnakeWorld.Width, controller.SnakeWorld.Height)
 }
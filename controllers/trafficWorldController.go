@@ -0,0 +1,229 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopherlife/colors"
+	"gopherlife/renderers"
+	"gopherlife/world"
+	"image/color"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var (
+	trafficGrassColor = color.RGBA{65, 119, 15, 1}
+	trafficRoadColor  = color.RGBA{60, 60, 60, 1}
+	trafficBlockColor = colors.Orange
+	trafficGreenColor = color.RGBA{0, 160, 0, 1}
+	trafficRedColor   = color.RGBA{160, 0, 0, 1}
+)
+
+//Numbers tuning how the TrafficWorldController measures the traffic
+const (
+	//trafficSettleTicks the number of ticks after the cars or road blocks change before the traffic is added to the
+	//fundamental diagram, so the jams have time to form or clear
+	trafficSettleTicks = 100
+	//trafficAverageTicks the number of ticks the density and flow shown are averaged over
+	trafficAverageTicks = 100
+	//trafficCarsPerKey the number of cars added or taken away by each press of the up or down arrow
+	trafficCarsPerKey = 10
+)
+
+//TrafficWorldController shows a TrafficWorld and builds up its fundamental diagram as the density of cars changes.
+//Clicking a road blocks it, the up and down arrows add and take away cars
+type TrafficWorldController struct {
+	world.TrafficWorldSettings
+	*world.TrafficWorld
+	*renderers.GridRenderer
+	Diagram world.FundamentalDiagram
+	//settledAt the tick the traffic will have settled into its new pattern after the last change
+	settledAt int
+}
+
+//NewTrafficWorldController Returns a Controller with a two lane ring road crossed by four roads with traffic lights
+func NewTrafficWorldController() TrafficWorldController {
+
+	settings := world.TrafficWorldSettings{
+		Dimensions:     world.Dimensions{Width: 150, Height: 50},
+		Lanes:          2,
+		Intersections:  4,
+		Density:        0.15,
+		MaxVelocity:    5,
+		SlowdownChance: 0.2,
+		LightCycle:     40,
+	}
+
+	renderer := renderers.NewRenderer(150, 50)
+
+	return TrafficWorldController{
+		TrafficWorldSettings: settings,
+		GridRenderer:         &renderer,
+		Diagram:              world.NewFundamentalDiagram(0.05),
+	}
+}
+
+func (controller *TrafficWorldController) Start() {
+	if controller.TrafficWorld == nil {
+		trafficWorld := world.NewTrafficWorld(controller.TrafficWorldSettings)
+		controller.TrafficWorld = &trafficWorld
+		controller.unsettle()
+	}
+}
+
+//unsettle Stops the traffic being added to the fundamental diagram until it has settled after a change
+func (controller *TrafficWorldController) unsettle() {
+	controller.settledAt = controller.TrafficWorld.Tick + trafficSettleTicks
+}
+
+//Update Moves the cars on and adds the traffic to the fundamental diagram once it has settled
+func (controller *TrafficWorldController) Update() bool {
+
+	controller.TrafficWorld.Update()
+
+	if samples := controller.Samples; controller.TrafficWorld.Tick >= controller.settledAt && len(samples) > 0 {
+		controller.Diagram.Add(samples[len(samples)-1])
+	}
+
+	return true
+}
+
+//Click Blocks the clicked road, or clears the block if there already is one
+func (controller *TrafficWorldController) Click(x int, y int) {
+	if controller.ToggleBlock(x, y) {
+		controller.unsettle()
+	}
+}
+
+//KeyPress The up arrow adds cars and the down arrow takes them away
+func (controller *TrafficWorldController) KeyPress(key Keys) {
+
+	switch key {
+	case UpArrow:
+		controller.AddCars(trafficCarsPerKey)
+		controller.unsettle()
+	case DownArrow:
+		controller.RemoveCars(trafficCarsPerKey)
+		controller.unsettle()
+	}
+}
+
+func (controller *TrafficWorldController) MarshalJSON() ([]byte, error) {
+
+	controller.GridRenderer.Surface = controller.TrafficWorld.Surface()
+	render := controller.GridRenderer.Draw(controller)
+
+	samples := controller.Samples
+	if len(samples) > trafficAverageTicks {
+		samples = samples[len(samples)-trafficAverageTicks:]
+	}
+
+	average := world.TrafficSample{}
+	for _, sample := range samples {
+		average.Density += sample.Density / float64(len(samples))
+		average.Flow += sample.Flow / float64(len(samples))
+		average.MeanVelocity += sample.MeanVelocity / float64(len(samples))
+	}
+
+	render.TextBelowCanvas += fmt.Sprintf("<span>Tick: %d Cars: %d Density: %.3f Flow: %.3f Mean Velocity: %.2f</span><br />",
+		controller.TrafficWorld.Tick, len(controller.Cars), average.Density, average.Flow, average.MeanVelocity)
+
+	diagram := []string{}
+	for _, point := range controller.Diagram.Points() {
+		diagram = append(diagram, fmt.Sprintf("%.2f: %.3f", point.Density, point.Flow))
+	}
+
+	render.TextBelowCanvas += fmt.Sprintf("<span>Fundamental Diagram (Density: Flow) %s</span><br />", strings.Join(diagram, ", "))
+
+	if controller.TrafficWorld.Tick < controller.settledAt {
+		render.TextBelowCanvas += "<span>Settling...</span><br />"
+	}
+
+	return json.Marshal(render)
+}
+
+func (controller *TrafficWorldController) RenderTile(x int, y int) color.RGBA {
+
+	tile, car := controller.Tile(x, y)
+
+	if car != nil {
+		return colors.Blend(colors.Red, colors.Green, float64(car.Velocity)/float64(controller.TrafficWorld.MaxVelocity))
+	}
+
+	switch tile {
+	case world.Road:
+		return trafficRoadColor
+	case world.Intersection:
+		if controller.IsGreen(x, y) {
+			return trafficGreenColor
+		}
+		return trafficRedColor
+	case world.RoadBlock:
+		return trafficBlockColor
+	}
+
+	return trafficGrassColor
+}
+
+func (controller *TrafficWorldController) PageLayout() WorldPageData {
+
+	settings := controller.TrafficWorldSettings
+
+	return WorldPageData{
+		FormData: []FormData{
+			FormDataWidth(settings.Width, 2),
+			FormDataHeight(settings.Height, 2),
+			FormDataLanes(settings.Lanes, 1),
+			FormDataIntersections(settings.Intersections, 1),
+			FormDataCarDensity(settings.Density, 1),
+			FormDataMaxVelocity(settings.MaxVelocity, 1),
+			FormDataSlowdownChance(settings.SlowdownChance, 2),
+			FormDataLightCycle(settings.LightCycle, 2),
+		},
+	}
+}
+
+//HandleForm Starts the world again. The fundamental diagram is kept if only the density of cars changed
+func (controller *TrafficWorldController) HandleForm(values url.Values) bool {
+
+	fd := FormDataLanes(0, 0)
+	if strings.Contains(values.Encode(), fd.Name) {
+
+		width, _ := strconv.ParseInt(values.Get(FormDataWidth(0, 0).Name), 10, 64)
+		height, _ := strconv.ParseInt(values.Get(FormDataHeight(0, 0).Name), 10, 64)
+		lanes, _ := strconv.ParseInt(values.Get(fd.Name), 10, 64)
+		intersections, _ := strconv.ParseInt(values.Get(FormDataIntersections(0, 0).Name), 10, 64)
+		density, _ := strconv.ParseFloat(values.Get(FormDataCarDensity(0, 0).Name), 64)
+		density = math.Max(0, math.Min(1, density))
+		maxVelocity, _ := strconv.ParseInt(values.Get(FormDataMaxVelocity(0, 0).Name), 10, 64)
+		slowdown, _ := strconv.ParseFloat(values.Get(FormDataSlowdownChance(0, 0).Name), 64)
+		lightCycle, _ := strconv.ParseInt(values.Get(FormDataLightCycle(0, 0).Name), 10, 64)
+
+		previous := controller.TrafficWorldSettings
+
+		controller.TrafficWorldSettings = world.TrafficWorldSettings{
+			Dimensions:     world.Dimensions{Width: int(width), Height: int(height)},
+			Lanes:          int(lanes),
+			Intersections:  int(intersections),
+			Density:        density,
+			MaxVelocity:    int(maxVelocity),
+			SlowdownChance: slowdown,
+			LightCycle:     int(lightCycle),
+		}
+
+		previous.Density = density
+
+		if previous != controller.TrafficWorldSettings {
+			controller.Diagram = world.NewFundamentalDiagram(controller.Diagram.BinWidth)
+		}
+	}
+
+	//The new world is built before the old one is replaced so the Loop never updates a missing world
+	trafficWorld := world.NewTrafficWorld(controller.TrafficWorldSettings)
+	controller.TrafficWorld = &trafficWorld
+	controller.unsettle()
+
+	return true
+}
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopherlife/colors"
+	"gopherlife/geometry"
+	"gopherlife/renderers"
+	"gopherlife/world"
+	"image/color"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+//turmitePalette the colour of each cell state, states past the end of the palette go round it again
+var turmitePalette = []color.RGBA{
+	colors.White,
+	colors.Black,
+	colors.MingBlue,
+	colors.Orange,
+	colors.Green,
+	colors.Purple,
+	colors.Cyan,
+	colors.Yellow,
+	colors.Pink,
+	colors.Blue,
+	colors.NokiaGreen,
+	colors.NokiaBorder,
+}
+
+var turmiteColor = colors.Red
+
+//TurmiteWorldController shows a TurmiteWorld. Clicking a cell adds an ant to it
+type TurmiteWorldController struct {
+	NoPlayerInput
+	world.TurmiteWorldSettings
+	*world.TurmiteWorld
+	*renderers.GridRenderer
+}
+
+//NewTurmiteWorldController Returns a Controller with a single Langton's ant in the middle of a torus
+func NewTurmiteWorldController() TurmiteWorldController {
+
+	rule, _ := world.ParseAntRule("RL")
+
+	settings := world.TurmiteWorldSettings{
+		Dimensions:     world.Dimensions{Width: 100, Height: 100},
+		Topology:       geometry.Torus,
+		Rule:           rule,
+		StepsPerUpdate: 20,
+	}
+
+	renderer := renderers.NewRenderer(100, 100)
+	renderer.Shift(settings.Width/2-renderer.Width/2, settings.Height/2-renderer.Height/2)
+
+	return TurmiteWorldController{
+		GridRenderer:         &renderer,
+		TurmiteWorldSettings: settings,
+	}
+}
+
+func (controller *TurmiteWorldController) Start() {
+	if controller.TurmiteWorld == nil {
+		tMap := world.NewTurmiteWorld(controller.TurmiteWorldSettings)
+		if len(tMap.Turmites) == 0 {
+			tMap.AddTurmite(tMap.Width/2, tMap.Height/2)
+		}
+		controller.TurmiteWorld = &tMap
+	}
+}
+
+//Click Adds an ant to the clicked cell
+func (controller *TurmiteWorldController) Click(x int, y int) {
+	controller.AddTurmite(x, y)
+}
+
+func (controller *TurmiteWorldController) MarshalJSON() ([]byte, error) {
+
+	controller.GridRenderer.Surface = controller.TurmiteWorld.Surface()
+	render := controller.GridRenderer.Draw(controller)
+	render.TextBelowCanvas += fmt.Sprintf("<span>Rule: %s Ants: %d Steps: %d</span><br />",
+		controller.TurmiteWorld.Rule.Name, len(controller.TurmiteWorld.Turmites), controller.Steps)
+
+	return json.Marshal(render)
+}
+
+func (controller *TurmiteWorldController) RenderTile(x int, y int) color.RGBA {
+
+	if state, ok := controller.Cell(x, y); ok {
+		if controller.HasTurmite(x, y) {
+			return turmiteColor
+		}
+		return turmitePalette[int(state)%len(turmitePalette)]
+	}
+
+	return offGridColor
+}
+
+func (controller *TurmiteWorldController) PageLayout() WorldPageData {
+
+	settings := controller.TurmiteWorldSettings
+
+	return WorldPageData{
+		FormData: []FormData{
+			FormDataWidth(settings.Width, 2),
+			FormDataHeight(settings.Height, 2),
+			FormDataTurmiteRule(settings.Rule.Name, 3),
+			FormDataTurmites(settings.InitialTurmites, 1),
+			FormDataStepsPerUpdate(settings.StepsPerUpdate, 2),
+			FormDataTopology(settings.Topology, 2),
+		},
+	}
+}
+
+func (controller *TurmiteWorldController) HandleForm(values url.Values) bool {
+
+	fd := FormDataTurmiteRule("", 0)
+	if strings.Contains(values.Encode(), fd.Name) {
+
+		width, _ := strconv.ParseInt(values.Get(FormDataWidth(0, 0).Name), 10, 64)
+		height, _ := strconv.ParseInt(values.Get(FormDataHeight(0, 0).Name), 10, 64)
+		turmites, _ := strconv.ParseInt(values.Get(FormDataTurmites(0, 0).Name), 10, 64)
+		steps, _ := strconv.ParseInt(values.Get(FormDataStepsPerUpdate(0, 0).Name), 10, 64)
+		topology, _ := strconv.ParseInt(values.Get(FormDataTopology(geometry.Bounded, 0).Name), 10, 64)
+
+		controller.TurmiteWorldSettings.Width = int(width)
+		controller.TurmiteWorldSettings.Height = int(height)
+		controller.TurmiteWorldSettings.InitialTurmites = int(turmites)
+		controller.TurmiteWorldSettings.StepsPerUpdate = int(steps)
+		controller.TurmiteWorldSettings.Topology = geometry.Topology(topology)
+
+		rule, err := world.ParseTurmiteRule(values.Get(fd.Name))
+		if err == nil {
+			controller.TurmiteWorldSettings.Rule = rule
+		} else {
+			log.Printf("Unable to read turmite rule: %v", err)
+		}
+	}
+
+	controller.TurmiteWorld = nil
+	controller.Start()
+	return true
+}
@@ -0,0 +1,78 @@
+package geometry
+
+//octants the multipliers that turn the first octant into each of the eight octants around a position
+var octants = [8][4]int{
+	{1, 0, 0, 1},
+	{0, 1, 1, 0},
+	{0, -1, 1, 0},
+	{-1, 0, 0, 1},
+	{-1, 0, 0, -1},
+	{0, -1, -1, 0},
+	{0, 1, -1, 0},
+	{1, 0, 0, -1},
+}
+
+//FieldOfView Calls visible for every tile within the radius that can be seen from the origin using recursive
+//shadowcasting. Tiles that are opaque can be seen but block sight of the tiles behind them. A tile on the edge of two
+//octants may be passed to visible more than once
+func FieldOfView(origin Coordinates, radius int, opaque func(x int, y int) bool, visible func(x int, y int)) {
+
+	visible(origin.X, origin.Y)
+
+	for _, octant := range octants {
+		castLight(origin, radius, 1, 1.0, 0.0, octant, opaque, visible)
+	}
+}
+
+//castLight Scans one octant row by row away from the origin between the start and end slopes, scanning again past each
+//run of opaque tiles with the slopes narrowed to the light that gets round them
+func castLight(origin Coordinates, radius int, row int, start float64, end float64, octant [4]int,
+	opaque func(x int, y int) bool, visible func(x int, y int)) {
+
+	if start < end {
+		return
+	}
+
+	xx, xy, yx, yy := octant[0], octant[1], octant[2], octant[3]
+	newStart := 0.0
+
+	for distance := row; distance <= radius; distance++ {
+
+		blocked := false
+		dy := -distance
+
+		for dx := -distance; dx <= 0; dx++ {
+
+			leftSlope := (float64(dx) - 0.5) / (float64(dy) + 0.5)
+			rightSlope := (float64(dx) + 0.5) / (float64(dy) - 0.5)
+
+			if start < rightSlope {
+				continue
+			} else if end > leftSlope {
+				break
+			}
+
+			x, y := origin.X+dx*xx+dy*xy, origin.Y+dx*yx+dy*yy
+
+			if dx*dx+dy*dy <= radius*radius {
+				visible(x, y)
+			}
+
+			switch {
+			case blocked && opaque(x, y):
+				newStart = rightSlope
+			case blocked:
+				blocked = false
+				start = newStart
+			case opaque(x, y) && distance < radius:
+				blocked = true
+				castLight(origin, radius, distance+1, start, leftSlope, octant, opaque, visible)
+				newStart = rightSlope
+			}
+		}
+
+		if blocked {
+			return
+		}
+	}
+}
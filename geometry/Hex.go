@@ -0,0 +1,209 @@
+package geometry
+
+import "math"
+
+//Hex the position of a hexagon in a grid of pointy topped hexagons, using axial coordinates. Q counts hexagons along
+//a row and R counts rows, the third cube coordinate S is worked out from the other two
+type Hex struct {
+	Q int
+	R int
+}
+
+//HexDirections the step to each of the six neighbours of a Hex, going anticlockwise from the right
+var HexDirections = [6]Hex{{1, 0}, {1, -1}, {0, -1}, {-1, 0}, {-1, 1}, {0, 1}}
+
+//NewHex Returns a Hex at the given axial coordinates
+func NewHex(q int, r int) Hex {
+	return Hex{Q: q, R: r}
+}
+
+//HexFromCoordinates Returns a Hex using the X and Y of the Coordinates as Q and R
+func HexFromCoordinates(c Coordinates) Hex {
+	return Hex{Q: c.X, R: c.Y}
+}
+
+//HexFromOffset Returns the Hex in the given column and row of a grid where every odd row is shifted half a hexagon right
+func HexFromOffset(col int, row int) Hex {
+	return Hex{Q: col - (row-(row&1))/2, R: row}
+}
+
+//S Returns the third cube coordinate of the Hex, Q + R + S is always zero
+func (h Hex) S() int {
+	return -h.Q - h.R
+}
+
+//Coordinates Returns the Hex as Coordinates with Q as X and R as Y
+func (h Hex) Coordinates() Coordinates {
+	return Coordinates{X: h.Q, Y: h.R}
+}
+
+//Offset Returns the column and row of the Hex in a grid where every odd row is shifted half a hexagon right
+func (h Hex) Offset() (col int, row int) {
+	return h.Q + (h.R-(h.R&1))/2, h.R
+}
+
+//Add Returns the sum of two Hexes
+func (h Hex) Add(h2 Hex) Hex {
+	return Hex{Q: h.Q + h2.Q, R: h.R + h2.R}
+}
+
+//Subtract Returns the difference between two Hexes
+func (h Hex) Subtract(h2 Hex) Hex {
+	return Hex{Q: h.Q - h2.Q, R: h.R - h2.R}
+}
+
+//Scale Returns the Hex multiplied by k
+func (h Hex) Scale(k int) Hex {
+	return Hex{Q: h.Q * k, R: h.R * k}
+}
+
+//Neighbour Returns the neighbouring Hex in the given direction, an index into HexDirections
+func (h Hex) Neighbour(direction int) Hex {
+	return h.Add(HexDirections[((direction%6)+6)%6])
+}
+
+//Neighbours Returns the six neighbours of the Hex
+func (h Hex) Neighbours() []Hex {
+
+	neighbours := make([]Hex, len(HexDirections))
+
+	for i := range HexDirections {
+		neighbours[i] = h.Neighbour(i)
+	}
+
+	return neighbours
+}
+
+//HexDistance Returns the number of steps between two Hexes
+func HexDistance(h Hex, h2 Hex) int {
+	d := h.Subtract(h2)
+	return (Abs(d.Q) + Abs(d.R) + Abs(d.S())) / 2
+}
+
+//HexRound Returns the Hex holding the given fractional axial coordinates
+func HexRound(q float64, r float64) Hex {
+
+	s := -q - r
+	rq, rr, rs := math.Round(q), math.Round(r), math.Round(s)
+	dq, dr, ds := math.Abs(rq-q), math.Abs(rr-r), math.Abs(rs-s)
+
+	switch {
+	case dq > dr && dq > ds:
+		rq = -rr - rs
+	case dr > ds:
+		rr = -rq - rs
+	}
+
+	return Hex{Q: int(rq), R: int(rr)}
+}
+
+//HexLine Returns every Hex on the straight line between two Hexes, including both ends
+func HexLine(h Hex, h2 Hex) []Hex {
+
+	n := HexDistance(h, h2)
+	line := make([]Hex, 0, n+1)
+
+	if n == 0 {
+		return append(line, h)
+	}
+
+	//Nudged slightly so points exactly between two hexagons always round the same way
+	q, r := float64(h.Q)+1e-6, float64(h.R)+1e-6
+	q2, r2 := float64(h2.Q)+1e-6, float64(h2.R)+1e-6
+
+	for i := 0; i <= n; i++ {
+		t := float64(i) / float64(n)
+		line = append(line, HexRound(q+(q2-q)*t, r+(r2-r)*t))
+	}
+
+	return line
+}
+
+//NextStep Returns the step to one of the six neighbours that is next along the line to the target. Returns a zero step
+//if the Hex is the target
+func (h Hex) NextStep(target Hex) Hex {
+
+	if h == target {
+		return Hex{}
+	}
+
+	return HexLine(h, target)[1].Subtract(h)
+}
+
+//HexRing steps through every Hex a fixed distance from a centre Hex
+type HexRing struct {
+	radius  int
+	current Hex
+	side    int
+	step    int
+	done    bool
+}
+
+//NewHexRing Returns a HexRing around the centre. A ring with a radius of zero is only the centre
+func NewHexRing(center Hex, radius int) HexRing {
+	return HexRing{
+		radius:  radius,
+		current: center.Add(HexDirections[4].Scale(radius)),
+		done:    radius < 0,
+	}
+}
+
+//Next Gets the next Hex in the ring. If there is no next Hex, returns false
+func (ring *HexRing) Next() (Hex, bool) {
+
+	if ring.done {
+		return Hex{}, false
+	}
+
+	h := ring.current
+
+	if ring.radius == 0 {
+		ring.done = true
+		return h, true
+	}
+
+	ring.current = ring.current.Neighbour(ring.side)
+	ring.step++
+
+	if ring.step == ring.radius {
+		ring.step = 0
+		ring.side++
+		ring.done = ring.side == len(HexDirections)
+	}
+
+	return h, true
+}
+
+//HexSpiral steps through every Hex within a distance of a centre Hex, ring by ring from the centre outwards so nearer
+//Hexes always come first
+type HexSpiral struct {
+	center    Hex
+	radius    int
+	maxRadius int
+	ring      HexRing
+}
+
+//NewHexSpiral Returns a HexSpiral covering every Hex up to maxRadius steps from the centre
+func NewHexSpiral(center Hex, maxRadius int) HexSpiral {
+	return HexSpiral{
+		center:    center,
+		maxRadius: maxRadius,
+		ring:      NewHexRing(center, 0),
+	}
+}
+
+//Next Gets the next Hex in the spiral. If there is no next Hex, returns false
+func (s *HexSpiral) Next() (Hex, bool) {
+
+	for s.radius <= s.maxRadius {
+
+		if h, ok := s.ring.Next(); ok {
+			return h, true
+		}
+
+		s.radius++
+		s.ring = NewHexRing(s.center, s.radius)
+	}
+
+	return Hex{}, false
+}
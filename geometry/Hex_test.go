@@ -0,0 +1,135 @@
+package geometry
+
+import (
+	"testing"
+)
+
+func TestHexDistance(t *testing.T) {
+
+	tests := []struct {
+		name string
+		h    Hex
+		h2   Hex
+		want int
+	}{
+		{"Same Hex", NewHex(2, 3), NewHex(2, 3), 0},
+		{"Neighbour", NewHex(0, 0), NewHex(1, -1), 1},
+		{"Along a row", NewHex(-2, 0), NewHex(3, 0), 5},
+		{"Diagonal", NewHex(0, 0), NewHex(3, -1), 3},
+		{"Opposite diagonal", NewHex(0, 0), NewHex(3, 3), 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HexDistance(tt.h, tt.h2); got != tt.want {
+				t.Errorf("HexDistance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHex_Neighbours(t *testing.T) {
+
+	h := NewHex(4, -2)
+
+	for _, n := range h.Neighbours() {
+		if HexDistance(h, n) != 1 {
+			t.Errorf("Hex.Neighbours() %v is not next to %v", n, h)
+		}
+	}
+
+	if h.Neighbour(6) != h.Neighbour(0) || h.Neighbour(-1) != h.Neighbour(5) {
+		t.Errorf("Hex.Neighbour() directions should wrap round")
+	}
+}
+
+func TestHex_Offset(t *testing.T) {
+
+	for row := -3; row <= 3; row++ {
+		for col := -3; col <= 3; col++ {
+
+			h := HexFromOffset(col, row)
+
+			if gotCol, gotRow := h.Offset(); gotCol != col || gotRow != row {
+				t.Errorf("HexFromOffset(%d, %d).Offset() = %d, %d", col, row, gotCol, gotRow)
+			}
+		}
+	}
+}
+
+func TestHexRing_Next(t *testing.T) {
+
+	center := NewHex(1, 1)
+
+	for radius := 0; radius < 5; radius++ {
+
+		ring := NewHexRing(center, radius)
+		seen := map[Hex]bool{}
+
+		for h, ok := ring.Next(); ok; h, ok = ring.Next() {
+			if HexDistance(center, h) != radius {
+				t.Errorf("HexRing.Next() %v is not %d from the centre", h, radius)
+			}
+			seen[h] = true
+		}
+
+		want := 6 * radius
+		if radius == 0 {
+			want = 1
+		}
+
+		if len(seen) != want {
+			t.Errorf("HexRing of radius %d has %d hexes, want %d", radius, len(seen), want)
+		}
+	}
+}
+
+func TestHexSpiral_Next(t *testing.T) {
+
+	spiral := NewHexSpiral(NewHex(0, 0), 3)
+	count, last := 0, 0
+
+	for h, ok := spiral.Next(); ok; h, ok = spiral.Next() {
+
+		distance := HexDistance(NewHex(0, 0), h)
+
+		if distance < last {
+			t.Errorf("HexSpiral.Next() %v is nearer than the hex before it", h)
+		}
+
+		last = distance
+		count++
+	}
+
+	if count != 37 {
+		t.Errorf("HexSpiral of radius 3 has %d hexes, want 37", count)
+	}
+}
+
+func TestHexLine(t *testing.T) {
+
+	h, h2 := NewHex(0, 0), NewHex(4, -2)
+	line := HexLine(h, h2)
+
+	if len(line) != 5 || line[0] != h || line[4] != h2 {
+		t.Fatalf("HexLine() = %v", line)
+	}
+
+	for i := 1; i < len(line); i++ {
+		if HexDistance(line[i-1], line[i]) != 1 {
+			t.Errorf("HexLine() steps from %v to %v", line[i-1], line[i])
+		}
+	}
+}
+
+func TestHex_NextStep(t *testing.T) {
+
+	h, target := NewHex(0, 0), NewHex(-3, 3)
+
+	if got := h.NextStep(target); got != NewHex(-1, 1) {
+		t.Errorf("Hex.NextStep() = %v, want %v", got, NewHex(-1, 1))
+	}
+
+	if got := h.NextStep(h); got != (Hex{}) {
+		t.Errorf("Hex.NextStep() to itself = %v, want no step", got)
+	}
+}
@@ -0,0 +1,317 @@
+package geometry
+
+import "container/heap"
+
+//DefaultQuadTreeCapacity the number of points a QuadTree node holds before it splits into four
+const DefaultQuadTreeCapacity = 8
+
+//QuadTree a spatial index of Coordinates. Each node covers a rectangle and splits into four quadrants once it holds
+//more than its capacity, quadrants are merged back together when enough points are removed. Each point can only be
+//stored once. QuadTree is not safe for concurrent writes but can be read from many goroutines at once
+type QuadTree struct {
+	root     *quadTreeNode
+	capacity int
+}
+
+type quadTreeNode struct {
+	bounds   Rectangle
+	points   []Coordinates
+	children []*quadTreeNode
+	count    int
+}
+
+//NewQuadTree Returns an empty QuadTree covering the given area. Nodes split once they hold more than capacity points
+func NewQuadTree(x int, y int, width int, height int, capacity int) QuadTree {
+
+	if capacity < 1 {
+		capacity = DefaultQuadTreeCapacity
+	}
+
+	return QuadTree{
+		root:     &quadTreeNode{bounds: NewRectangle(x, y, width, height)},
+		capacity: capacity,
+	}
+}
+
+//Len Returns the number of points in the QuadTree
+func (tree *QuadTree) Len() int {
+	return tree.root.count
+}
+
+//Insert Adds a point to the QuadTree. Returns false if the point is outside the QuadTree or already in it
+func (tree *QuadTree) Insert(c Coordinates) bool {
+	if !tree.root.bounds.Contains(c.X, c.Y) {
+		return false
+	}
+	return tree.root.insert(c, tree.capacity)
+}
+
+//Remove Removes a point from the QuadTree. Returns false if the point was not in the QuadTree
+func (tree *QuadTree) Remove(c Coordinates) bool {
+	if !tree.root.bounds.Contains(c.X, c.Y) {
+		return false
+	}
+	return tree.root.remove(c, tree.capacity)
+}
+
+//Contains Returns true if the point is in the QuadTree
+func (tree *QuadTree) Contains(x int, y int) bool {
+
+	node := tree.root
+
+	if !node.bounds.Contains(x, y) {
+		return false
+	}
+
+	for node.children != nil {
+		node = node.child(x, y)
+	}
+
+	return indexOf(node.points, Coordinates{x, y}) >= 0
+}
+
+//Depth Returns the number of levels of the QuadTree
+func (tree *QuadTree) Depth() int {
+	return tree.root.depth()
+}
+
+//Nearest Returns up to k points that are inside the search area and pass the filter, nearest first by the same
+//distance as SortByNearestFromCoordinate. The filter may be nil. Nodes are visited nearest first so only the part of
+//the QuadTree around the position is searched
+func (tree *QuadTree) Nearest(position Coordinates, k int, area Rectangle, filter func(Coordinates) bool) []Coordinates {
+
+	found := []Coordinates{}
+
+	if k <= 0 {
+		return found
+	}
+
+	queue := quadTreeQueue{{node: tree.root, distance: tree.root.distance(position)}}
+
+	for queue.Len() > 0 {
+
+		item := heap.Pop(&queue).(quadTreeQueueItem)
+
+		if item.node == nil {
+			if filter == nil || filter(item.point) {
+				found = append(found, item.point)
+				if len(found) >= k {
+					break
+				}
+			}
+			continue
+		}
+
+		node := item.node
+
+		if node.count == 0 || !node.bounds.overlaps(area) {
+			continue
+		}
+
+		for _, child := range node.children {
+			heap.Push(&queue, quadTreeQueueItem{node: child, distance: child.distance(position)})
+		}
+
+		for _, point := range node.points {
+			if area.Contains(point.X, point.Y) {
+				heap.Push(&queue, quadTreeQueueItem{point: point, distance: manhattanDistance(position, point)})
+			}
+		}
+	}
+
+	return found
+}
+
+func (node *quadTreeNode) insert(c Coordinates, capacity int) bool {
+
+	if node.children != nil {
+		if node.child(c.X, c.Y).insert(c, capacity) {
+			node.count++
+			return true
+		}
+		return false
+	}
+
+	if indexOf(node.points, c) >= 0 {
+		return false
+	}
+
+	node.points = append(node.points, c)
+	node.count++
+
+	if len(node.points) > capacity && (node.bounds.width > 1 || node.bounds.height > 1) {
+		node.split(capacity)
+	}
+
+	return true
+}
+
+func (node *quadTreeNode) remove(c Coordinates, capacity int) bool {
+
+	if node.children != nil {
+
+		if !node.child(c.X, c.Y).remove(c, capacity) {
+			return false
+		}
+
+		node.count--
+
+		if node.count <= capacity/2 {
+			node.merge()
+		}
+
+		return true
+	}
+
+	i := indexOf(node.points, c)
+
+	if i < 0 {
+		return false
+	}
+
+	last := len(node.points) - 1
+	node.points[i] = node.points[last]
+	node.points = node.points[:last]
+	node.count--
+
+	return true
+}
+
+//split Moves the node's points into four new quadrants
+func (node *quadTreeNode) split(capacity int) {
+
+	b := node.bounds
+	halfWidth, halfHeight := (b.width+1)/2, (b.height+1)/2
+
+	quadrants := []Rectangle{
+		NewRectangle(b.x, b.y, halfWidth, halfHeight),
+		NewRectangle(b.x+halfWidth, b.y, b.width-halfWidth, halfHeight),
+		NewRectangle(b.x, b.y+halfHeight, halfWidth, b.height-halfHeight),
+		NewRectangle(b.x+halfWidth, b.y+halfHeight, b.width-halfWidth, b.height-halfHeight),
+	}
+
+	node.children = make([]*quadTreeNode, 0, len(quadrants))
+
+	for _, quadrant := range quadrants {
+		if quadrant.width > 0 && quadrant.height > 0 {
+			node.children = append(node.children, &quadTreeNode{bounds: quadrant})
+		}
+	}
+
+	points := node.points
+	node.points = nil
+	node.count = 0
+
+	for _, point := range points {
+		node.insert(point, capacity)
+	}
+}
+
+//merge Moves every point below the node back into the node and removes its quadrants
+func (node *quadTreeNode) merge() {
+
+	points := make([]Coordinates, 0, node.count)
+	node.collect(&points)
+
+	node.points = points
+	node.children = nil
+}
+
+func (node *quadTreeNode) collect(points *[]Coordinates) {
+	*points = append(*points, node.points...)
+	for _, child := range node.children {
+		child.collect(points)
+	}
+}
+
+func (node *quadTreeNode) child(x int, y int) *quadTreeNode {
+	for _, child := range node.children {
+		if child.bounds.Contains(x, y) {
+			return child
+		}
+	}
+	return nil
+}
+
+func (node *quadTreeNode) depth() int {
+
+	deepest := 0
+
+	for _, child := range node.children {
+		if d := child.depth(); d > deepest {
+			deepest = d
+		}
+	}
+
+	return deepest + 1
+}
+
+//distance Returns the distance from the position to the closest tile in the node
+func (node *quadTreeNode) distance(position Coordinates) int {
+
+	b := node.bounds
+
+	dx, dy := 0, 0
+
+	if position.X < b.x {
+		dx = b.x - position.X
+	} else if position.X >= b.x+b.width {
+		dx = position.X - (b.x + b.width - 1)
+	}
+
+	if position.Y < b.y {
+		dy = b.y - position.Y
+	} else if position.Y >= b.y+b.height {
+		dy = position.Y - (b.y + b.height - 1)
+	}
+
+	return dx + dy
+}
+
+//overlaps Returns true if the two Rectangles share any tiles
+func (r *Rectangle) overlaps(r2 Rectangle) bool {
+	return r.x < r2.x+r2.width && r2.x < r.x+r.width && r.y < r2.y+r2.height && r2.y < r.y+r.height
+}
+
+func manhattanDistance(c Coordinates, c2 Coordinates) int {
+	return Abs(c.X-c2.X) + Abs(c.Y-c2.Y)
+}
+
+func indexOf(points []Coordinates, c Coordinates) int {
+	for i, point := range points {
+		if point == c {
+			return i
+		}
+	}
+	return -1
+}
+
+//quadTreeQueueItem either a node or a point waiting to be visited by Nearest
+type quadTreeQueueItem struct {
+	node     *quadTreeNode
+	point    Coordinates
+	distance int
+}
+
+//quadTreeQueue a priority queue of nodes and points, nearest first. Points come before nodes at the same distance
+type quadTreeQueue []quadTreeQueueItem
+
+func (q quadTreeQueue) Len() int { return len(q) }
+
+func (q quadTreeQueue) Less(i, j int) bool {
+	if q[i].distance == q[j].distance {
+		return q[i].node == nil && q[j].node != nil
+	}
+	return q[i].distance < q[j].distance
+}
+
+func (q quadTreeQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *quadTreeQueue) Push(x interface{}) { *q = append(*q, x.(quadTreeQueueItem)) }
+
+func (q *quadTreeQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,163 @@
+package geometry
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestQuadTree_InsertAndRemove(t *testing.T) {
+
+	tree := NewQuadTree(0, 0, 100, 100, 4)
+
+	points := GenerateRandomizedCoordinateArray(0, 0, 100, 100)[:500]
+
+	for _, point := range points {
+		if !tree.Insert(point) {
+			t.Fatalf("QuadTree.Insert(%v) = false, want true", point)
+		}
+	}
+
+	if tree.Len() != len(points) {
+		t.Errorf("QuadTree.Len() = %v, want %v", tree.Len(), len(points))
+	}
+
+	if tree.Depth() <= 1 {
+		t.Errorf("QuadTree.Depth() = %v, want the tree to have split", tree.Depth())
+	}
+
+	if tree.Insert(points[0]) {
+		t.Errorf("QuadTree.Insert() of a duplicate point = true, want false")
+	}
+
+	if tree.Insert(Coordinates{100, 0}) {
+		t.Errorf("QuadTree.Insert() outside the tree = true, want false")
+	}
+
+	for _, point := range points {
+		if !tree.Contains(point.X, point.Y) {
+			t.Fatalf("QuadTree.Contains(%v) = false, want true", point)
+		}
+		if !tree.Remove(point) {
+			t.Fatalf("QuadTree.Remove(%v) = false, want true", point)
+		}
+		if tree.Contains(point.X, point.Y) {
+			t.Fatalf("QuadTree.Contains(%v) after Remove = true, want false", point)
+		}
+	}
+
+	if tree.Len() != 0 {
+		t.Errorf("QuadTree.Len() = %v, want 0", tree.Len())
+	}
+
+	if tree.Depth() != 1 {
+		t.Errorf("QuadTree.Depth() = %v, want the tree to have merged to 1", tree.Depth())
+	}
+}
+
+func TestQuadTree_Nearest(t *testing.T) {
+
+	tree := NewQuadTree(0, 0, 20, 20, 2)
+
+	for _, point := range []Coordinates{{1, 1}, {5, 5}, {6, 5}, {10, 10}, {19, 19}, {4, 5}} {
+		tree.Insert(point)
+	}
+
+	everywhere := NewRectangle(0, 0, 20, 20)
+	notX4 := func(c Coordinates) bool { return c.X != 4 }
+
+	tests := []struct {
+		name     string
+		position Coordinates
+		k        int
+		area     Rectangle
+		filter   func(Coordinates) bool
+		want     []Coordinates
+	}{
+		{"Nearest point", Coordinates{9, 9}, 1, everywhere, nil, []Coordinates{{10, 10}}},
+		{"Nearest three", Coordinates{5, 6}, 3, everywhere, nil, []Coordinates{{5, 5}, {6, 5}, {4, 5}}},
+		{"Filtered", Coordinates{5, 6}, 3, everywhere, notX4, []Coordinates{{5, 5}, {6, 5}, {1, 1}}},
+		{"Outside of area is ignored", Coordinates{0, 0}, 2, NewRectangle(8, 8, 12, 12), nil, []Coordinates{{10, 10}, {19, 19}}},
+		{"More than the tree holds", Coordinates{0, 0}, 10, everywhere, nil, nil},
+		{"Zero", Coordinates{0, 0}, 0, everywhere, nil, []Coordinates{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			got := tree.Nearest(tt.position, tt.k, tt.area, tt.filter)
+
+			if tt.want == nil {
+				if len(got) != tree.Len() {
+					t.Errorf("QuadTree.Nearest() returned %v points, want %v", len(got), tree.Len())
+				}
+				return
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("QuadTree.Nearest() = %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if manhattanDistance(tt.position, got[i]) != manhattanDistance(tt.position, tt.want[i]) {
+					t.Errorf("QuadTree.Nearest() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestQuadTree_NearestMatchesSort(t *testing.T) {
+
+	tree := NewQuadTree(0, 0, 200, 200, DefaultQuadTreeCapacity)
+	points := GenerateRandomizedCoordinateArray(0, 0, 200, 200)[:2000]
+
+	for _, point := range points {
+		tree.Insert(point)
+	}
+
+	for i := 0; i < 50; i++ {
+
+		position := Coordinates{rand.Intn(200), rand.Intn(200)}
+		area := NewRectangle(position.X-12, position.Y-12, 25, 25)
+
+		inArea := []Coordinates{}
+		for _, point := range points {
+			if area.Contains(point.X, point.Y) {
+				inArea = append(inArea, point)
+			}
+		}
+		SortByNearestFromCoordinate(position, inArea)
+
+		got := tree.Nearest(position, 5, area, nil)
+
+		if len(inArea) > 5 {
+			inArea = inArea[:5]
+		}
+
+		if len(got) != len(inArea) {
+			t.Fatalf("QuadTree.Nearest() = %v, want %v", got, inArea)
+		}
+
+		for j := range got {
+			if manhattanDistance(position, got[j]) != manhattanDistance(position, inArea[j]) {
+				t.Fatalf("QuadTree.Nearest() = %v, want %v", got, inArea)
+			}
+		}
+	}
+}
+
+func BenchmarkQuadTreeNearest(b *testing.B) {
+
+	tree := NewQuadTree(0, 0, 1000, 1000, DefaultQuadTreeCapacity)
+
+	for _, point := range GenerateRandomizedCoordinateArray(0, 0, 1000, 1000)[:100000] {
+		tree.Insert(point)
+	}
+
+	area := NewRectangle(0, 0, 1000, 1000)
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		tree.Nearest(Coordinates{rand.Intn(1000), rand.Intn(1000)}, 1, area, nil)
+	}
+}
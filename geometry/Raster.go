@@ -0,0 +1,226 @@
+package geometry
+
+//LinePoints Returns the tiles on the straight line from one position to the other using Bresenham's algorithm,
+//including both ends. Each tile touches the one before it on a side or a corner
+func LinePoints(from Coordinates, to Coordinates) []Coordinates {
+
+	dx, dy := Abs(to.X-from.X), -Abs(to.Y-from.Y)
+	stepX, stepY := 1, 1
+
+	if from.X > to.X {
+		stepX = -1
+	}
+
+	if from.Y > to.Y {
+		stepY = -1
+	}
+
+	points := make([]Coordinates, 0, dx-dy+1)
+	x, y, err := from.X, from.Y, dx+dy
+
+	for {
+		points = append(points, Coordinates{x, y})
+
+		if x == to.X && y == to.Y {
+			return points
+		}
+
+		e2 := 2 * err
+
+		if e2 >= dy {
+			err += dy
+			x += stepX
+		}
+
+		if e2 <= dx {
+			err += dx
+			y += stepY
+		}
+	}
+}
+
+//CircleOutline Returns the tiles on the edge of a circle using the midpoint circle algorithm, each tile once
+func CircleOutline(center Coordinates, radius int) []Coordinates {
+
+	points := pointSet{}
+
+	if radius <= 0 {
+		points.add(center)
+		return points.list
+	}
+
+	x, y, err := radius, 0, 1-radius
+
+	for x >= y {
+
+		for _, c := range []Coordinates{{x, y}, {y, x}, {-y, x}, {-x, y}, {-x, -y}, {-y, -x}, {y, -x}, {x, -y}} {
+			points.add(Add(center, c))
+		}
+
+		y++
+
+		if err < 0 {
+			err += 2*y + 1
+		} else {
+			x--
+			err += 2*(y-x) + 1
+		}
+	}
+
+	return points.list
+}
+
+//CirclePoints Returns every tile inside the CircleOutline of the same centre and radius, including the outline
+func CirclePoints(center Coordinates, radius int) []Coordinates {
+
+	minX, maxX := map[int]int{}, map[int]int{}
+
+	for _, c := range CircleOutline(center, radius) {
+		if x, ok := minX[c.Y]; !ok || c.X < x {
+			minX[c.Y] = c.X
+		}
+		if x, ok := maxX[c.Y]; !ok || c.X > x {
+			maxX[c.Y] = c.X
+		}
+	}
+
+	points := []Coordinates{}
+
+	for y := center.Y - radius; y <= center.Y+radius; y++ {
+		for x := minX[y]; x <= maxX[y]; x++ {
+			points = append(points, Coordinates{x, y})
+		}
+	}
+
+	return points
+}
+
+//PolygonOutline Returns the tiles on the lines between each corner of a polygon and the next, each tile once. The last
+//corner is joined back to the first
+func PolygonOutline(corners ...Coordinates) []Coordinates {
+
+	points := pointSet{}
+
+	for i := range corners {
+		for _, c := range LinePoints(corners[i], corners[(i+1)%len(corners)]) {
+			points.add(c)
+		}
+	}
+
+	return points.list
+}
+
+//PolygonPoints Returns every tile inside a polygon with the given corners, including its PolygonOutline. Tiles are
+//inside if their centre is, using the even-odd rule
+func PolygonPoints(corners ...Coordinates) []Coordinates {
+
+	points := pointSet{}
+
+	for _, c := range PolygonOutline(corners...) {
+		points.add(c)
+	}
+
+	if len(corners) < 3 {
+		return points.list
+	}
+
+	minX, minY, maxX, maxY := corners[0].X, corners[0].Y, corners[0].X, corners[0].Y
+
+	for _, c := range corners {
+		if c.X < minX {
+			minX = c.X
+		}
+		if c.X > maxX {
+			maxX = c.X
+		}
+		if c.Y < minY {
+			minY = c.Y
+		}
+		if c.Y > maxY {
+			maxY = c.Y
+		}
+	}
+
+	polygon := NewPolygon(corners...)
+
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			if polygon.Contains(x, y) {
+				points.add(Coordinates{x, y})
+			}
+		}
+	}
+
+	return points.list
+}
+
+//FloodFill Returns every tile that can be reached from the start by stepping up, down, left or right onto tiles that
+//are included. The start is only filled if it is included. The area reached must be finite
+func FloodFill(start Coordinates, include func(x int, y int) bool) []Coordinates {
+
+	points := pointSet{}
+
+	if !include(start.X, start.Y) {
+		return points.list
+	}
+
+	points.add(start)
+
+	for i := 0; i < len(points.list); i++ {
+
+		c := points.list[i]
+
+		for _, next := range []Coordinates{{c.X + 1, c.Y}, {c.X - 1, c.Y}, {c.X, c.Y + 1}, {c.X, c.Y - 1}} {
+			if !points.has(next) && include(next.X, next.Y) {
+				points.add(next)
+			}
+		}
+	}
+
+	return points.list
+}
+
+//Points a Shape made of a set of tiles, such as those returned by LinePoints or FloodFill
+type Points map[Coordinates]bool
+
+//NewPoints Returns a Shape of every tile in the given lists
+func NewPoints(lists ...[]Coordinates) Points {
+
+	points := Points{}
+
+	for _, list := range lists {
+		for _, c := range list {
+			points[c] = true
+		}
+	}
+
+	return points
+}
+
+//Contains Returns true if x and y is one of the Points
+func (p Points) Contains(x int, y int) bool {
+	return p[Coordinates{x, y}]
+}
+
+//pointSet a list of tiles in the order they were added, without repeats
+type pointSet struct {
+	list []Coordinates
+	seen map[Coordinates]bool
+}
+
+func (s *pointSet) add(c Coordinates) {
+
+	if s.seen == nil {
+		s.seen = map[Coordinates]bool{}
+		s.list = []Coordinates{}
+	}
+
+	if !s.seen[c] {
+		s.seen[c] = true
+		s.list = append(s.list, c)
+	}
+}
+
+func (s *pointSet) has(c Coordinates) bool {
+	return s.seen[c]
+}
@@ -0,0 +1,126 @@
+package geometry
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLinePoints(t *testing.T) {
+
+	tests := []struct {
+		name string
+		from Coordinates
+		to   Coordinates
+		want []Coordinates
+	}{
+		{"Single tile", Coordinates{2, 2}, Coordinates{2, 2}, []Coordinates{{2, 2}}},
+		{"Horizontal", Coordinates{0, 0}, Coordinates{3, 0}, []Coordinates{{0, 0}, {1, 0}, {2, 0}, {3, 0}}},
+		{"Diagonal backwards", Coordinates{2, 2}, Coordinates{0, 0}, []Coordinates{{2, 2}, {1, 1}, {0, 0}}},
+		{"Shallow", Coordinates{0, 0}, Coordinates{4, 2}, []Coordinates{{0, 0}, {1, 1}, {2, 1}, {3, 2}, {4, 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LinePoints(tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LinePoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCirclePoints(t *testing.T) {
+
+	center := Coordinates{5, -3}
+	outline := NewPoints(CircleOutline(center, 4))
+	filled := NewPoints(CirclePoints(center, 4))
+
+	for c := range outline {
+		if !filled[c] {
+			t.Errorf("CirclePoints() is missing outline tile %v", c)
+		}
+	}
+
+	for _, c := range []Coordinates{{9, -3}, {1, -3}, {5, 1}, {5, -7}} {
+		if !outline[c] {
+			t.Errorf("CircleOutline() is missing %v", c)
+		}
+	}
+
+	if outline[center] || !filled[center] {
+		t.Errorf("Only the filled circle should contain the centre")
+	}
+
+	if filled.Contains(9, 1) {
+		t.Errorf("CirclePoints() should not contain the corner of its bounding square")
+	}
+}
+
+func TestPolygonPoints(t *testing.T) {
+
+	square := []Coordinates{{0, 0}, {4, 0}, {4, 4}, {0, 4}}
+
+	if got := len(PolygonOutline(square...)); got != 16 {
+		t.Errorf("PolygonOutline() of a 5x5 square has %d tiles, want 16", got)
+	}
+
+	if got := len(PolygonPoints(square...)); got != 25 {
+		t.Errorf("PolygonPoints() of a 5x5 square has %d tiles, want 25", got)
+	}
+
+	triangle := NewPoints(PolygonPoints(Coordinates{0, 0}, Coordinates{10, 0}, Coordinates{0, 10}))
+
+	if !triangle.Contains(2, 2) || triangle.Contains(8, 8) {
+		t.Errorf("PolygonPoints() of a triangle should contain (2,2) but not (8,8)")
+	}
+}
+
+func TestFloodFill(t *testing.T) {
+
+	room := NewRectangle(0, 0, 5, 5)
+	wall := NewLine(2, 0, 2, 4)
+
+	include := func(x int, y int) bool {
+		return room.Contains(x, y) && !wall.Contains(x, y)
+	}
+
+	if got := len(FloodFill(Coordinates{0, 0}, include)); got != 10 {
+		t.Errorf("FloodFill() left of the wall filled %d tiles, want 10", got)
+	}
+
+	if got := len(FloodFill(Coordinates{2, 2}, include)); got != 0 {
+		t.Errorf("FloodFill() from inside the wall filled %d tiles, want 0", got)
+	}
+}
+
+func TestFieldOfView(t *testing.T) {
+
+	pillar := Coordinates{3, 0}
+
+	opaque := func(x int, y int) bool {
+		return x == pillar.X && y == pillar.Y
+	}
+
+	seen := Points{}
+	FieldOfView(Coordinates{0, 0}, 6, opaque, func(x int, y int) {
+		seen[Coordinates{x, y}] = true
+	})
+
+	tests := []struct {
+		name string
+		c    Coordinates
+		want bool
+	}{
+		{"Origin", Coordinates{0, 0}, true},
+		{"Pillar", pillar, true},
+		{"Behind the pillar", Coordinates{5, 0}, false},
+		{"Beside the pillar", Coordinates{3, 2}, true},
+		{"Behind the origin", Coordinates{-5, 0}, true},
+		{"Out of range", Coordinates{5, 5}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if seen[tt.c] != tt.want {
+				t.Errorf("FieldOfView() saw %v = %v, want %v", tt.c, seen[tt.c], tt.want)
+			}
+		})
+	}
+}
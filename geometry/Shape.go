@@ -25,3 +25,126 @@ func (r *Rectangle) Contains(x int, y int) bool {
 	}
 	return true
 }
+
+//Shape anything that can say whether it contains a tile
+type Shape interface {
+	Contains(x int, y int) bool
+}
+
+//lineHalfWidth tiles closer than this to a Line or the edge of a Polygon are part of it. It is wide enough that
+//diagonal lines have no gaps that can be slipped through diagonally
+const lineHalfWidth = 0.75
+
+//Circle a filled circle of tiles
+type Circle struct {
+	Center Coordinates
+	Radius float64
+}
+
+//NewCircle Returns a Circle centred on x and y
+func NewCircle(x int, y int, radius float64) Circle {
+	return Circle{Center: Coordinates{x, y}, Radius: radius}
+}
+
+//Contains Returns true if x and y are no further than the Radius from the Center
+func (c Circle) Contains(x int, y int) bool {
+	dx, dy := float64(x-c.Center.X), float64(y-c.Center.Y)
+	return dx*dx+dy*dy <= c.Radius*c.Radius
+}
+
+//Line a straight line of tiles between two Coordinates
+type Line struct {
+	From Coordinates
+	To   Coordinates
+}
+
+//NewLine Returns a Line from x1, y1 to x2, y2
+func NewLine(x1 int, y1 int, x2 int, y2 int) Line {
+	return Line{From: Coordinates{x1, y1}, To: Coordinates{x2, y2}}
+}
+
+//Contains Returns true if x and y are on the Line
+func (l Line) Contains(x int, y int) bool {
+	return segmentDistance(Coordinates{x, y}.ToVector(), l.From.ToVector(), l.To.ToVector()) <= lineHalfWidth
+}
+
+//Polygon a filled shape with straight edges between its Points
+type Polygon struct {
+	Points []Coordinates
+}
+
+//NewPolygon Returns a Polygon with the given corners, in order
+func NewPolygon(points ...Coordinates) Polygon {
+	return Polygon{Points: points}
+}
+
+//Contains Returns true if x and y are inside the Polygon or on one of its edges
+func (p Polygon) Contains(x int, y int) bool {
+
+	point := Coordinates{x, y}.ToVector()
+	inside := false
+
+	for i := range p.Points {
+
+		a := p.Points[i].ToVector()
+		b := p.Points[(i+1)%len(p.Points)].ToVector()
+
+		if segmentDistance(point, a, b) <= lineHalfWidth {
+			return true
+		}
+
+		//Even-odd rule, count the edges crossed by a ray from the point towards positive x
+		if (a.Y > point.Y) != (b.Y > point.Y) && point.X < a.X+(point.Y-a.Y)/(b.Y-a.Y)*(b.X-a.X) {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+//Union a Shape made of every tile that is in at least one of the Shapes
+type Union []Shape
+
+//Contains Returns true if any of the Shapes contain x and y
+func (u Union) Contains(x int, y int) bool {
+	for _, shape := range u {
+		if shape.Contains(x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+//Intersection a Shape made of the tiles that are in all of the Shapes
+type Intersection []Shape
+
+//Contains Returns true if all of the Shapes contain x and y
+func (in Intersection) Contains(x int, y int) bool {
+	for _, shape := range in {
+		if !shape.Contains(x, y) {
+			return false
+		}
+	}
+	return true
+}
+
+//segmentDistance Returns the distance from the point to the closest point on the line segment between a and b
+func segmentDistance(point Vector, a Vector, b Vector) float64 {
+
+	ab := b.Sub(a)
+	lengthSquared := ab.Dot(ab)
+
+	if lengthSquared == 0 {
+		return point.Sub(a).Length()
+	}
+
+	t := point.Sub(a).Dot(ab) / lengthSquared
+
+	if t < 0 {
+		t = 0
+	} else if t > 1 {
+		t = 1
+	}
+
+	return point.Sub(a.Add(ab.Scale(t))).Length()
+}
@@ -66,3 +66,69 @@ func TestRectangle_Contains(t *testing.T) {
 		})
 	}
 }
+
+func TestShapes_Contains(t *testing.T) {
+	type args struct {
+		x    int
+		y    int
+		want bool
+	}
+	tests := []struct {
+		name  string
+		shape Shape
+		args  []args
+	}{
+		{"Circle", NewCircle(5, 5, 2),
+			[]args{
+				{5, 5, true},
+				{7, 5, true},
+				{7, 7, false},
+			},
+		},
+		{"Horizontal Line", NewLine(0, 2, 10, 2),
+			[]args{
+				{5, 2, true},
+				{5, 3, false},
+				{11, 2, false},
+			},
+		},
+		{"Diagonal Line Has No Diagonal Gaps", NewLine(0, 0, 5, 5),
+			[]args{
+				{2, 2, true},
+				{3, 2, true},
+				{4, 2, false},
+			},
+		},
+		{"Triangle", NewPolygon(Coordinates{0, 0}, Coordinates{10, 0}, Coordinates{0, 10}),
+			[]args{
+				{2, 2, true},
+				{10, 0, true},
+				{6, 6, false},
+				{-2, 5, false},
+			},
+		},
+		{"Union", Union{NewCircle(0, 0, 1), NewCircle(10, 0, 1)},
+			[]args{
+				{0, 0, true},
+				{10, 0, true},
+				{5, 0, false},
+			},
+		},
+		{"Intersection", Intersection{&Rectangle{0, 0, 10, 10}, NewCircle(0, 0, 5)},
+			[]args{
+				{1, 1, true},
+				{-1, 0, false},
+				{6, 0, false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, args := range tt.args {
+				if got := tt.shape.Contains(args.x, args.y); got != args.want {
+					t.Errorf("%s.Contains(%d, %d) = %v, want %v", tt.name, args.x, args.y, got, args.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package geometry
+
+import "sort"
+
+//Topology how the edges of an area are joined together
+type Topology int
+
+const (
+	//Bounded the edges are walls, nothing can leave the area
+	Bounded Topology = iota
+	//Torus the left edge is joined to the right edge and the top edge to the bottom edge
+	Torus
+	//HorizontalCylinder the left edge is joined to the right edge, the top and bottom edges are walls
+	HorizontalCylinder
+)
+
+//WrapsX Returns true if leaving the left or right edge comes back on the opposite edge
+func (t Topology) WrapsX() bool {
+	return t == Torus || t == HorizontalCylinder
+}
+
+//WrapsY Returns true if leaving the top or bottom edge comes back on the opposite edge
+func (t Topology) WrapsY() bool {
+	return t == Torus
+}
+
+func (t Topology) String() string {
+	switch t {
+	case Torus:
+		return "Torus"
+	case HorizontalCylinder:
+		return "Horizontal Cylinder"
+	}
+	return "Bounded"
+}
+
+//Surface a Rectangle whose edges are joined together by a Topology. Positions past a joined edge are the same as
+//positions the same distance in from the opposite edge, distances are measured the shortest way round
+type Surface struct {
+	Rectangle
+	Topology Topology
+}
+
+//NewSurface Returns a Surface of the given position, width, height and Topology
+func NewSurface(x int, y int, width int, height int, topology Topology) Surface {
+	return Surface{Rectangle: NewRectangle(x, y, width, height), Topology: topology}
+}
+
+//Wrap Returns x and y moved onto the Surface across any joined edges. Returns false if they are off the Surface past
+//an edge that is not joined
+func (s *Surface) Wrap(x int, y int) (int, int, bool) {
+
+	if s.Topology.WrapsX() && s.width > 0 {
+		x = s.x + mod(x-s.x, s.width)
+	}
+
+	if s.Topology.WrapsY() && s.height > 0 {
+		y = s.y + mod(y-s.y, s.height)
+	}
+
+	return x, y, s.Rectangle.Contains(x, y)
+}
+
+//Contains Returns true if x and y are on the Surface once they have been wrapped across any joined edges
+func (s *Surface) Contains(x int, y int) bool {
+	_, _, ok := s.Wrap(x, y)
+	return ok
+}
+
+//Difference Returns how far x and y it is from one position to the other, the shortest way round the Surface
+func (s *Surface) Difference(from Coordinates, to Coordinates) (int, int) {
+
+	dx, dy := to.X-from.X, to.Y-from.Y
+
+	if s.Topology.WrapsX() {
+		dx = shortest(dx, s.width)
+	}
+
+	if s.Topology.WrapsY() {
+		dy = shortest(dy, s.height)
+	}
+
+	return dx, dy
+}
+
+//Distance Returns the number of horizontal and vertical steps between two positions, the shortest way round the Surface
+func (s *Surface) Distance(c Coordinates, c2 Coordinates) int {
+	dx, dy := s.Difference(c, c2)
+	return Abs(dx) + Abs(dy)
+}
+
+//IsInRange Checks if one position is within maxX and maxY of the other, the shortest way round the Surface
+func (s *Surface) IsInRange(c Coordinates, c2 Coordinates, maxX int, maxY int) bool {
+	dx, dy := s.Difference(c, c2)
+	return Abs(dx) <= maxX && Abs(dy) <= maxY
+}
+
+//FindNextStep Returns the step to take from start towards end, the shortest way round the Surface
+func (s *Surface) FindNextStep(start Coordinates, end Coordinates) (int, int) {
+	dx, dy := s.Difference(start, end)
+	return FindNextStep(Coordinates{}, Coordinates{dx, dy})
+}
+
+//SortByNearest Sorts the positions by their Distance from the given position
+func (s *Surface) SortByNearest(position Coordinates, cs []Coordinates) {
+	sort.Slice(cs, func(i, j int) bool {
+		return s.Distance(position, cs[i]) < s.Distance(position, cs[j])
+	})
+}
+
+//SearchSize Returns the width and height shrunk so that an area of that size does not overlap itself across the
+//joined edges of the Surface
+func (s *Surface) SearchSize(width int, height int) (int, int) {
+
+	if s.Topology.WrapsX() && width > s.width {
+		width = s.width
+	}
+
+	if s.Topology.WrapsY() && height > s.height {
+		height = s.height
+	}
+
+	return width, height
+}
+
+//Offsets Returns how far a copy of the Surface is shifted for each copy next to the Surface across a joined edge,
+//including the Surface itself. Searching around a position shifted by each of these finds everything near the position
+//on the other side of a joined edge
+func (s *Surface) Offsets() []Coordinates {
+
+	xs, ys := []int{0}, []int{0}
+
+	if s.Topology.WrapsX() {
+		xs = append(xs, -s.width, s.width)
+	}
+
+	if s.Topology.WrapsY() {
+		ys = append(ys, -s.height, s.height)
+	}
+
+	offsets := make([]Coordinates, 0, len(xs)*len(ys))
+
+	for _, x := range xs {
+		for _, y := range ys {
+			offsets = append(offsets, Coordinates{x, y})
+		}
+	}
+
+	return offsets
+}
+
+//mod Returns a modulo n between 0 and n - 1, even for negative a
+func mod(a int, n int) int {
+	return ((a % n) + n) % n
+}
+
+//shortest Returns the distance d, or the distance the other way round a loop of the given length if that is shorter
+func shortest(d int, length int) int {
+
+	if length <= 0 {
+		return d
+	}
+
+	d = mod(d, length)
+
+	if d > length/2 {
+		d -= length
+	}
+
+	return d
+}
@@ -0,0 +1,83 @@
+package geometry
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSurface_Wrap(t *testing.T) {
+
+	type want struct {
+		x  int
+		y  int
+		ok bool
+	}
+	tests := []struct {
+		name    string
+		surface Surface
+		x       int
+		y       int
+		want    want
+	}{
+		{"Bounded inside", NewSurface(0, 0, 10, 10, Bounded), 3, 4, want{3, 4, true}},
+		{"Bounded outside", NewSurface(0, 0, 10, 10, Bounded), -1, 4, want{-1, 4, false}},
+		{"Torus left edge", NewSurface(0, 0, 10, 10, Torus), -1, 4, want{9, 4, true}},
+		{"Torus corner", NewSurface(0, 0, 10, 10, Torus), 10, -12, want{0, 8, true}},
+		{"Torus offset", NewSurface(5, 5, 10, 10, Torus), 4, 15, want{14, 5, true}},
+		{"Cylinder left edge", NewSurface(0, 0, 10, 10, HorizontalCylinder), -1, 4, want{9, 4, true}},
+		{"Cylinder top edge", NewSurface(0, 0, 10, 10, HorizontalCylinder), 3, -1, want{3, -1, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y, ok := tt.surface.Wrap(tt.x, tt.y)
+			if got := (want{x, y, ok}); got != tt.want {
+				t.Errorf("Surface.Wrap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSurface_Difference(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		surface Surface
+		from    Coordinates
+		to      Coordinates
+		wantX   int
+		wantY   int
+	}{
+		{"Bounded", NewSurface(0, 0, 10, 10, Bounded), Coordinates{1, 1}, Coordinates{9, 9}, 8, 8},
+		{"Torus across both edges", NewSurface(0, 0, 10, 10, Torus), Coordinates{1, 1}, Coordinates{9, 9}, -2, -2},
+		{"Torus same side", NewSurface(0, 0, 10, 10, Torus), Coordinates{1, 1}, Coordinates{4, 3}, 3, 2},
+		{"Cylinder", NewSurface(0, 0, 10, 10, HorizontalCylinder), Coordinates{1, 1}, Coordinates{9, 9}, -2, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := tt.surface.Difference(tt.from, tt.to)
+			if x != tt.wantX || y != tt.wantY {
+				t.Errorf("Surface.Difference() = %v, %v, want %v, %v", x, y, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
+
+func TestSurface_FindNextStep(t *testing.T) {
+
+	torus := NewSurface(0, 0, 10, 10, Torus)
+
+	if x, y := torus.FindNextStep(Coordinates{0, 5}, Coordinates{9, 5}); x != -1 || y != 0 {
+		t.Errorf("Surface.FindNextStep() = %v, %v, want -1, 0", x, y)
+	}
+
+	if !torus.IsInRange(Coordinates{0, 0}, Coordinates{9, 9}, 1, 1) {
+		t.Errorf("Surface.IsInRange() = false, want true across the corner")
+	}
+
+	cs := []Coordinates{{5, 5}, {2, 0}, {9, 0}}
+	torus.SortByNearest(Coordinates{0, 0}, cs)
+
+	if want := []Coordinates{{9, 0}, {2, 0}, {5, 5}}; !reflect.DeepEqual(cs, want) {
+		t.Errorf("Surface.SortByNearest() = %v, want %v", cs, want)
+	}
+}
@@ -0,0 +1,63 @@
+package geometry
+
+import "math"
+
+//Vector a 2D vector with floating point components
+type Vector struct {
+	X float64
+	Y float64
+}
+
+//NewVector Returns a Vector with the given X and Y
+func NewVector(x float64, y float64) Vector {
+	return Vector{X: x, Y: y}
+}
+
+//VectorFromAngle Returns a Vector of the given length pointing at the given angle in radians
+func VectorFromAngle(angle float64, length float64) Vector {
+	return Vector{X: math.Cos(angle) * length, Y: math.Sin(angle) * length}
+}
+
+//Add Returns the sum of two Vectors
+func (v Vector) Add(v2 Vector) Vector {
+	return Vector{v.X + v2.X, v.Y + v2.Y}
+}
+
+//Sub Returns the difference between two Vectors
+func (v Vector) Sub(v2 Vector) Vector {
+	return Vector{v.X - v2.X, v.Y - v2.Y}
+}
+
+//Scale Returns the Vector multiplied by s
+func (v Vector) Scale(s float64) Vector {
+	return Vector{v.X * s, v.Y * s}
+}
+
+//Dot Returns the dot product of two Vectors
+func (v Vector) Dot(v2 Vector) float64 {
+	return v.X*v2.X + v.Y*v2.Y
+}
+
+//Length Returns the length of the Vector
+func (v Vector) Length() float64 {
+	return math.Hypot(v.X, v.Y)
+}
+
+//Normalize Returns a Vector of length 1 in the same direction. The zero Vector is returned unchanged
+func (v Vector) Normalize() Vector {
+	length := v.Length()
+	if length == 0 {
+		return v
+	}
+	return v.Scale(1 / length)
+}
+
+//Round Returns the Coordinates of the tile nearest the Vector, each tile is centred on its whole numbered Coordinates
+func (v Vector) Round() Coordinates {
+	return Coordinates{int(math.Round(v.X)), int(math.Round(v.Y))}
+}
+
+//ToVector Returns the Coordinates as a Vector
+func (c Coordinates) ToVector() Vector {
+	return Vector{float64(c.X), float64(c.Y)}
+}
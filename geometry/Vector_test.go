@@ -0,0 +1,49 @@
+package geometry
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestVector_Normalize(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Vector
+		want Vector
+	}{
+		{"Axis", Vector{5, 0}, Vector{1, 0}},
+		{"Negative Axis", Vector{0, -3}, Vector{0, -1}},
+		{"Zero", Vector{0, 0}, Vector{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.Normalize(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vector.Normalize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if length := (Vector{3, 4}).Normalize().Length(); math.Abs(length-1) > 1e-9 {
+		t.Errorf("Vector.Normalize().Length() = %v, want 1", length)
+	}
+}
+
+func TestVector_Round(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Vector
+		want Coordinates
+	}{
+		{"Round Down", Vector{1.4, 2.2}, Coordinates{1, 2}},
+		{"Round Up", Vector{1.5, 2.7}, Coordinates{2, 3}},
+		{"Negative", Vector{-1.6, -0.2}, Coordinates{-2, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.Round(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vector.Round() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
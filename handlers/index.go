@@ -4,47 +4,94 @@ import (
 	"encoding/json"
 	"fmt"
 	"gopherlife/controllers"
+	"gopherlife/highscores"
+	"gopherlife/metrics"
+	"gopherlife/registry"
+	"gopherlife/timer"
+	"gopherlife/world"
 	"html/template"
 	"log"
 	"net/http"
-	"net/url"
 	"sort"
 	"strconv"
+	"sync"
+	"time"
 )
 
-type RenderController interface {
-	Update() bool
-	Start()
-	PageLayout() controllers.WorldPageData
-	HandleForm(url.Values) bool
-	json.Marshaler
-	controllers.UserInputHandler
-}
-
 type ControllerContainer struct {
 	SelectedKey string
 
-	RenderControllers map[string]RenderController
-	pageData          PageData
+	RenderControllers map[string]registry.RenderController
+	//displayNames the name shown in the world selection for each key, the key itself is shown if it does not have one
+	displayNames map[string]string
+	pageData     PageData
+
+	//Loop updates the selected RenderController at its tick rate, independently of any requests
+	Loop  *timer.Loop
+	mutex *sync.Mutex
 }
 
+//defaultTickRate the time between each Update of a RenderController that does not declare its own tick rate
+const defaultTickRate = time.Millisecond * world.FrameSpeedMultiplier * 2
+
 func NewControllerContainer() ControllerContainer {
 
 	return ControllerContainer{
-		RenderControllers: make(map[string]RenderController),
+		RenderControllers: make(map[string]registry.RenderController),
+		displayNames:      make(map[string]string),
+		mutex:             &sync.Mutex{},
+	}
+}
+
+//tick Updates the selected RenderController and sets the Loop to its tick rate
+func (c *ControllerContainer) tick() {
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	rc := c.Selected()
+	rc.Update()
+
+	if tr, ok := rc.(world.TickRater); ok {
+		c.Loop.SetTimestep(tr.TickRate())
+	} else {
+		c.Loop.SetTimestep(defaultTickRate)
 	}
 }
 
-func (c *ControllerContainer) Add(rc RenderController, key string) {
+//locked Returns the handler wrapped so it does not run at the same time as an Update of the selected RenderController
+func (c *ControllerContainer) locked(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		handler(w, r)
+	}
+}
+
+func (c *ControllerContainer) Add(rc registry.RenderController, key string) {
 	c.RenderControllers[key] = rc
 }
 
-func (c *ControllerContainer) AddSelected(rc RenderController, key string) {
+func (c *ControllerContainer) AddSelected(rc registry.RenderController, key string) {
 	c.Add(rc, key)
 	c.SelectedKey = key
 }
 
-func (c *ControllerContainer) Selected() RenderController {
+//AddWorld Adds a new RenderController of the World, keyed by the World's ID
+func (c *ControllerContainer) AddWorld(world registry.World, dependencies registry.Dependencies) {
+	c.Add(world.New(dependencies), world.ID)
+	c.displayNames[world.ID] = world.DisplayName
+}
+
+//displayName Returns the name shown in the world selection for the key
+func (c *ControllerContainer) displayName(key string) string {
+	if name, ok := c.displayNames[key]; ok {
+		return name
+	}
+	return key
+}
+
+func (c *ControllerContainer) Selected() registry.RenderController {
 
 	if c.SelectedKey == "" {
 		for k := range c.RenderControllers {
@@ -66,10 +113,12 @@ func (c *ControllerContainer) PopulatePageData() {
 	for key := range c.RenderControllers {
 		keys = append(keys, key)
 	}
-	sort.Strings(keys)
+	sort.Slice(keys, func(i, j int) bool {
+		return c.displayName(keys[i]) < c.displayName(keys[j])
+	})
 	for _, key := range keys {
 		data.WorldSelectFormInput = append(data.WorldSelectFormInput, WorldSelectFormInput{
-			DisplayName: key,
+			DisplayName: c.displayName(key),
 			Value:       key,
 		})
 	}
@@ -89,48 +138,68 @@ type WorldSelectFormInput struct {
 	Value       string
 }
 
-func SetUpPage() {
+//highScoreFile the local file high-scores are saved to
+const highScoreFile = "highscores.json"
 
-	ControllerContainer := NewControllerContainer()
+//maxHighScores the number of scores kept for each game and settings
+const maxHighScores = 10
 
-	ss := controllers.NewGopherWorldWithSpiralSearch()
-	ControllerContainer.AddSelected(&ss, "GopherWorld With Spiral Search")
+//SetUpPage Serves every world in the registry, showing the world with the selected ID first
+func SetUpPage(selectedID string) {
 
-	ps := controllers.NewGopherWorldWithParitionGridAndSearch()
-	ControllerContainer.Add(&ps, "GopherWorld With Partition")
+	ControllerContainer := NewControllerContainer()
 
-	sm := controllers.NewSpiralWorldController()
-	ControllerContainer.Add(&sm, "Black and White Spiral World")
+	scores, err := highscores.Open(highScoreFile, maxHighScores)
 
-	wsm := controllers.NewWeirdSpiralWorldController()
-	ControllerContainer.Add(&wsm, "Black and White Spiral World (Weird)")
+	if err != nil {
+		log.Printf("Unable to load high-scores, they will not be saved: %v", err)
+	}
 
-	fireworks := controllers.NewFireWorksController()
-	ControllerContainer.Add(&fireworks, "Fireworks!")
+	worlds := registry.Default.Worlds()
+	dependencies := registry.Dependencies{HighScores: scores}
 
-	collision := controllers.NewCollisionWorldController()
-	ControllerContainer.Add(&collision, "Collision World")
+	if len(worlds) == 0 {
+		log.Fatal("No worlds are registered")
+	}
 
-	diagonalCollision := controllers.NewDiagonalCollisionWorldController()
-	ControllerContainer.Add(&diagonalCollision, "Collision World (Diagonal)")
+	for _, world := range worlds {
+		ControllerContainer.AddWorld(world, dependencies)
+	}
 
-	SnakeWorld := controllers.NewSnakeWorldController()
-	ControllerContainer.Add(&SnakeWorld, "Elongating Gopher")
+	ControllerContainer.SelectedKey = selectedID
 
-	blockblockRevolution := controllers.NewBlockBlockRevolutionController()
-	ControllerContainer.Add(&blockblockRevolution, "Block Block Revolution")
+	if _, ok := registry.Default.Lookup(selectedID); !ok {
+		log.Printf("There is no world %q, showing %q instead", selectedID, worlds[0].ID)
+		ControllerContainer.SelectedKey = worlds[0].ID
+	}
 
 	ControllerContainer.Selected().Start()
 	ControllerContainer.PopulatePageData()
 
 	http.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("static"))))
-	http.HandleFunc("/", worldToHTML(&ControllerContainer))
-	http.HandleFunc("/Update", Update(&ControllerContainer))
-	http.HandleFunc("/Click", HandleClick(&ControllerContainer))
-	http.HandleFunc("/KeyPress", HandleKeyPress(&ControllerContainer))
-	http.HandleFunc("/Scroll", HandleScroll(&ControllerContainer))
-	http.HandleFunc("/ResetWorld", ResetWorld(&ControllerContainer))
-	http.HandleFunc("/SwitchWorld", SwitchWorld(&ControllerContainer))
+	ControllerContainer.Loop = timer.NewLoop(defaultTickRate, ControllerContainer.tick)
+	locked := ControllerContainer.locked
+
+	http.HandleFunc("/", locked(worldToHTML(&ControllerContainer)))
+	http.HandleFunc("/Update", locked(Update(&ControllerContainer)))
+	http.HandleFunc("/Click", locked(HandleClick(&ControllerContainer)))
+	http.HandleFunc("/KeyPress", locked(HandleKeyPress(&ControllerContainer)))
+	http.HandleFunc("/Scroll", locked(HandleScroll(&ControllerContainer)))
+	http.HandleFunc("/ResetWorld", locked(ResetWorld(&ControllerContainer)))
+	http.HandleFunc("/SwitchWorld", locked(SwitchWorld(&ControllerContainer)))
+	http.HandleFunc("/HighScores", HighScores(scores))
+	http.HandleFunc("/HighScores/Current", locked(CurrentHighScores(&ControllerContainer)))
+	http.HandleFunc("/Replay/Save", locked(SaveReplay(&ControllerContainer)))
+	http.HandleFunc("/Replay/Play", locked(PlayReplay(&ControllerContainer)))
+	http.HandleFunc("/Replay/Verify", locked(VerifyReplay(&ControllerContainer)))
+	http.HandleFunc("/Stats", locked(Stats(&ControllerContainer)))
+	http.HandleFunc("/Loop/Pause", PauseLoop(ControllerContainer.Loop))
+	http.HandleFunc("/Loop/Step", StepLoop(ControllerContainer.Loop))
+	http.HandleFunc("/Loop/Speed", SetLoopSpeed(ControllerContainer.Loop))
+	http.HandleFunc("/metrics", Metrics(metrics.Default))
+
+	go ControllerContainer.Loop.Run(timer.SleepWait)
+
 	fmt.Println("Listening...")
 	http.ListenAndServe(":8080", nil)
 
@@ -151,12 +220,11 @@ func worldToHTML(ControllerContainer *ControllerContainer) func(w http.ResponseW
 
 }
 
-//Update Runs the Update function of the selected RenderController. Returns JSON
+//Update Returns the selected RenderController as JSON. The RenderController is updated by the ControllerContainer's Loop
 func Update(ControllerContainer *ControllerContainer) func(w http.ResponseWriter, r *http.Request) {
 
 	return func(w http.ResponseWriter, r *http.Request) {
 
-		ControllerContainer.Selected().Update()
 		jsonData, err := ControllerContainer.Selected().MarshalJSON()
 
 		if err == nil {
@@ -186,6 +254,12 @@ func SwitchWorld(ControllerContainer *ControllerContainer) func(w http.ResponseW
 		r.ParseForm()
 
 		worldSelection := r.FormValue("worldSelection")
+
+		if _, ok := ControllerContainer.RenderControllers[worldSelection]; !ok {
+			http.Error(w, fmt.Sprintf("there is no world %q", worldSelection), 404)
+			return
+		}
+
 		ControllerContainer.SelectedKey = worldSelection
 		ControllerContainer.Selected().Start()
 
@@ -240,3 +314,194 @@ func HandleScroll(ControllerContainer *ControllerContainer) func(w http.Response
 		w.WriteHeader(200)
 	}
 }
+
+//HighScores Returns every high-score table as JSON. The tables can be filtered with the 'game' query value
+func HighScores(scores *highscores.Store) func(w http.ResponseWriter, r *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		if scores == nil {
+			w.WriteHeader(404)
+			return
+		}
+
+		r.ParseForm()
+
+		jsonData, err := json.Marshal(scores.Tables(r.FormValue("game")))
+
+		if err == nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(jsonData)
+		} else {
+			w.WriteHeader(500)
+		}
+	}
+}
+
+//CurrentHighScores Returns the high-score table of the selected world's current settings as JSON
+func CurrentHighScores(ControllerContainer *ControllerContainer) func(w http.ResponseWriter, r *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		recorder, ok := ControllerContainer.Selected().(controllers.HighScoreRecorder)
+
+		if !ok {
+			w.WriteHeader(404)
+			return
+		}
+
+		jsonData, err := json.Marshal(recorder.HighScores())
+
+		if err == nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(jsonData)
+		} else {
+			w.WriteHeader(500)
+		}
+	}
+}
+
+//SaveReplay Saves the selected game's inputs to the replay given by the 'name' query value
+func SaveReplay(ControllerContainer *ControllerContainer) func(w http.ResponseWriter, r *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		replayer, ok := ControllerContainer.Selected().(controllers.ReplayController)
+
+		if !ok {
+			w.WriteHeader(404)
+			return
+		}
+
+		if err := replayer.SaveReplay(r.FormValue("name")); err != nil {
+			http.Error(w, err.Error(), 500)
+			return
+		}
+
+		w.WriteHeader(200)
+	}
+}
+
+//PlayReplay Plays the replay given by the 'name' query value in the selected game. If 'fast' is set to 1 the replay is played at fast speed
+func PlayReplay(ControllerContainer *ControllerContainer) func(w http.ResponseWriter, r *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		replayer, ok := ControllerContainer.Selected().(controllers.ReplayController)
+
+		if !ok {
+			w.WriteHeader(404)
+			return
+		}
+
+		if err := replayer.PlayReplay(r.FormValue("name"), r.FormValue("fast") == "1"); err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+
+		w.WriteHeader(200)
+	}
+}
+
+//VerifyReplay Plays the replay given by the 'name' query value as fast as possible and returns whether it reached the recorded score as JSON
+func VerifyReplay(ControllerContainer *ControllerContainer) func(w http.ResponseWriter, r *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		replayer, ok := ControllerContainer.Selected().(controllers.ReplayController)
+
+		if !ok {
+			w.WriteHeader(404)
+			return
+		}
+
+		result, err := replayer.VerifyReplay(r.FormValue("name"))
+
+		if err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+
+		jsonData, err := json.Marshal(result)
+
+		if err == nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(jsonData)
+		} else {
+			w.WriteHeader(500)
+		}
+	}
+}
+
+//Stats Returns the statistics the selected world keeps as JSON, such as how many things were on each side of it, in
+//each state of a disease for its last ticks or burnt by each fire
+func Stats(ControllerContainer *ControllerContainer) func(w http.ResponseWriter, r *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		reporter, ok := ControllerContainer.Selected().(controllers.StatsReporter)
+
+		if !ok {
+			w.WriteHeader(404)
+			return
+		}
+
+		jsonData, err := json.Marshal(reporter.Stats())
+
+		if err == nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(jsonData)
+		} else {
+			w.WriteHeader(500)
+		}
+	}
+}
+
+//PauseLoop Pauses the Loop updating the selected world, or starts it again. Returns whether it is now paused as JSON
+func PauseLoop(loop *timer.Loop) func(w http.ResponseWriter, r *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		loop.TogglePause()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(loop.IsPaused())
+	}
+}
+
+//Metrics Writes every metric in the registry in the Prometheus text format
+func Metrics(registry *metrics.Registry) func(w http.ResponseWriter, r *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		registry.WriteText(w)
+	}
+}
+
+//StepLoop Runs a single Update of the selected world, used while the Loop is paused
+func StepLoop(loop *timer.Loop) func(w http.ResponseWriter, r *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		loop.Step()
+		w.WriteHeader(200)
+	}
+}
+
+//SetLoopSpeed Runs the Loop the number of times faster than the world's tick rate given by the 'multiplier' query value
+func SetLoopSpeed(loop *timer.Loop) func(w http.ResponseWriter, r *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		multiplier, err := strconv.ParseFloat(r.FormValue("multiplier"), 64)
+
+		if err != nil || multiplier <= 0 {
+			http.Error(w, "multiplier must be a number above zero", 400)
+			return
+		}
+
+		loop.SetSpeed(multiplier)
+		w.WriteHeader(200)
+	}
+}
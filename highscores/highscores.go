@@ -0,0 +1,161 @@
+package highscores
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+//Entry a single score in a high-score table
+type Entry struct {
+	Name   string
+	Score  int
+	Date   time.Time
+	Length time.Duration
+}
+
+//Table the high-scores of a game played with the same settings
+type Table struct {
+	Game     string
+	Settings string
+	Entries  []Entry
+}
+
+//Store keeps the top scores of every game and settings combination and saves them to a JSON file
+type Store struct {
+	path       string
+	maxEntries int
+
+	mutex  sync.Mutex
+	tables map[string]map[string][]Entry
+}
+
+//Open Returns a Store backed by the JSON file at path, keeping at most maxEntries scores per table.
+//If the file does not exist the Store starts empty and the file is created on the first save. If the file cannot be
+//read or decoded no Store is returned, so the scores in it are never overwritten
+func Open(path string, maxEntries int) (*Store, error) {
+
+	store := Store{
+		path:       path,
+		maxEntries: maxEntries,
+		tables:     make(map[string]map[string][]Entry),
+	}
+
+	data, err := ioutil.ReadFile(path)
+
+	if os.IsNotExist(err) {
+		return &store, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &store.tables); err != nil {
+			return nil, err
+		}
+	}
+
+	return &store, nil
+}
+
+//Add Inserts the entry into the table for the game and settings and saves the Store.
+//Returns the zero based rank of the entry, or false if the entry did not make the table
+func (store *Store) Add(game string, settings string, entry Entry) (int, bool, error) {
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.tables[game] == nil {
+		store.tables[game] = make(map[string][]Entry)
+	}
+
+	entries := store.tables[game][settings]
+
+	rank := sort.Search(len(entries), func(i int) bool {
+		return entries[i].Score < entry.Score
+	})
+
+	if rank >= store.maxEntries {
+		return 0, false, nil
+	}
+
+	entries = append(entries, Entry{})
+	copy(entries[rank+1:], entries[rank:])
+	entries[rank] = entry
+
+	if len(entries) > store.maxEntries {
+		entries = entries[:store.maxEntries]
+	}
+
+	store.tables[game][settings] = entries
+
+	return rank, true, store.save()
+}
+
+//Table Returns a copy of the table for the given game and settings
+func (store *Store) Table(game string, settings string) Table {
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	entries := store.tables[game][settings]
+
+	return Table{
+		Game:     game,
+		Settings: settings,
+		Entries:  append([]Entry{}, entries...),
+	}
+}
+
+//Tables Returns a copy of every table, sorted by game and then settings. If game is not empty only that game's tables are returned
+func (store *Store) Tables(game string) []Table {
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	tables := []Table{}
+
+	for g, settingsTables := range store.tables {
+		if game != "" && g != game {
+			continue
+		}
+
+		for settings, entries := range settingsTables {
+			tables = append(tables, Table{
+				Game:     g,
+				Settings: settings,
+				Entries:  append([]Entry{}, entries...),
+			})
+		}
+	}
+
+	sort.Slice(tables, func(i, j int) bool {
+		if tables[i].Game != tables[j].Game {
+			return tables[i].Game < tables[j].Game
+		}
+		return tables[i].Settings < tables[j].Settings
+	})
+
+	return tables
+}
+
+//save Writes the Store to a temporary file and then replaces the Store's file so a failed write does not lose scores
+func (store *Store) save() error {
+
+	data, err := json.MarshalIndent(store.tables, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	tmp := store.path + ".tmp"
+
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, store.path)
+}
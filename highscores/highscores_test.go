@@ -0,0 +1,100 @@
+package highscores
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStore_Add(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "highscores")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "scores.json")
+	store, err := Open(path, 3)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		score    int
+		wantRank int
+		wantOk   bool
+	}{
+		{"First Score", 50, 0, true},
+		{"Higher Score", 100, 0, true},
+		{"Lowest Score", 10, 2, true},
+		{"Equal Score Ranks After", 50, 2, true},
+		{"Too Low For Full Table", 5, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rank, ok, err := store.Add("Snake", "5", Entry{Name: tt.name, Score: tt.score})
+			if err != nil {
+				t.Fatalf("Store.Add() error = %v", err)
+			}
+			if rank != tt.wantRank || ok != tt.wantOk {
+				t.Errorf("Store.Add() = (%d, %v), want (%d, %v)", rank, ok, tt.wantRank, tt.wantOk)
+			}
+		})
+	}
+
+	reopened, err := Open(path, 3)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	table := reopened.Table("Snake", "5")
+	want := []int{100, 50, 50}
+
+	if len(table.Entries) != len(want) {
+		t.Fatalf("Table() has %d entries, want %d", len(table.Entries), len(want))
+	}
+
+	for i, score := range want {
+		if table.Entries[i].Score != score {
+			t.Errorf("Table().Entries[%d].Score = %d, want %d", i, table.Entries[i].Score, score)
+		}
+	}
+
+	if other := reopened.Table("Snake", "10"); len(other.Entries) != 0 {
+		t.Errorf("Table() for other settings has %d entries, want 0", len(other.Entries))
+	}
+}
+
+func TestOpen_CorruptFile(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "highscores")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "scores.json")
+	corrupt := []byte(`{"Elongating Gopher": {"Speed 1": [{"Name": "Gordon", "Sco`)
+
+	if err := ioutil.WriteFile(path, corrupt, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := Open(path, 3)
+
+	if err == nil || store != nil {
+		t.Fatalf("Open() of a corrupt file = %v, %v, want no Store and an error", store, err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != string(corrupt) {
+		t.Errorf("Open() changed the corrupt file to %q, want it left alone", data)
+	}
+}
@@ -1,14 +1,44 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	handlers "gopherlife/handlers"
+	"gopherlife/registry"
 	"math/rand"
 	"time"
+
+	//Each world registers itself when its package is imported, remove an import to leave the world out
+	_ "gopherlife/worlds/antcolony"
+	_ "gopherlife/worlds/blockblock"
+	_ "gopherlife/worlds/collision"
+	_ "gopherlife/worlds/flocking"
+	_ "gopherlife/worlds/forestfire"
+	_ "gopherlife/worlds/gopherworld"
+	_ "gopherlife/worlds/grayscott"
+	_ "gopherlife/worlds/life"
+	_ "gopherlife/worlds/maze"
+	_ "gopherlife/worlds/sand"
+	_ "gopherlife/worlds/snake"
+	_ "gopherlife/worlds/traffic"
+	_ "gopherlife/worlds/turmites"
 )
 
 func main() {
+
+	selected := flag.String("world", "gopherworld-spiral-search", "the ID of the world shown first")
+	list := flag.Bool("worlds", false, "list the ID, name and description of every world, then exit")
+	flag.Parse()
+
+	if *list {
+		for _, world := range registry.Default.Worlds() {
+			fmt.Printf("%-28s %s: %s\n", world.ID, world.DisplayName, world.Description)
+		}
+		return
+	}
+
 	//runtime.GOMAXPROCS(1)
 	rand.Seed(time.Now().UnixNano())
 	//rand.Seed(1)
-	handlers.SetUpPage()
+	handlers.SetUpPage(*selected)
 }
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"gopherlife/registry"
+	"net/url"
+	"testing"
+)
+
+//smallSettings Returns the default settings of the World shrunk down so it is quick to start
+func smallSettings(world registry.World) url.Values {
+
+	settings := url.Values{}
+	for name, values := range world.Defaults {
+		settings[name] = append([]string(nil), values...)
+	}
+
+	small := map[string]string{"width": "40", "height": "30", "initialPopulation": "50", "numberOfFood": "100"}
+
+	for name, value := range small {
+		if settings.Get(name) != "" {
+			settings.Set(name, value)
+		}
+	}
+
+	return settings
+}
+
+//TestWorlds_ResetUpdateAndDraw Checks every World that main imports has a Description and Settings, and that a new
+//RenderController of it resets from its shrunk down Defaults, updates and draws
+func TestWorlds_ResetUpdateAndDraw(t *testing.T) {
+
+	worlds := registry.Default.Worlds()
+
+	if len(worlds) == 0 {
+		t.Fatalf("No worlds are registered")
+	}
+
+	for _, world := range worlds {
+
+		world := world
+
+		t.Run(world.ID, func(t *testing.T) {
+
+			t.Parallel()
+
+			if world.Description == "" {
+				t.Errorf("World %q has no Description", world.ID)
+			}
+
+			if len(world.Defaults) != len(world.Settings) {
+				t.Errorf("World %q has %d Defaults for %d Settings", world.ID, len(world.Defaults), len(world.Settings))
+			}
+
+			controller := world.New(registry.Dependencies{})
+
+			//Resetting from the settings form starts most worlds, Start does nothing if it already has
+			if !controller.HandleForm(smallSettings(world)) {
+				t.Errorf("HandleForm() of the default settings = false, want true")
+			}
+
+			controller.Start()
+
+			for i := 0; i < 3; i++ {
+				controller.Update()
+			}
+
+			if _, err := controller.MarshalJSON(); err != nil {
+				t.Fatalf("MarshalJSON() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package metrics
+
+import (
+	"math"
+	"math/bits"
+	"sync"
+)
+
+//subBucketBits the number of significant bits each value is kept to. Every recorded value is kept to within
+//1 / 2^(subBucketBits-1) of itself, about 3%
+const subBucketBits = 6
+
+//subBuckets the number of buckets for each power of two
+const subBuckets = 1 << subBucketBits
+
+//Histogram counts non-negative values in buckets that get wider as the values get larger, like an HDR histogram. It
+//uses a fixed amount of memory however many values are recorded, and percentiles are accurate to a few percent. The
+//Max is exact. A Histogram is safe to use from more than one goroutine
+type Histogram struct {
+	mutex  sync.Mutex
+	counts [(64 - subBucketBits + 1) * subBuckets]uint64
+	count  uint64
+	sum    int64
+	max    int64
+}
+
+//NewHistogram Returns an empty Histogram
+func NewHistogram() *Histogram {
+	return &Histogram{}
+}
+
+//bucket Returns the index of the bucket holding the value
+func bucket(value int64) int {
+
+	v := uint64(value)
+
+	if v < subBuckets {
+		return int(v)
+	}
+
+	exponent := bits.Len64(v) - subBucketBits
+	return exponent*subBuckets + int(v>>uint(exponent))
+}
+
+//bucketHighest Returns the largest value held by the bucket
+func bucketHighest(index int) int64 {
+
+	exponent, mantissa := index/subBuckets, uint64(index%subBuckets)
+
+	if exponent == 0 {
+		return int64(mantissa)
+	}
+
+	return int64(((mantissa+1)<<uint(exponent))-1) & math.MaxInt64
+}
+
+//Record Adds the value to the Histogram. Negative values are recorded as zero
+func (h *Histogram) Record(value int64) {
+
+	if value < 0 {
+		value = 0
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.counts[bucket(value)]++
+	h.count++
+	h.sum += value
+
+	if value > h.max {
+		h.max = value
+	}
+}
+
+//Count Returns the number of values recorded
+func (h *Histogram) Count() uint64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.count
+}
+
+//Sum Returns the total of every value recorded
+func (h *Histogram) Sum() int64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.sum
+}
+
+//Max Returns the largest value recorded
+func (h *Histogram) Max() int64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.max
+}
+
+//Mean Returns the average of the values recorded
+func (h *Histogram) Mean() float64 {
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.count == 0 {
+		return 0
+	}
+
+	return float64(h.sum) / float64(h.count)
+}
+
+//Percentile Returns the value that p percent of the recorded values are less than or equal to, p is between 0 and 100
+func (h *Histogram) Percentile(p float64) int64 {
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.count == 0 {
+		return 0
+	}
+
+	rank := uint64(math.Ceil(p / 100 * float64(h.count)))
+
+	if rank < 1 {
+		rank = 1
+	}
+
+	seen := uint64(0)
+
+	for i, count := range h.counts {
+
+		seen += count
+
+		if seen >= rank {
+			if highest := bucketHighest(i); highest < h.max {
+				return highest
+			}
+			return h.max
+		}
+	}
+
+	return h.max
+}
+
+//Reset Removes every recorded value
+func (h *Histogram) Reset() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.counts = [len(h.counts)]uint64{}
+	h.count, h.sum, h.max = 0, 0, 0
+}
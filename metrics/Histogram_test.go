@@ -0,0 +1,62 @@
+package metrics
+
+import "testing"
+
+func TestHistogram_Percentile(t *testing.T) {
+
+	h := NewHistogram()
+
+	for i := int64(1); i <= 1000; i++ {
+		h.Record(i * 1000)
+	}
+
+	tests := []struct {
+		name string
+		p    float64
+		want int64
+	}{
+		{"p50", 50, 500000},
+		{"p95", 95, 950000},
+		{"p99", 99, 990000},
+		{"p100", 100, 1000000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := h.Percentile(tt.p)
+			if got < tt.want || float64(got) > float64(tt.want)*1.04 {
+				t.Errorf("Histogram.Percentile(%v) = %v, want within 4%% above %v", tt.p, got, tt.want)
+			}
+		})
+	}
+
+	if h.Max() != 1000000 {
+		t.Errorf("Histogram.Max() = %v, want %v", h.Max(), 1000000)
+	}
+
+	if h.Count() != 1000 {
+		t.Errorf("Histogram.Count() = %v, want %v", h.Count(), 1000)
+	}
+}
+
+func TestHistogram_SmallValues(t *testing.T) {
+
+	h := NewHistogram()
+
+	for _, v := range []int64{-5, 0, 1, 2, 3} {
+		h.Record(v)
+	}
+
+	if got := h.Percentile(60); got != 1 {
+		t.Errorf("Histogram.Percentile(60) = %v, want 1. Small values should be exact", got)
+	}
+
+	if h.Sum() != 6 {
+		t.Errorf("Histogram.Sum() = %v, want 6. Negative values should be recorded as zero", h.Sum())
+	}
+
+	h.Reset()
+
+	if h.Count() != 0 || h.Percentile(50) != 0 {
+		t.Errorf("Histogram.Reset() left %v values", h.Count())
+	}
+}
@@ -0,0 +1,281 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+//Quantiles the percentiles of each Histogram written in the text format
+var Quantiles = []float64{50, 95, 99}
+
+//Counter a number that only goes up, such as the number of actions dropped
+type Counter struct {
+	value int64
+}
+
+//Add Adds n to the Counter
+func (c *Counter) Add(n int64) {
+	atomic.AddInt64(&c.value, n)
+}
+
+//Inc Adds one to the Counter
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+//Value Returns the current count
+func (c *Counter) Value() int64 {
+	return atomic.LoadInt64(&c.value)
+}
+
+//Gauge a number that can go up and down, such as the number of gophers alive
+type Gauge struct {
+	value int64
+}
+
+//Set Sets the Gauge to the value
+func (g *Gauge) Set(value int64) {
+	atomic.StoreInt64(&g.value, value)
+}
+
+//Value Returns the current value of the Gauge
+func (g *Gauge) Value() int64 {
+	return atomic.LoadInt64(&g.value)
+}
+
+//metric a Counter, Gauge or Histogram in a Registry
+type metric struct {
+	counter   *Counter
+	gauge     *Gauge
+	histogram *Histogram
+	//scale each value of a histogram is multiplied by this when written, so durations can be written in seconds
+	scale float64
+}
+
+//family the metrics in a Registry sharing a name, one for each set of labels they are recorded with
+type family struct {
+	help   string
+	kind   string
+	series map[string]*metric
+}
+
+//Registry a set of named metrics that can be written in the Prometheus text format
+type Registry struct {
+	mutex    sync.Mutex
+	families map[string]*family
+}
+
+//NewRegistry Returns an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+//Default the Registry used across gopherlife and served on the metrics endpoint
+var Default = NewRegistry()
+
+//get Returns the metric with the given name and labels, creating it with create if it does not exist. Panics if a
+//metric of another kind already has the name
+func (r *Registry) get(name string, labels string, help string, kind string, create func() *metric) *metric {
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	f, ok := r.families[name]
+
+	if !ok {
+		f = &family{help: help, kind: kind, series: make(map[string]*metric)}
+		r.families[name] = f
+	}
+
+	if f.kind != kind {
+		panic(fmt.Sprintf("metric %q is a %s not a %s", name, f.kind, kind))
+	}
+
+	m, ok := f.series[labels]
+
+	if !ok {
+		m = create()
+		f.series[labels] = m
+	}
+
+	return m
+}
+
+//Counter Returns the Counter with the given name, creating it if it does not exist
+func (r *Registry) Counter(name string, help string) *Counter {
+	return r.Scope("", "").Counter(name, help)
+}
+
+//Gauge Returns the Gauge with the given name, creating it if it does not exist
+func (r *Registry) Gauge(name string, help string) *Gauge {
+	return r.Scope("", "").Gauge(name, help)
+}
+
+//Histogram Returns the Histogram with the given name, creating it if it does not exist
+func (r *Registry) Histogram(name string, help string) *Histogram {
+	return r.Scope("", "").Histogram(name, help)
+}
+
+//DurationHistogram Returns the Histogram of nanosecond durations with the given name, creating it if it does not exist.
+//It is written in seconds
+func (r *Registry) DurationHistogram(name string, help string) *Histogram {
+	return r.Scope("", "").DurationHistogram(name, help)
+}
+
+//Scope the metrics of a Registry recorded with one label, so that things of the same kind, such as each world, keep
+//their own metrics under the same names
+type Scope struct {
+	registry *Registry
+	labels   string
+}
+
+//Scope Returns the Scope of the metrics labelled with the value. An empty label is the metrics without one
+func (r *Registry) Scope(label string, value string) Scope {
+
+	if label == "" {
+		return Scope{registry: r}
+	}
+
+	return Scope{registry: r, labels: fmt.Sprintf("%s=%q", label, value)}
+}
+
+//Counter Returns the Counter with the given name in the Scope, creating it if it does not exist
+func (s Scope) Counter(name string, help string) *Counter {
+	return s.registry.get(name, s.labels, help, "counter", func() *metric {
+		return &metric{counter: &Counter{}}
+	}).counter
+}
+
+//Gauge Returns the Gauge with the given name in the Scope, creating it if it does not exist
+func (s Scope) Gauge(name string, help string) *Gauge {
+	return s.registry.get(name, s.labels, help, "gauge", func() *metric {
+		return &metric{gauge: &Gauge{}}
+	}).gauge
+}
+
+//Histogram Returns the Histogram with the given name in the Scope, creating it if it does not exist
+func (s Scope) Histogram(name string, help string) *Histogram {
+	return s.registry.get(name, s.labels, help, "summary", func() *metric {
+		return &metric{histogram: NewHistogram(), scale: 1}
+	}).histogram
+}
+
+//DurationHistogram Returns the Histogram of nanosecond durations with the given name in the Scope, creating it if it
+//does not exist. It is written in seconds
+func (s Scope) DurationHistogram(name string, help string) *Histogram {
+	return s.registry.get(name, s.labels, help, "summary", func() *metric {
+		return &metric{histogram: NewHistogram(), scale: 1e-9}
+	}).histogram
+}
+
+//series Returns the name of a series with the labels and any extra label, such as the quantile of a summary
+func series(name string, labels string, extra string) string {
+
+	switch {
+	case labels == "" && extra == "":
+		return name
+	case labels == "":
+		return name + "{" + extra + "}"
+	case extra == "":
+		return name + "{" + labels + "}"
+	}
+
+	return name + "{" + labels + "," + extra + "}"
+}
+
+//WriteText Writes every metric in the Prometheus text format, sorted by name and then labels. Histograms are written
+//as summaries of their Quantiles along with a gauge of their maximum named with a _max suffix
+func (r *Registry) WriteText(w io.Writer) error {
+
+	r.mutex.Lock()
+
+	names := make([]string, 0, len(r.families))
+	families := make(map[string]family, len(r.families))
+	labels := make(map[string][]string, len(r.families))
+
+	for name, f := range r.families {
+
+		names = append(names, name)
+
+		copied := make(map[string]*metric, len(f.series))
+		for l, m := range f.series {
+			copied[l] = m
+			labels[name] = append(labels[name], l)
+		}
+
+		families[name] = family{help: f.help, kind: f.kind, series: copied}
+		sort.Strings(labels[name])
+	}
+
+	r.mutex.Unlock()
+
+	sort.Strings(names)
+
+	for _, name := range names {
+
+		f := families[name]
+
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, f.help, name, f.kind); err != nil {
+			return err
+		}
+
+		for _, l := range labels[name] {
+
+			m := f.series[l]
+
+			var err error
+
+			switch f.kind {
+			case "counter":
+				_, err = fmt.Fprintf(w, "%s %d\n", series(name, l, ""), m.counter.Value())
+			case "gauge":
+				_, err = fmt.Fprintf(w, "%s %d\n", series(name, l, ""), m.gauge.Value())
+			default:
+				err = m.writeSummary(w, name, l)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if f.kind != "summary" {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "# HELP %s_max The largest value of %s\n# TYPE %s_max gauge\n", name, name, name); err != nil {
+			return err
+		}
+
+		for _, l := range labels[name] {
+			m := f.series[l]
+			if _, err := fmt.Fprintf(w, "%s %g\n", series(name+"_max", l, ""), float64(m.histogram.Max())*m.scale); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+//writeSummary Writes the Quantiles, sum and count of the histogram of the series with the labels
+func (m *metric) writeSummary(w io.Writer, name string, labels string) error {
+
+	h := m.histogram
+
+	for _, q := range Quantiles {
+		quantile := fmt.Sprintf("quantile=\"%g\"", q/100)
+		if _, err := fmt.Fprintf(w, "%s %g\n", series(name, labels, quantile), float64(h.Percentile(q))*m.scale); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "%s %g\n%s %d\n",
+		series(name+"_sum", labels, ""), float64(h.Sum())*m.scale,
+		series(name+"_count", labels, ""), h.Count())
+
+	return err
+}
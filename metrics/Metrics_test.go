@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRegistry_WriteText(t *testing.T) {
+
+	r := NewRegistry()
+
+	r.Counter("dropped_total", "Dropped").Add(3)
+	r.Gauge("alive", "Alive").Set(7)
+	r.DurationHistogram("update_seconds", "Update").Record(int64(2 * time.Second))
+
+	if r.Counter("dropped_total", "Dropped").Value() != 3 {
+		t.Errorf("Registry.Counter() did not return the existing Counter")
+	}
+
+	var b strings.Builder
+	if err := r.WriteText(&b); err != nil {
+		t.Fatalf("Registry.WriteText() error = %v", err)
+	}
+
+	text := b.String()
+
+	for _, want := range []string{
+		"# TYPE alive gauge\nalive 7\n",
+		"# TYPE dropped_total counter\ndropped_total 3\n",
+		"# TYPE update_seconds summary\n",
+		"update_seconds{quantile=\"0.99\"} 2\n",
+		"update_seconds_count 1\n",
+		"update_seconds_max 2\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Registry.WriteText() = %q, want it to contain %q", text, want)
+		}
+	}
+
+	if strings.Index(text, "alive") > strings.Index(text, "dropped_total") {
+		t.Errorf("Registry.WriteText() did not sort metrics by name")
+	}
+}
+
+func TestRegistry_KindMismatch(t *testing.T) {
+
+	r := NewRegistry()
+	r.Gauge("alive", "Alive")
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Registry.Counter() with the name of a Gauge did not panic")
+		}
+	}()
+
+	r.Counter("alive", "Alive")
+}
+
+func TestRegistry_Scope(t *testing.T) {
+
+	r := NewRegistry()
+
+	r.Scope("world", "hex").Gauge("alive", "Alive").Set(3)
+	r.Scope("world", "spiral").Gauge("alive", "Alive").Set(5)
+	r.Scope("world", "hex").DurationHistogram("update_seconds", "Update").Record(int64(time.Second))
+
+	if r.Scope("world", "hex").Gauge("alive", "Alive").Value() != 3 {
+		t.Errorf("Scope.Gauge() did not return the existing Gauge of the Scope")
+	}
+
+	var b strings.Builder
+	if err := r.WriteText(&b); err != nil {
+		t.Fatalf("Registry.WriteText() error = %v", err)
+	}
+
+	text := b.String()
+
+	for _, want := range []string{
+		"# TYPE alive gauge\nalive{world=\"hex\"} 3\nalive{world=\"spiral\"} 5\n",
+		"update_seconds{world=\"hex\",quantile=\"0.5\"} 1\n",
+		"update_seconds_count{world=\"hex\"} 1\n",
+		"update_seconds_max{world=\"hex\"} 1\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Registry.WriteText() = %q, want it to contain %q", text, want)
+		}
+	}
+
+	if strings.Count(text, "# TYPE alive gauge") != 1 {
+		t.Errorf("Registry.WriteText() wrote the type of the alive gauge more than once")
+	}
+}
@@ -0,0 +1,158 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopherlife/controllers"
+	"gopherlife/highscores"
+	"net/url"
+	"sort"
+	"sync"
+)
+
+//RenderController a world that can be shown on the page, updated, reset from its settings form and sent user input
+type RenderController interface {
+	Update() bool
+	Start()
+	PageLayout() controllers.WorldPageData
+	HandleForm(url.Values) bool
+	json.Marshaler
+	controllers.UserInputHandler
+}
+
+//Dependencies things shared between every world that a Factory can use
+type Dependencies struct {
+	//HighScores where games save their high-scores, nil if they should not be saved
+	HighScores *highscores.Store
+}
+
+//Factory Returns a new RenderController of a world with its default settings
+type Factory func(Dependencies) RenderController
+
+//World a world that can be picked on the page
+type World struct {
+	//ID the unique name the world is picked by, lowercase words joined by dashes
+	ID          string
+	DisplayName string
+	Description string
+	New         Factory
+	//Settings the form fields the World's settings are changed with, holding their default values. Read from a
+	//RenderController that has not been started the first time the World is looked up, unless they are given
+	Settings []controllers.FormData
+	//Defaults the default settings as the form values the RenderController's HandleForm reads, made from the Settings
+	//the first time the World is looked up
+	Defaults url.Values
+}
+
+//FormValues Returns the values of the form fields as they are sent when the settings form is submitted
+func FormValues(formData []controllers.FormData) url.Values {
+
+	values := url.Values{}
+
+	for _, fd := range formData {
+		values.Set(fd.Name, fd.Value)
+	}
+
+	return values
+}
+
+//Registry a set of Worlds picked by their ID
+type Registry struct {
+	mutex  sync.Mutex
+	worlds map[string]World
+}
+
+//NewRegistry Returns an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{worlds: make(map[string]World)}
+}
+
+//Default the Registry every world in gopherlife registers itself with
+var Default = NewRegistry()
+
+//Register Adds the World to the Registry. Returns an error if it has no ID or Factory, or the ID is already taken
+func (r *Registry) Register(world World) error {
+
+	if world.ID == "" {
+		return fmt.Errorf("world %q has no ID", world.DisplayName)
+	}
+
+	if world.New == nil {
+		return fmt.Errorf("world %q has no Factory", world.ID)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.worlds[world.ID]; ok {
+		return fmt.Errorf("world %q is already registered", world.ID)
+	}
+
+	if world.DisplayName == "" {
+		world.DisplayName = world.ID
+	}
+
+	world.Defaults = nil
+
+	r.worlds[world.ID] = world
+	return nil
+}
+
+//settle Fills in the Settings and Defaults of the registered World the first time it is looked up, so no
+//RenderController is made while the worlds are registering themselves. The mutex must be held
+func (r *Registry) settle(world World) World {
+
+	if world.Defaults != nil {
+		return world
+	}
+
+	if world.Settings == nil {
+		world.Settings = world.New(Dependencies{}).PageLayout().FormData
+	}
+
+	world.Defaults = FormValues(world.Settings)
+
+	r.worlds[world.ID] = world
+	return world
+}
+
+//Lookup Returns the World with the ID, false if there is not one
+func (r *Registry) Lookup(id string) (World, bool) {
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	world, ok := r.worlds[id]
+
+	if !ok {
+		return World{}, false
+	}
+
+	return r.settle(world), true
+}
+
+//Worlds Returns every World in the Registry in order of their DisplayName
+func (r *Registry) Worlds() []World {
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	worlds := make([]World, 0, len(r.worlds))
+	for _, world := range r.worlds {
+		worlds = append(worlds, r.settle(world))
+	}
+
+	sort.Slice(worlds, func(i, j int) bool {
+		return worlds[i].DisplayName < worlds[j].DisplayName
+	})
+
+	return worlds
+}
+
+//Register Adds the World to the Default Registry, it is meant to be called from an init function. Panics if the World
+//cannot be registered
+func Register(world World) {
+	if err := Default.Register(world); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,122 @@
+package registry
+
+import (
+	"gopherlife/controllers"
+	"net/url"
+	"testing"
+)
+
+//testController a RenderController with a single setting
+type testController struct {
+	controllers.NoPlayerInput
+	size int
+}
+
+func (controller *testController) Update() bool { return true }
+func (controller *testController) Start()       {}
+func (controller *testController) Scroll(int)   {}
+
+func (controller *testController) HandleForm(url.Values) bool { return true }
+
+func (controller *testController) MarshalJSON() ([]byte, error) { return []byte("{}"), nil }
+
+func (controller *testController) PageLayout() controllers.WorldPageData {
+	return controllers.WorldPageData{
+		FormData: []controllers.FormData{controllers.FormDataWidth(controller.size, 2)},
+	}
+}
+
+func newTestWorld(id string, displayName string) World {
+	return World{
+		ID:          id,
+		DisplayName: displayName,
+		New: func(Dependencies) RenderController {
+			return &testController{size: 42}
+		},
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+
+	r := NewRegistry()
+
+	if err := r.Register(newTestWorld("zebra", "Zebra")); err != nil {
+		t.Fatalf("Registry.Register() error = %v", err)
+	}
+
+	if err := r.Register(newTestWorld("aardvark", "Aardvark")); err != nil {
+		t.Fatalf("Registry.Register() error = %v", err)
+	}
+
+	if err := r.Register(newTestWorld("zebra", "Another Zebra")); err == nil {
+		t.Errorf("Registry.Register() of a taken ID did not return an error")
+	}
+
+	if err := r.Register(World{ID: "nothing"}); err == nil {
+		t.Errorf("Registry.Register() of a World without a Factory did not return an error")
+	}
+
+	if err := r.Register(newTestWorld("", "No ID")); err == nil {
+		t.Errorf("Registry.Register() of a World without an ID did not return an error")
+	}
+
+	worlds := r.Worlds()
+
+	if len(worlds) != 2 || worlds[0].ID != "aardvark" || worlds[1].ID != "zebra" {
+		t.Errorf("Registry.Worlds() = %v, want aardvark then zebra", worlds)
+	}
+
+	if world, ok := r.Lookup("zebra"); !ok || world.DisplayName != "Zebra" {
+		t.Errorf("Registry.Lookup(zebra) = %v, %v, want the first Zebra", world.DisplayName, ok)
+	}
+
+	if _, ok := r.Lookup("unicorn"); ok {
+		t.Errorf("Registry.Lookup(unicorn) found a World that was never registered")
+	}
+}
+
+func TestRegistry_RegisterSettings(t *testing.T) {
+
+	r := NewRegistry()
+
+	if err := r.Register(newTestWorld("test", "Test")); err != nil {
+		t.Fatalf("Registry.Register() error = %v", err)
+	}
+
+	world, _ := r.Lookup("test")
+
+	if len(world.Settings) != 1 || world.Settings[0].Name != "width" {
+		t.Fatalf("World.Settings = %v, want the width field", world.Settings)
+	}
+
+	if width := world.Defaults.Get("width"); width != "42" {
+		t.Errorf("World.Defaults width = %q, want 42", width)
+	}
+}
+
+func TestRegistry_SettingsReadWhenLookedUp(t *testing.T) {
+
+	r := NewRegistry()
+	made := 0
+
+	world := newTestWorld("test", "Test")
+	world.New = func(Dependencies) RenderController {
+		made++
+		return &testController{size: 42}
+	}
+
+	if err := r.Register(world); err != nil {
+		t.Fatalf("Registry.Register() error = %v", err)
+	}
+
+	if made != 0 {
+		t.Errorf("Registry.Register() made %d RenderControllers, want none", made)
+	}
+
+	r.Lookup("test")
+	r.Worlds()
+
+	if made != 1 {
+		t.Errorf("Looking the World up twice made %d RenderControllers, want 1", made)
+	}
+}
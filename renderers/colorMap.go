@@ -0,0 +1,108 @@
+package renderers
+
+import (
+	"image/color"
+	"math"
+)
+
+//ColorMap turns a value between 0 and 1 into a colour, used to draw scalar fields as heatmaps
+type ColorMap int
+
+const (
+	Viridis ColorMap = iota
+	Magma
+	Grayscale
+)
+
+//colorMapStops evenly spaced colours along each ColorMap, the colours between them are blended
+var colorMapStops = map[ColorMap][]color.RGBA{
+	Viridis: {
+		{68, 1, 84, 1}, {71, 45, 123, 1}, {59, 82, 139, 1}, {44, 114, 142, 1}, {33, 145, 140, 1},
+		{40, 174, 128, 1}, {94, 201, 98, 1}, {173, 220, 48, 1}, {253, 231, 37, 1},
+	},
+	Magma: {
+		{0, 0, 4, 1}, {28, 16, 68, 1}, {79, 18, 123, 1}, {129, 37, 129, 1}, {181, 54, 122, 1},
+		{229, 80, 100, 1}, {251, 135, 97, 1}, {254, 194, 135, 1}, {252, 253, 191, 1},
+	},
+	Grayscale: {
+		{0, 0, 0, 1}, {255, 255, 255, 1},
+	},
+}
+
+func (colorMap ColorMap) String() string {
+	switch colorMap {
+	case Magma:
+		return "Magma"
+	case Grayscale:
+		return "Grayscale"
+	}
+	return "Viridis"
+}
+
+//Color Returns the colour of the value along the ColorMap, values outside 0 to 1 are clamped
+func (colorMap ColorMap) Color(value float64) color.RGBA {
+
+	stops, ok := colorMapStops[colorMap]
+	if !ok {
+		stops = colorMapStops[Viridis]
+	}
+
+	if math.IsNaN(value) || value < 0 {
+		value = 0
+	} else if value > 1 {
+		value = 1
+	}
+
+	position := value * float64(len(stops)-1)
+	i := int(position)
+
+	if i >= len(stops)-1 {
+		return stops[len(stops)-1]
+	}
+
+	from, to := stops[i], stops[i+1]
+	amount := position - float64(i)
+
+	blend := func(a uint8, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + (float64(b)-float64(a))*amount))
+	}
+
+	return color.RGBA{blend(from.R, to.R), blend(from.G, to.G), blend(from.B, to.B), 1}
+}
+
+//ScalarFieldContainer when given an x and y value the value of the field there should be returned, false if the
+//position is off the field
+type ScalarFieldContainer interface {
+	ScalarValue(x int, y int) (float64, bool)
+}
+
+//Heatmap draws a ScalarFieldContainer by passing each value, scaled between Min and Max, through a ColorMap. Positions
+//off the field are drawn in the OffField colour
+type Heatmap struct {
+	Field    ScalarFieldContainer
+	ColorMap ColorMap
+	Min      float64
+	Max      float64
+	OffField color.RGBA
+}
+
+//RenderTile Returns the colour of the value of the field at x and y
+func (heatmap Heatmap) RenderTile(x int, y int) color.RGBA {
+
+	value, ok := heatmap.Field.ScalarValue(x, y)
+
+	if !ok {
+		return heatmap.OffField
+	}
+
+	if heatmap.Max <= heatmap.Min {
+		return heatmap.ColorMap.Color(0)
+	}
+
+	return heatmap.ColorMap.Color((value - heatmap.Min) / (heatmap.Max - heatmap.Min))
+}
+
+//DrawHeatmap returns a struct containing the colours of a scalar field found within the dimensions of the Renderer
+func (renderer *GridRenderer) DrawHeatmap(heatmap Heatmap) Render {
+	return renderer.Draw(heatmap)
+}
@@ -1,6 +1,7 @@
 package renderers
 
 import (
+	"gopherlife/geometry"
 	"image/color"
 )
 
@@ -13,6 +14,14 @@ type GridRenderer struct {
 
 	TileWidth  int
 	TileHeight int
+
+	//Surface optional shape of the world being drawn. Tiles past a joined edge are drawn from the opposite edge and the
+	//viewport wraps round instead of drifting away from the world
+	Surface *geometry.Surface
+
+	//Hex draws the tiles as pointy topped hexagons with every odd row shifted half a tile right, x and y are the column
+	//and row of each hexagon
+	Hex bool
 }
 
 type Render struct {
@@ -22,6 +31,7 @@ type Render struct {
 	StartY          int
 	TileWidth       int
 	TileHeight      int
+	Hex             bool
 }
 
 type RenderTile struct {
@@ -47,6 +57,7 @@ func (renderer *GridRenderer) Draw(container RenderTileContainer) Render {
 		StartY:          renderer.StartY,
 		TileWidth:       renderer.TileWidth,
 		TileHeight:      renderer.TileHeight,
+		Hex:             renderer.Hex,
 	}
 
 	render.Grid = make([][]*RenderTile, renderer.Width)
@@ -65,7 +76,7 @@ func (renderer *GridRenderer) Draw(container RenderTileContainer) Render {
 
 	for y := startY; y < startY+renderer.Height; y++ {
 		for x := startX; x < startX+renderer.Width; x++ {
-			render.Grid[x-startX][y-startY].RGBA = container.RenderTile(x, y)
+			render.Grid[x-startX][y-startY].RGBA = container.RenderTile(renderer.wrap(x, y))
 		}
 	}
 
@@ -76,6 +87,15 @@ func (renderer *GridRenderer) Draw(container RenderTileContainer) Render {
 func (gr *GridRenderer) Shift(x int, y int) {
 	gr.StartX += x
 	gr.StartY += y
+	gr.StartX, gr.StartY = gr.wrap(gr.StartX, gr.StartY)
+}
+
+//wrap Moves x and y across any joined edges of the Surface
+func (gr *GridRenderer) wrap(x int, y int) (int, int) {
+	if gr.Surface != nil {
+		x, y, _ = gr.Surface.Wrap(x, y)
+	}
+	return x, y
 }
 
 func (gr *GridRenderer) Scroll(deltaY int) {
@@ -1,3 +1,6 @@
+//RenderInterval the milliseconds between each request for the world, the server updates the world on its own
+var RenderInterval = 16
+
 $(document).ready(function () {
 
     var ci = new CanvasInformation()
@@ -24,6 +27,41 @@ $(document).ready(function () {
     $canvas.on('click', function(event) {
         HandleClick(event, ci)
     });
+
+    //Dragging with the button held clicks each new tile the mouse moves over
+    var lastDragTile = null
+
+    $canvas.on('mousedown', function(event) {
+        lastDragTile = ClickToWorld(event, ci)
+    });
+
+    $canvas.on('mousemove', function(event) {
+        if (lastDragTile == null || (event.buttons & 1) == 0) {
+            lastDragTile = null
+            return
+        }
+
+        var tile = ClickToWorld(event, ci)
+
+        if (tile.x != lastDragTile.x || tile.y != lastDragTile.y) {
+            lastDragTile = tile
+            SendClick(tile)
+        }
+    });
+
+    $("#loop-pause").on('click', function() {
+        $.getJSON('/Loop/Pause', function(paused) {
+            $("#loop-pause").text(paused ? "Play" : "Pause")
+        })
+    });
+
+    $("#loop-step").on('click', function() {
+        $.get('/Loop/Step')
+    });
+
+    $("#loop-speed").on('change', function() {
+        $.get('/Loop/Speed?multiplier=' + $(this).val())
+    });
 })
 
 
@@ -63,6 +101,7 @@ function CanvasInformation(){
     this.Grid = {}
     this.OtherStartX = 0
     this.OtherStartY = 0
+    this.Hex = false
 }
 
 
@@ -77,13 +116,19 @@ function OpenWorld(CanvasInformation) {
             CanvasInformation.OtherStartY = data.StartY;
             CanvasInformation.TileWidth = data.TileWidth;
             CanvasInformation.TileHeight = data.TileHeight;
+            CanvasInformation.Hex = data.Hex;
             UpdateWorldDisplay(data, CanvasInformation);
-            OpenWorld(CanvasInformation);
+            setTimeout(function () { OpenWorld(CanvasInformation) }, RenderInterval);
         },
     })
 }
 
 function HandleClick(event, CanvasInformation) {
+    SendClick(ClickToWorld(event, CanvasInformation))
+}
+
+//ClickToWorld Returns the world x and y of the tile under the mouse
+function ClickToWorld(event, CanvasInformation) {
     var canvas = document.querySelector('canvas')
     var rect = canvas.getBoundingClientRect();
 
@@ -98,7 +143,19 @@ function HandleClick(event, CanvasInformation) {
     //Convert render x and y to world coordinates
     x = (CanvasInformation.OtherStartX + x) - 1
     y = (CanvasInformation.OtherStartY + y) - 1
-    
+
+    if (CanvasInformation.Hex) {
+        var row = Math.floor((canvasY - CanvasInformation.StartY) / CanvasInformation.TileHeight) + 1
+        y = CanvasInformation.OtherStartY + row
+        x = CanvasInformation.OtherStartX + Math.floor((canvasX - CanvasInformation.StartX) / CanvasInformation.TileWidth - HexRowShift(y))
+    }
+
+    return {x: x, y: y}
+}
+
+function SendClick(tile) {
+    var x = tile.x
+    var y = tile.y
 
     $.ajax({
         type: 'GET',
@@ -130,11 +187,37 @@ function DrawGrid(Grid, CanvasInformation) {
 
             var x = CanvasInformation.StartX + (i * CanvasInformation.TileWidth)
             var y = CanvasInformation.StartY + (j * CanvasInformation.TileHeight)
+
+            if (CanvasInformation.Hex) {
+                x += HexRowShift(CanvasInformation.OtherStartY + j) * CanvasInformation.TileWidth
+                FillHex(cxt, x + CanvasInformation.TileWidth / 2, canvas.height - y + CanvasInformation.TileHeight / 2, CanvasInformation.TileWidth, CanvasInformation.TileHeight)
+                continue
+            }
+
             cxt.fillRect(x, canvas.height - y, CanvasInformation.TileWidth, CanvasInformation.TileHeight);
         }
     }
 }
 
+//HexRowShift Returns how many tiles a row of hexagons is shifted right, every odd row is shifted half a tile
+function HexRowShift(row) {
+    return ((row % 2) + 2) % 2 / 2
+}
+
+//FillHex Fills a pointy topped hexagon centred on x and y. Rows of hexagons are height apart, so each hexagon is a
+//third taller than that and overlaps the rows above and below at its points
+function FillHex(cxt, x, y, width, height) {
+    cxt.beginPath();
+    cxt.moveTo(x, y - height * 2 / 3);
+    cxt.lineTo(x + width / 2, y - height / 3);
+    cxt.lineTo(x + width / 2, y + height / 3);
+    cxt.lineTo(x, y + height * 2 / 3);
+    cxt.lineTo(x - width / 2, y + height / 3);
+    cxt.lineTo(x - width / 2, y - height / 3);
+    cxt.closePath();
+    cxt.fill();
+}
+
 function ResizeCanvasToDisplaySize(canvas) {
     const width = canvas.clientWidth;
     const height = canvas.clientHeight;
@@ -155,4 +238,5 @@ function DisplaySelectedGopher(gopher) {
     $("#gopher-position").html("(" + x + "," + y + ")")
     $("#gopher-hunger").html("(" + gopher.Hunger + ")")
     $("#gopher-lifespan").html("(" + gopher.Lifespan + ")")
+    $("#gopher-infection").html(gopher.Infection)
 }
\ No newline at end of file
@@ -0,0 +1,228 @@
+package timer
+
+import (
+	"sync"
+	"time"
+)
+
+//WaitMode how a Loop waits between steps
+type WaitMode int
+
+const (
+	//SleepWait sleeps until the next step is due
+	SleepWait WaitMode = iota
+	//TickerWait wakes up once every timestep using a time.Ticker
+	TickerWait
+)
+
+//minimumTimestep the shortest time a Loop can take between steps
+const minimumTimestep = time.Millisecond
+
+//maxStepsPerAdvance the most steps a Loop runs to catch up at once. If the steps take longer than the timestep the
+//Loop slows down instead of falling further and further behind
+const maxStepsPerAdvance = 5
+
+//Loop calls a step function at a fixed rate. Time that passes is added to an accumulator and a step is run for each
+//whole timestep in it, so the simulation runs at the same rate however long each wait really takes. A Loop can be
+//paused, stepped one step at a time while paused, and sped up or slowed down
+type Loop struct {
+	mutex sync.Mutex
+
+	step         func()
+	timestep     time.Duration
+	speed        float64
+	accumulator  time.Duration
+	paused       bool
+	pendingSteps int
+	ticks        int
+
+	stop    chan struct{}
+	stopped bool
+}
+
+//NewLoop Returns a Loop that calls step once every timestep
+func NewLoop(timestep time.Duration, step func()) *Loop {
+	loop := Loop{step: step, speed: 1, stop: make(chan struct{})}
+	loop.SetTimestep(timestep)
+	return &loop
+}
+
+//Timestep Returns the time between each step
+func (l *Loop) Timestep() time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.timestep
+}
+
+//SetTimestep Changes the time between each step. It can be called from inside the step function
+func (l *Loop) SetTimestep(timestep time.Duration) {
+
+	if timestep < minimumTimestep {
+		timestep = minimumTimestep
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.timestep = timestep
+}
+
+//Speed Returns how many times faster than its timestep the Loop runs
+func (l *Loop) Speed() float64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.speed
+}
+
+//SetSpeed Runs the Loop the given number of times faster than its timestep, a multiplier below one slows it down.
+//Multipliers that are not above zero are ignored
+func (l *Loop) SetSpeed(multiplier float64) {
+
+	if multiplier <= 0 {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.speed = multiplier
+}
+
+//IsPaused Returns true if the Loop is paused
+func (l *Loop) IsPaused() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.paused
+}
+
+//TogglePause Pauses the Loop or starts it again. Time that passes while paused is not caught up
+func (l *Loop) TogglePause() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.paused = !l.paused
+	l.accumulator = 0
+}
+
+//Step Runs a single step the next time the Loop advances, even if it is paused
+func (l *Loop) Step() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.pendingSteps++
+}
+
+//Ticks Returns the number of steps run
+func (l *Loop) Ticks() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.ticks
+}
+
+//Advance Adds the elapsed time, multiplied by the speed, to the accumulator and runs a step for each whole timestep in
+//it along with any single steps. Returns the number of steps run
+func (l *Loop) Advance(elapsed time.Duration) int {
+
+	l.mutex.Lock()
+
+	steps := l.pendingSteps
+	l.pendingSteps = 0
+
+	if !l.paused {
+
+		l.accumulator += time.Duration(float64(elapsed) * l.speed)
+		due := int(l.accumulator / l.timestep)
+
+		if due > maxStepsPerAdvance {
+			due = maxStepsPerAdvance
+			l.accumulator = 0
+		} else {
+			l.accumulator -= time.Duration(due) * l.timestep
+		}
+
+		steps += due
+	}
+
+	l.ticks += steps
+
+	l.mutex.Unlock()
+
+	for i := 0; i < steps; i++ {
+		l.step()
+	}
+
+	return steps
+}
+
+//untilNextStep Returns how long until the accumulator holds a whole timestep
+func (l *Loop) untilNextStep() time.Duration {
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.paused {
+		return l.timestep
+	}
+
+	wait := time.Duration(float64(l.timestep-l.accumulator) / l.speed)
+
+	if wait < minimumTimestep {
+		wait = minimumTimestep
+	}
+
+	return wait
+}
+
+//Run Advances the Loop by the time that really passes until Stop is called, waiting between steps using the WaitMode
+func (l *Loop) Run(mode WaitMode) {
+
+	stop := l.stop
+
+	var ticker *time.Ticker
+	period := l.Timestep()
+
+	if mode == TickerWait {
+		ticker = time.NewTicker(period)
+		defer func() { ticker.Stop() }()
+	}
+
+	last := time.Now()
+
+	for {
+
+		if mode == TickerWait {
+
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+
+			if timestep := l.Timestep(); timestep != period {
+				period = timestep
+				ticker.Stop()
+				ticker = time.NewTicker(period)
+			}
+
+		} else {
+
+			select {
+			case <-stop:
+				return
+			case <-time.After(l.untilNextStep()):
+			}
+		}
+
+		now := time.Now()
+		l.Advance(now.Sub(last))
+		last = now
+	}
+}
+
+//Stop Stops the Loop running, once stopped it cannot be run again
+func (l *Loop) Stop() {
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if !l.stopped {
+		close(l.stop)
+		l.stopped = true
+	}
+}
@@ -0,0 +1,85 @@
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoop_Advance(t *testing.T) {
+
+	steps := 0
+	loop := NewLoop(10*time.Millisecond, func() { steps++ })
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		want    int
+	}{
+		{"Less than a timestep", 4 * time.Millisecond, 0},
+		{"Accumulated to a timestep", 7 * time.Millisecond, 1},
+		{"Several timesteps", 25 * time.Millisecond, 2},
+		{"Remainder carried over", 4 * time.Millisecond, 1},
+		{"Too far behind", time.Second, maxStepsPerAdvance},
+		{"Backlog dropped", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := loop.Advance(tt.elapsed); got != tt.want {
+				t.Errorf("Loop.Advance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if steps != loop.Ticks() {
+		t.Errorf("Loop.Ticks() = %v, want %v", loop.Ticks(), steps)
+	}
+}
+
+func TestLoop_PauseAndStep(t *testing.T) {
+
+	loop := NewLoop(10*time.Millisecond, func() {})
+	loop.TogglePause()
+
+	if got := loop.Advance(time.Second); got != 0 {
+		t.Errorf("Loop.Advance() while paused = %v, want 0", got)
+	}
+
+	loop.Step()
+	loop.Step()
+
+	if got := loop.Advance(0); got != 2 {
+		t.Errorf("Loop.Advance() after two steps = %v, want 2", got)
+	}
+
+	loop.TogglePause()
+	loop.SetSpeed(2)
+
+	if got := loop.Advance(10 * time.Millisecond); got != 2 {
+		t.Errorf("Loop.Advance() at double speed = %v, want 2", got)
+	}
+}
+
+func TestLoop_Run(t *testing.T) {
+
+	for _, mode := range []WaitMode{SleepWait, TickerWait} {
+
+		ran := make(chan bool, 100)
+
+		loop := NewLoop(time.Millisecond, func() {
+			select {
+			case ran <- true:
+			default:
+			}
+		})
+
+		go loop.Run(mode)
+
+		select {
+		case <-ran:
+		case <-time.After(time.Second):
+			t.Errorf("Loop.Run() with WaitMode %v did not step", mode)
+		}
+
+		loop.Stop()
+	}
+}
@@ -1,11 +1,17 @@
 package timer
 
-import "time"
+import (
+	"gopherlife/metrics"
+	"time"
+)
 
 type StopWatch struct {
 	records []time.Duration
 
 	startTime time.Time
+
+	//Histogram if set every duration is also recorded here, so percentiles cover every run rather than the last few
+	Histogram *metrics.Histogram
 }
 
 const maxRecords = 10
@@ -26,6 +32,10 @@ func (s *StopWatch) Stop() {
 	}
 
 	s.records = append(s.records, diff)
+
+	if s.Histogram != nil {
+		s.Histogram.Record(int64(diff))
+	}
 }
 
 func (s *StopWatch) GetCurrentElaspedTime() time.Duration {
@@ -56,3 +66,24 @@ func (s *StopWatch) GetAverage() time.Duration {
 	return total / div
 
 }
+
+//GetPercentile Returns the duration that p percent of all recorded durations are less than or equal to. Returns zero if
+//the StopWatch has no Histogram
+func (s *StopWatch) GetPercentile(p float64) time.Duration {
+
+	if s.Histogram == nil {
+		return 0
+	}
+
+	return time.Duration(s.Histogram.Percentile(p))
+}
+
+//GetMax Returns the longest recorded duration. Returns zero if the StopWatch has no Histogram
+func (s *StopWatch) GetMax() time.Duration {
+
+	if s.Histogram == nil {
+		return 0
+	}
+
+	return time.Duration(s.Histogram.Max())
+}
@@ -0,0 +1,30 @@
+package timer
+
+import (
+	"gopherlife/metrics"
+	"testing"
+)
+
+func TestStopWatch_Histogram(t *testing.T) {
+
+	var s StopWatch
+
+	if s.GetPercentile(99) != 0 || s.GetMax() != 0 {
+		t.Errorf("StopWatch without a Histogram should report zero percentiles")
+	}
+
+	s.Histogram = metrics.NewHistogram()
+
+	for i := 0; i < 20; i++ {
+		s.Start()
+		s.Stop()
+	}
+
+	if s.Histogram.Count() != 20 {
+		t.Errorf("StopWatch recorded %d durations into its Histogram, want 20", s.Histogram.Count())
+	}
+
+	if s.GetPercentile(50) > s.GetMax() {
+		t.Errorf("StopWatch.GetPercentile(50) = %v is above StopWatch.GetMax() = %v", s.GetPercentile(50), s.GetMax())
+	}
+}
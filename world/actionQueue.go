@@ -1,5 +1,7 @@
 package world
 
+import "gopherlife/metrics"
+
 type ActionQueuer interface {
 	Add(action func())
 	Process()
@@ -8,12 +10,24 @@ type ActionQueuer interface {
 type FiniteActionQueue struct {
 	actionQueue chan func()
 	maxActions  int
+
+	//queued the number of actions waiting when the queue was last processed, and dropped the number thrown away
+	//because it was full
+	queued  *metrics.Gauge
+	dropped *metrics.Counter
 }
 
-func NewFiniteActionQueue(maxActions int) FiniteActionQueue {
+//NewFiniteActionQueue Returns a FiniteActionQueue holding up to maxActions, counted in the metrics of the world with
+//the name
+func NewFiniteActionQueue(maxActions int, world string) FiniteActionQueue {
+
+	scope := worldMetrics(world)
+
 	return FiniteActionQueue{
 		actionQueue: make(chan func(), maxActions),
 		maxActions:  maxActions,
+		queued:      scope.Gauge("actions_queued", "Number of actions waiting when the action queue was last processed"),
+		dropped:     scope.Counter("actions_dropped_total", "Number of actions dropped because the action queue was full"),
 	}
 }
 
@@ -21,6 +35,7 @@ func (finiteActionQueue *FiniteActionQueue) Add(action func()) {
 	select {
 	case finiteActionQueue.actionQueue <- action: // Put 2 in the channel unless it is full
 	default:
+		finiteActionQueue.dropped.Inc()
 	}
 }
 
@@ -28,6 +43,7 @@ func (finiteActionQueue *FiniteActionQueue) Process() {
 	actionChannel := finiteActionQueue.actionQueue
 	finiteActionQueue.actionQueue = make(chan func(), finiteActionQueue.maxActions)
 	close(actionChannel)
+	finiteActionQueue.queued.Set(int64(len(actionChannel)))
 	for action := range actionChannel {
 		action()
 	}
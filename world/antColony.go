@@ -0,0 +1,307 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"math"
+	"math/rand"
+	"time"
+)
+
+//antHeadings the eight directions an Ant can face, in clockwise order
+var antHeadings = [8][2]int{{0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}, {-1, -1}, {-1, 0}, {-1, 1}}
+
+//Ant a single ant of an AntColony. It wanders out of the nest looking for food and carries what it finds back home
+type Ant struct {
+	Position     geometry.Coordinates
+	Heading      int
+	CarryingFood bool
+	//trail the number of steps since the Ant last left the nest or picked up food, the pheromone it lays gets weaker
+	//the further it goes
+	trail int
+}
+
+//Numbers tuning how the ants behave
+const (
+	//antSenseRadius how far away an Ant can see food, or the nest when it is carrying food home
+	antSenseRadius = 3
+	//antDeposit the pheromone an Ant lays on a tile as it leaves the nest or a piece of food
+	antDeposit = 1.0
+	//antWanderChance the chance out of 100 that an Ant turns at random instead of following the scent
+	antWanderChance = 10
+	//antDeliverySampleRate the number of ticks between each AntDeliveries sample
+	antDeliverySampleRate = 50
+	//maxAntDeliverySamples the number of AntDeliveries samples kept, older samples are dropped
+	maxAntDeliverySamples = 100
+)
+
+//AntDeliveries the total food delivered to the nest by a tick
+type AntDeliveries struct {
+	Tick      int
+	Delivered int
+}
+
+//AntColonySettings sets up an AntColony
+type AntColonySettings struct {
+	Dimensions
+	Seeded
+	NumberOfAnts int
+	NumberOfFood int
+	//NestRadius the size of the nest in the middle of the world
+	NestRadius int
+	//Evaporation the fraction of pheromone that fades away each tick
+	Evaporation float64
+	//Diffusion the fraction of pheromone on each tile that spreads to the tiles next to it each tick
+	Diffusion float64
+}
+
+//AntColony ants leave a nest and wander around looking for food. Ants heading out lay home pheromone so others can
+//find their way back, ants carrying food lay food pheromone so others can find what they found. Ants follow the scent
+//they are looking for, so the paths to food get stronger as more ants use them
+type AntColony struct {
+	AntColonySettings
+	Nest          geometry.Coordinates
+	Ants          []*Ant
+	HomePheromone PheromoneField
+	FoodPheromone PheromoneField
+	FoodDelivered int
+	//History the food delivered sampled every antDeliverySampleRate ticks, oldest first
+	History []AntDeliveries
+	Tick    int
+
+	food *Basic2DContainer
+	GopherWorldSearcher
+	random *rand.Rand
+}
+
+//NewAntColony Returns an AntColony with its ants in the nest and its food scattered around the world
+func NewAntColony(settings AntColonySettings) AntColony {
+
+	if settings.Width < 1 {
+		settings.Width = 1
+	}
+
+	if settings.Height < 1 {
+		settings.Height = 1
+	}
+
+	random := settings.random()
+
+	food := NewBasic2DContainer(0, 0, settings.Width, settings.Height)
+
+	antColony := AntColony{
+		AntColonySettings: settings,
+		Nest:              geometry.NewCoordinate(settings.Width/2, settings.Height/2),
+		HomePheromone:     NewPheromoneField(settings.Dimensions),
+		FoodPheromone:     NewPheromoneField(settings.Dimensions),
+		food:              &food,
+		random:            random,
+	}
+
+	antColony.GopherWorldSearcher = &SpiralTileSearch{TileContainer: antColony.food}
+
+	positions := []geometry.Coordinates{}
+
+	for _, pos := range geometry.GenerateCoordinateArray(0, 0, settings.Width, settings.Height) {
+		if !antColony.IsNest(pos.GetX(), pos.GetY()) {
+			positions = append(positions, pos)
+		}
+	}
+
+	//Shuffled with the colony's own random numbers so the same Seed scatters the food the same way
+	antColony.random.Shuffle(len(positions), func(i, j int) {
+		positions[i], positions[j] = positions[j], positions[i]
+	})
+
+	PlaceFood(antColony.food, positions, settings.NumberOfFood)
+
+	for i := 0; i < settings.NumberOfAnts; i++ {
+		antColony.Ants = append(antColony.Ants, &Ant{
+			Position: antColony.Nest,
+			Heading:  antColony.random.Intn(len(antHeadings)),
+		})
+	}
+
+	return antColony
+}
+
+//Surface Returns the shape of the world
+func (antColony *AntColony) Surface() *geometry.Surface {
+	return antColony.food.Surface()
+}
+
+//IsNest Returns true if x and y are inside the nest
+func (antColony *AntColony) IsNest(x int, y int) bool {
+	return antColony.Surface().Distance(antColony.Nest, geometry.NewCoordinate(x, y)) <= antColony.NestRadius
+}
+
+//HasFood Returns true if there is food at x and y
+func (antColony *AntColony) HasFood(x int, y int) bool {
+	_, ok := antColony.food.HasFood(x, y)
+	return ok
+}
+
+//AddFood Drops a piece of food at x and y. Returns false if there is already food there or it is off the world
+func (antColony *AntColony) AddFood(x int, y int) bool {
+
+	if antColony.HasFood(x, y) || antColony.IsNest(x, y) {
+		return false
+	}
+
+	food := NewPotato()
+	return antColony.food.InsertFood(x, y, &food)
+}
+
+//HasAnt Returns the ant at x and y, if there is one
+func (antColony *AntColony) HasAnt(x int, y int) (*Ant, bool) {
+	for _, ant := range antColony.Ants {
+		if ant.Position.GetX() == x && ant.Position.GetY() == y {
+			return ant, true
+		}
+	}
+	return nil, false
+}
+
+//DeliveryRate Returns the food delivered per tick over the samples in the History
+func (antColony *AntColony) DeliveryRate() float64 {
+
+	if len(antColony.History) < 2 {
+		return 0
+	}
+
+	first, last := antColony.History[0], antColony.History[len(antColony.History)-1]
+
+	return float64(last.Delivered-first.Delivered) / float64(last.Tick-first.Tick)
+}
+
+//Update Moves every ant one step, then evaporates and diffuses the pheromone
+func (antColony *AntColony) Update() bool {
+
+	for _, ant := range antColony.Ants {
+		antColony.act(ant)
+	}
+
+	antColony.HomePheromone.Step(antColony.Evaporation, antColony.Diffusion)
+	antColony.FoodPheromone.Step(antColony.Evaporation, antColony.Diffusion)
+
+	antColony.Tick++
+
+	if antColony.Tick%antDeliverySampleRate == 0 {
+		antColony.History = append(antColony.History, AntDeliveries{Tick: antColony.Tick, Delivered: antColony.FoodDelivered})
+		if len(antColony.History) > maxAntDeliverySamples {
+			antColony.History = antColony.History[1:]
+		}
+	}
+
+	return true
+}
+
+//TickRate Returns the time between each Update
+func (antColony *AntColony) TickRate() time.Duration {
+	return time.Millisecond * FrameSpeedMultiplier * 3
+}
+
+//act An ant looking for food picks up food it is standing on, heads for food it can see or follows the food
+//pheromone. An ant carrying food drops it in the nest, heads for the nest if it can see it or follows the home
+//pheromone. Either way it lays its own pheromone as it goes
+func (antColony *AntColony) act(ant *Ant) {
+
+	x, y := ant.Position.GetX(), ant.Position.GetY()
+
+	if ant.CarryingFood {
+
+		if antColony.IsNest(x, y) {
+			ant.CarryingFood = false
+			ant.trail = 0
+			ant.Heading = (ant.Heading + len(antHeadings)/2) % len(antHeadings)
+			antColony.FoodDelivered++
+			return
+		}
+
+		antColony.FoodPheromone.Deposit(x, y, antColony.deposit(ant))
+
+		if antColony.Surface().Distance(ant.Position, antColony.Nest) <= antColony.NestRadius+antSenseRadius {
+			antColony.headTowards(ant, antColony.Nest)
+		} else {
+			antColony.follow(ant, &antColony.HomePheromone)
+		}
+
+	} else {
+
+		if _, ok := antColony.food.RemoveFood(x, y); ok {
+			ant.CarryingFood = true
+			ant.trail = 0
+			ant.Heading = (ant.Heading + len(antHeadings)/2) % len(antHeadings)
+			return
+		}
+
+		antColony.HomePheromone.Deposit(x, y, antColony.deposit(ant))
+
+		size := antSenseRadius*2 + 1
+
+		if food := antColony.Search(ant.Position, size, size, 1, SearchForFood); len(food) > 0 {
+			antColony.headTowards(ant, food[0])
+		} else {
+			antColony.follow(ant, &antColony.FoodPheromone)
+		}
+	}
+
+	antColony.move(ant)
+}
+
+//deposit Returns the pheromone the ant lays, weaker the further the ant is along its trail
+func (antColony *AntColony) deposit(ant *Ant) float64 {
+	return antDeposit / (1 + float64(ant.trail)/10)
+}
+
+//headTowards Turns the ant to face the target
+func (antColony *AntColony) headTowards(ant *Ant, target geometry.Coordinates) {
+
+	dx, dy := antColony.Surface().FindNextStep(ant.Position, target)
+
+	for i, heading := range antHeadings {
+		if heading[0] == dx && heading[1] == dy {
+			ant.Heading = i
+		}
+	}
+}
+
+//follow Turns the ant towards one of the three tiles in front of it, picked at random weighted by the strength of the
+//pheromone on each. Now and again the ant ignores the scent and turns at random
+func (antColony *AntColony) follow(ant *Ant, field *PheromoneField) {
+
+	if antColony.random.Intn(100) < antWanderChance {
+		ant.Heading = (ant.Heading + antColony.random.Intn(3) - 1 + len(antHeadings)) % len(antHeadings)
+		return
+	}
+
+	weights := [3]float64{}
+	total := 0.0
+
+	for i := range weights {
+		heading := antHeadings[(ant.Heading+i-1+len(antHeadings))%len(antHeadings)]
+		weights[i] = math.Pow(0.05+field.Value(ant.Position.GetX()+heading[0], ant.Position.GetY()+heading[1]), 2)
+		total += weights[i]
+	}
+
+	pick := antColony.random.Float64() * total
+
+	for i, weight := range weights {
+		if pick -= weight; pick <= 0 || i == len(weights)-1 {
+			ant.Heading = (ant.Heading + i - 1 + len(antHeadings)) % len(antHeadings)
+			return
+		}
+	}
+}
+
+//move Moves the ant one tile the way it is facing. An ant that would walk off the world turns around instead
+func (antColony *AntColony) move(ant *Ant) {
+
+	heading := antHeadings[ant.Heading]
+
+	if x, y, ok := antColony.Surface().Wrap(ant.Position.GetX()+heading[0], ant.Position.GetY()+heading[1]); ok {
+		ant.Position = geometry.NewCoordinate(x, y)
+		ant.trail++
+	} else {
+		ant.Heading = (ant.Heading + len(antHeadings)/2) % len(antHeadings)
+	}
+}
@@ -0,0 +1,104 @@
+package world
+
+import "testing"
+
+func TestAntColony_DeliversFood(t *testing.T) {
+
+	antColony := NewAntColony(AntColonySettings{
+		Dimensions:   Dimensions{Width: 21, Height: 21},
+		NumberOfAnts: 10,
+		NestRadius:   1,
+		Evaporation:  0.01,
+		Diffusion:    0.1,
+		Seeded:       Seeded{Seed: 1},
+	})
+
+	antColony.AddFood(13, 10)
+
+	for i := 0; i < 200 && antColony.FoodDelivered == 0; i++ {
+		antColony.Update()
+	}
+
+	if antColony.FoodDelivered != 1 {
+		t.Errorf("AntColony.FoodDelivered = %d, want the food next to the nest delivered", antColony.FoodDelivered)
+	}
+
+	if antColony.HasFood(13, 10) {
+		t.Errorf("Food was not picked up")
+	}
+}
+
+func TestAntColony_LaysPheromone(t *testing.T) {
+
+	antColony := NewAntColony(AntColonySettings{
+		Dimensions:   Dimensions{Width: 21, Height: 21},
+		NumberOfAnts: 5,
+		NestRadius:   1,
+		Evaporation:  0.01,
+		Diffusion:    0.1,
+		Seeded:       Seeded{Seed: 1},
+	})
+
+	for i := 0; i < 10; i++ {
+		antColony.Update()
+	}
+
+	if antColony.HomePheromone.Max() == 0 {
+		t.Errorf("Ants leaving the nest did not lay home pheromone")
+	}
+
+	if antColony.FoodPheromone.Max() != 0 {
+		t.Errorf("Ants without food laid food pheromone")
+	}
+}
+
+func TestAntColony_FoodIsNotPlacedInNest(t *testing.T) {
+
+	antColony := NewAntColony(AntColonySettings{
+		Dimensions:   Dimensions{Width: 5, Height: 5},
+		NumberOfFood: 25,
+		NestRadius:   1,
+		Seeded:       Seeded{Seed: 1},
+	})
+
+	if antColony.HasFood(2, 2) || antColony.HasFood(2, 3) {
+		t.Errorf("Food was placed inside the nest")
+	}
+
+	if antColony.AddFood(2, 2) {
+		t.Errorf("AntColony.AddFood() added food inside the nest")
+	}
+}
+
+func TestAntColony_SeedPlacesFood(t *testing.T) {
+
+	settings := AntColonySettings{
+		Dimensions:   Dimensions{Width: 30, Height: 30},
+		NumberOfFood: 50,
+		NestRadius:   2,
+		Seeded:       Seeded{Seed: 7},
+	}
+
+	first, second := NewAntColony(settings), NewAntColony(settings)
+
+	for y := 0; y < settings.Height; y++ {
+		for x := 0; x < settings.Width; x++ {
+			if first.HasFood(x, y) != second.HasFood(x, y) {
+				t.Fatalf("Food at %v,%v differs between two colonies with the same Seed", x, y)
+			}
+		}
+	}
+}
+
+func TestAntColony_History(t *testing.T) {
+
+	antColony := NewAntColony(AntColonySettings{Dimensions: Dimensions{Width: 5, Height: 5}, Seeded: Seeded{Seed: 1}})
+
+	for i := 0; i < antDeliverySampleRate*(maxAntDeliverySamples+5); i++ {
+		antColony.Update()
+	}
+
+	if len(antColony.History) != maxAntDeliverySamples {
+		t.Errorf("len(AntColony.History) = %d, want %d", len(antColony.History), maxAntDeliverySamples)
+	}
+}
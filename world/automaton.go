@@ -0,0 +1,185 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"runtime"
+	"sync"
+)
+
+//AutomatonRule decides the next state of a cell from its current state and the number of its eight neighbours that
+//are alive. A state of zero is dead, any other state is alive
+type AutomatonRule interface {
+	Next(state uint8, alive int) uint8
+}
+
+//Automaton a grid of cells that all change state at once each Step by following an AutomatonRule. The grid is double
+//buffered, the next states are written to a second grid which is swapped in once every cell has been updated. The rows
+//are split into stripes that are updated in parallel
+type Automaton struct {
+	Rule AutomatonRule
+
+	dimensions Dimensions
+	surface    geometry.Surface
+	cells      []uint8
+	next       []uint8
+
+	Generation int
+	Population int
+
+	stripes int
+}
+
+//NewAutomaton Returns an Automaton of dead cells with its edges joined by the topology
+func NewAutomaton(width int, height int, topology geometry.Topology, rule AutomatonRule) Automaton {
+
+	if width < 1 {
+		width = 1
+	}
+
+	if height < 1 {
+		height = 1
+	}
+
+	stripes := runtime.NumCPU()
+	if stripes > height {
+		stripes = height
+	}
+
+	return Automaton{
+		Rule:       rule,
+		dimensions: Dimensions{Width: width, Height: height},
+		surface:    geometry.NewSurface(0, 0, width, height, topology),
+		cells:      make([]uint8, width*height),
+		next:       make([]uint8, width*height),
+		stripes:    stripes,
+	}
+}
+
+//Dimensions Returns the width and height of the Automaton
+func (automaton *Automaton) Dimensions() Dimensions {
+	return automaton.dimensions
+}
+
+//Surface Returns the shape of the Automaton
+func (automaton *Automaton) Surface() *geometry.Surface {
+	return &automaton.surface
+}
+
+//index Returns where the cell is in the grid once it has been wrapped across any joined edges, false if it is off
+//the grid
+func (automaton *Automaton) index(x int, y int) (int, bool) {
+
+	x, y, ok := automaton.surface.Wrap(x, y)
+
+	if !ok {
+		return 0, false
+	}
+
+	return y*automaton.dimensions.Width + x, true
+}
+
+//Cell Returns the state of the cell, false if it is off the grid
+func (automaton *Automaton) Cell(x int, y int) (uint8, bool) {
+
+	if i, ok := automaton.index(x, y); ok {
+		return automaton.cells[i], true
+	}
+
+	return 0, false
+}
+
+//SetCell Changes the state of the cell. Returns false if it is off the grid
+func (automaton *Automaton) SetCell(x int, y int, state uint8) bool {
+
+	i, ok := automaton.index(x, y)
+
+	if !ok {
+		return false
+	}
+
+	if automaton.cells[i] == 0 && state != 0 {
+		automaton.Population++
+	} else if automaton.cells[i] != 0 && state == 0 {
+		automaton.Population--
+	}
+
+	automaton.cells[i] = state
+	return true
+}
+
+//Clear Kills every cell
+func (automaton *Automaton) Clear() {
+	for i := range automaton.cells {
+		automaton.cells[i] = 0
+	}
+	automaton.Population = 0
+}
+
+//AliveNeighbours Returns how many of the eight cells around the cell are alive
+func (automaton *Automaton) AliveNeighbours(x int, y int) int {
+
+	alive := 0
+
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if dx == 0 && dy == 0 {
+				continue
+			}
+
+			if state, ok := automaton.Cell(x+dx, y+dy); ok && state != 0 {
+				alive++
+			}
+		}
+	}
+
+	return alive
+}
+
+//Step Moves every cell on to its next state
+func (automaton *Automaton) Step() {
+
+	width, height := automaton.dimensions.Width, automaton.dimensions.Height
+	rowsPerStripe := (height + automaton.stripes - 1) / automaton.stripes
+	populations := make([]int, automaton.stripes)
+
+	var wg sync.WaitGroup
+
+	for stripe := 0; stripe < automaton.stripes; stripe++ {
+
+		wg.Add(1)
+
+		go func(stripe int) {
+
+			defer wg.Done()
+
+			start := stripe * rowsPerStripe
+			end := start + rowsPerStripe
+			if end > height {
+				end = height
+			}
+
+			for y := start; y < end; y++ {
+				for x := 0; x < width; x++ {
+
+					i := y*width + x
+					state := automaton.Rule.Next(automaton.cells[i], automaton.AliveNeighbours(x, y))
+					automaton.next[i] = state
+
+					if state != 0 {
+						populations[stripe]++
+					}
+				}
+			}
+		}(stripe)
+	}
+
+	wg.Wait()
+
+	automaton.cells, automaton.next = automaton.next, automaton.cells
+	automaton.Generation++
+
+	automaton.Population = 0
+	for _, population := range populations {
+		automaton.Population += population
+	}
+}
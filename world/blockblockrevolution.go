@@ -3,7 +3,6 @@ package world
 import (
 	"gopherlife/colors"
 	"gopherlife/geometry"
-	"gopherlife/timer"
 	"image/color"
 	"math/rand"
 	"time"
@@ -11,6 +10,7 @@ import (
 
 type BlockBlockRevolutionSettings struct {
 	Dimensions
+	Seeded
 	BlockSpeedReduction int
 }
 
@@ -26,10 +26,12 @@ type BlockBlockRevolutionWorld struct {
 
 	ActionQueuer
 
-	FrameTimer timer.StopWatch
 	FrameSpeed time.Duration
 
 	DownToNextLineCount int
+	//Tick the number of frames that have been played
+	Tick   int
+	random *rand.Rand
 
 	Score      int
 	IsGameOver bool
@@ -55,7 +57,9 @@ func NewBlockBlockRevolutionWorld(settings BlockBlockRevolutionSettings) BlockBl
 		}
 	}
 
-	qa := NewFiniteActionQueue(1)
+	qa := NewFiniteActionQueue(1, "block-block-revolution")
+
+	random := settings.random()
 
 	bbrw := BlockBlockRevolutionWorld{
 		Container:                    &r,
@@ -63,6 +67,7 @@ func NewBlockBlockRevolutionWorld(settings BlockBlockRevolutionSettings) BlockBl
 		ActionQueuer:                 &qa,
 		grid:                         grid,
 		FrameSpeed:                   5,
+		random:                       random,
 		newBlockFunctions: []func(int, int, BlockInserterAndRemover) (Tetromino, bool){
 			NewSquareTetrominoes,
 			NewLTetrominoes,
@@ -86,7 +91,6 @@ func (bbrw *BlockBlockRevolutionWorld) Update() bool {
 		return false
 	}
 
-	bbrw.FrameTimer.Start()
 	bbrw.Process()
 
 	if bbrw.DownToNextLineCount < 5 {
@@ -103,12 +107,16 @@ func (bbrw *BlockBlockRevolutionWorld) Update() bool {
 		}
 	}
 
-	for bbrw.FrameTimer.GetCurrentElaspedTime() < time.Millisecond*FrameSpeedMultiplier*time.Duration(bbrw.BlockBlockRevolutionSettings.BlockSpeedReduction) {
-	}
+	bbrw.Tick++
 
 	return true
 }
 
+//TickRate Returns the time between each Update
+func (bbrw *BlockBlockRevolutionWorld) TickRate() time.Duration {
+	return time.Millisecond * FrameSpeedMultiplier * time.Duration(bbrw.BlockBlockRevolutionSettings.BlockSpeedReduction)
+}
+
 func (bbrw *BlockBlockRevolutionWorld) Tile(x int, y int) (*BlockBlockRevolutionTile, bool) {
 	if bbrw.Contains(x, y) {
 		return bbrw.grid[x][y], true
@@ -252,7 +260,7 @@ func (bbrw *BlockBlockRevolutionWorld) AddNewBlock() bool {
 
 	if len(bbrw.nextNewBlockFunctions) == 0 {
 		for i := 0; i < 3; i++ {
-			for _, newblockfunc := range rand.Perm(len(bbrw.newBlockFunctions)) {
+			for _, newblockfunc := range bbrw.random.Perm(len(bbrw.newBlockFunctions)) {
 				bbrw.nextNewBlockFunctions = append(bbrw.nextNewBlockFunctions, bbrw.newBlockFunctions[newblockfunc])
 			}
 		}
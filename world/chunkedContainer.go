@@ -0,0 +1,169 @@
+package world
+
+import (
+	"gopherlife/geometry"
+)
+
+//ChunkSize the width and height of each chunk of a ChunkedContainer
+const ChunkSize = 32
+
+//chunk a square of tiles and the number of gophers and food on them
+type chunk struct {
+	tiles    [ChunkSize][ChunkSize]GopherWorldTile
+	entities int
+}
+
+//ChunkedContainer a container with no edges that is split into chunks of tiles. A chunk is only created when a gopher or
+//food is first inserted into it and is freed once the last one is removed, so memory grows with the number of gophers
+//and food instead of the area they cover. Positions can be negative
+type ChunkedContainer struct {
+	chunks map[geometry.Coordinates]*chunk
+}
+
+//NewChunkedContainer Returns an empty ChunkedContainer
+func NewChunkedContainer() ChunkedContainer {
+	return ChunkedContainer{
+		chunks: make(map[geometry.Coordinates]*chunk),
+	}
+}
+
+//Chunks Returns the number of chunks that have been created and not yet freed
+func (container *ChunkedContainer) Chunks() int {
+	return len(container.chunks)
+}
+
+//locate Returns the position of the chunk holding x and y, and the position of x and y inside that chunk
+func (container *ChunkedContainer) locate(x int, y int) (geometry.Coordinates, int, int) {
+	chunkX, chunkY := floorDivide(x, ChunkSize), floorDivide(y, ChunkSize)
+	return geometry.Coordinates{X: chunkX, Y: chunkY}, x - chunkX*ChunkSize, y - chunkY*ChunkSize
+}
+
+//Tile Returns the tile at x and y. Every position has a tile, positions in a chunk that has not been created get an
+//empty tile that is not kept by the container. Use the insert methods to add gophers and food
+func (container *ChunkedContainer) Tile(x int, y int) (*GopherWorldTile, bool) {
+
+	key, i, j := container.locate(x, y)
+
+	if c, ok := container.chunks[key]; ok {
+		return &c.tiles[i][j], true
+	}
+
+	return &GopherWorldTile{}, true
+}
+
+//Contains Returns true, a ChunkedContainer has no edges
+func (container *ChunkedContainer) Contains(x int, y int) bool {
+	return true
+}
+
+//touch Returns the tile at x and y, creating its chunk if it does not exist yet
+func (container *ChunkedContainer) touch(x int, y int) (*chunk, *GopherWorldTile) {
+
+	key, i, j := container.locate(x, y)
+
+	c, ok := container.chunks[key]
+
+	if !ok {
+		c = &chunk{}
+		container.chunks[key] = c
+	}
+
+	return c, &c.tiles[i][j]
+}
+
+//release Counts one less entity in the chunk holding x and y, freeing the chunk once it is empty
+func (container *ChunkedContainer) release(x int, y int) {
+
+	key, _, _ := container.locate(x, y)
+
+	if c, ok := container.chunks[key]; ok {
+		c.entities--
+		if c.entities <= 0 {
+			delete(container.chunks, key)
+		}
+	}
+}
+
+//InsertGopher Inserts the given gopher at x and y, creating the chunk if needed
+func (container *ChunkedContainer) InsertGopher(x int, y int, gopher *Gopher) bool {
+
+	c, tile := container.touch(x, y)
+
+	if tile.HasGopher() {
+		return false
+	}
+
+	gopher.Position.SetXY(x, y)
+	tile.SetGopher(gopher)
+	c.entities++
+	return true
+}
+
+//InsertFood Inserts the given food at x and y, creating the chunk if needed
+func (container *ChunkedContainer) InsertFood(x int, y int, food *Food) bool {
+
+	c, tile := container.touch(x, y)
+
+	if tile.HasFood() {
+		return false
+	}
+
+	food.Position.SetXY(x, y)
+	tile.SetFood(food)
+	c.entities++
+	return true
+}
+
+//RemoveGopher Removes the gopher at x and y, freeing the chunk if it is left empty
+func (container *ChunkedContainer) RemoveGopher(x int, y int) (*Gopher, bool) {
+
+	if gopher, ok := container.HasGopher(x, y); ok {
+		tile, _ := container.Tile(x, y)
+		tile.ClearGopher()
+		container.release(x, y)
+		return gopher, true
+	}
+
+	return nil, false
+}
+
+//RemoveFood Removes the food at x and y, freeing the chunk if it is left empty
+func (container *ChunkedContainer) RemoveFood(x int, y int) (*Food, bool) {
+
+	if food, ok := container.HasFood(x, y); ok {
+		tile, _ := container.Tile(x, y)
+		tile.ClearFood()
+		container.release(x, y)
+		return food, true
+	}
+
+	return nil, false
+}
+
+//HasGopher Returns the gopher at x and y, if there is one
+func (container *ChunkedContainer) HasGopher(x int, y int) (*Gopher, bool) {
+
+	if tile, ok := container.Tile(x, y); ok && tile.HasGopher() {
+		return tile.Gopher, true
+	}
+
+	return nil, false
+}
+
+//HasFood Returns the food at x and y, if there is one
+func (container *ChunkedContainer) HasFood(x int, y int) (*Food, bool) {
+
+	if tile, ok := container.Tile(x, y); ok && tile.HasFood() {
+		return tile.Food, true
+	}
+
+	return nil, false
+}
+
+//floorDivide Divides a by n rounding down, even for negative a
+func floorDivide(a int, n int) int {
+	if a < 0 {
+		return -((-a + n - 1) / n)
+	}
+	return a / n
+}
@@ -0,0 +1,109 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"testing"
+)
+
+func TestChunkedContainer_InsertAndRemove(t *testing.T) {
+
+	container := NewChunkedContainer()
+
+	if _, ok := container.Tile(-1000, 5000); !ok {
+		t.Errorf("ChunkedContainer.Tile() should have a tile at every position")
+	}
+
+	if container.Chunks() != 0 {
+		t.Errorf("ChunkedContainer.Chunks() = %d after reading a tile, want 0", container.Chunks())
+	}
+
+	gopher := &Gopher{}
+	food := &Food{}
+
+	if !container.InsertGopher(-1, -1, gopher) || !container.InsertFood(-32, -32, food) {
+		t.Fatalf("ChunkedContainer.Insert at a negative position failed")
+	}
+
+	if container.InsertGopher(-1, -1, &Gopher{}) {
+		t.Errorf("ChunkedContainer.InsertGopher() should not insert onto another gopher")
+	}
+
+	if got, ok := container.HasGopher(-1, -1); !ok || got != gopher || gopher.Position != geometry.NewCoordinate(-1, -1) {
+		t.Errorf("ChunkedContainer.HasGopher() = %v, %v want %v", got, ok, gopher)
+	}
+
+	if _, ok := container.HasGopher(31, 31); ok {
+		t.Errorf("ChunkedContainer.HasGopher() found a gopher in the wrong chunk")
+	}
+
+	if container.Chunks() != 1 {
+		t.Errorf("ChunkedContainer.Chunks() = %d, want 1", container.Chunks())
+	}
+
+	container.InsertFood(1000000, 1000000, &Food{})
+
+	if container.Chunks() != 2 {
+		t.Errorf("ChunkedContainer.Chunks() = %d, want 2", container.Chunks())
+	}
+
+	container.RemoveGopher(-1, -1)
+
+	if container.Chunks() != 2 {
+		t.Errorf("ChunkedContainer.Chunks() = %d after removing one of two entities, want 2", container.Chunks())
+	}
+
+	container.RemoveFood(-32, -32)
+	container.RemoveFood(1000000, 1000000)
+
+	if container.Chunks() != 0 {
+		t.Errorf("ChunkedContainer.Chunks() = %d after removing everything, want 0", container.Chunks())
+	}
+}
+
+func TestFloorDivide(t *testing.T) {
+
+	tests := []struct {
+		a, n, want int
+	}{
+		{0, 32, 0},
+		{31, 32, 0},
+		{32, 32, 1},
+		{-1, 32, -1},
+		{-32, 32, -1},
+		{-33, 32, -2},
+	}
+	for _, tt := range tests {
+		if got := floorDivide(tt.a, tt.n); got != tt.want {
+			t.Errorf("floorDivide(%d, %d) = %d, want %d", tt.a, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestCreateGopherWorldInfinite_MoveGopher(t *testing.T) {
+
+	settings := GopherWorldSettings{
+		Dimensions:      Dimensions{10, 10},
+		Population:      Population{0, 100},
+		NumberOfFood:    0,
+		GopherBirthRate: 7,
+	}
+
+	gw := CreateGopherWorldInfinite(settings)
+
+	gopher := NewGopher("a", geometry.NewCoordinate(0, 0))
+	gw.InsertGopher(0, 0, &gopher)
+
+	for i := 0; i < 100; i++ {
+		if !gw.MoveGopher(&gopher, -1, 0) {
+			t.Fatalf("GopherWorld.MoveGopher() failed at %v", gopher.Position)
+		}
+	}
+
+	if _, ok := gw.HasGopher(-100, 0); !ok {
+		t.Errorf("GopherWorld.HasGopher() should find the gopher at (-100, 0), it is at %v", gopher.Position)
+	}
+
+	if chunks := gw.TileContainer.(*ChunkedContainer).Chunks(); chunks != 1 {
+		t.Errorf("ChunkedContainer.Chunks() = %d, chunks behind the gopher should have been freed", chunks)
+	}
+}
@@ -0,0 +1,219 @@
+package world
+
+import (
+	"bufio"
+	"fmt"
+	"gopherlife/geometry"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//collisionLayoutComment lines starting with this are ignored
+const collisionLayoutComment = ";"
+
+//collisionLayoutBounds a shape starting with this is part of the bounds rather than an obstacle
+const collisionLayoutBounds = "bounds"
+
+//CollisionLayout The bounds and static obstacles of a CollisionWorld
+type CollisionLayout struct {
+	Name string
+	//Bounds Colliders can only be inside the Bounds. If nil the whole world is used
+	Bounds    geometry.Shape
+	Obstacles []geometry.Shape
+	//SplitX the column that divides the left side of the world from the right when counting Colliders. 0 is the middle of the world
+	SplitX int
+}
+
+//NewSlitLayout Returns a CollisionLayout with a wall down the middle of the world with a gap of the given size in its centre
+func NewSlitLayout(d Dimensions, gap int) CollisionLayout {
+	layout := CollisionLayout{Name: "slit"}
+	layout.addDivider(d, d.Width/2, gap)
+	return layout
+}
+
+//addDivider Adds a wall down the given column with a gap in its centre and splits the world at the wall
+func (layout *CollisionLayout) addDivider(d Dimensions, x int, gap int) {
+
+	gapStart := (d.Height - gap) / 2
+	gapEnd := gapStart + gap
+
+	below := geometry.NewRectangle(x, 0, 1, gapStart)
+	above := geometry.NewRectangle(x, gapEnd, 1, d.Height-gapEnd)
+
+	layout.Obstacles = append(layout.Obstacles, &below, &above)
+	layout.SplitX = x
+}
+
+//LoadCollisionLayout Reads a CollisionLayout from the given file. The layout is named after the file
+func LoadCollisionLayout(path string, d Dimensions) (CollisionLayout, error) {
+
+	file, err := os.Open(path)
+
+	if err != nil {
+		return CollisionLayout{}, err
+	}
+
+	defer file.Close()
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	return ParseCollisionLayout(name, file, d)
+}
+
+//ParseCollisionLayout Reads a CollisionLayout from a text file with one shape per line. Numbers are in tiles.
+//
+//	rectangle x y width height
+//	circle x y radius
+//	line x1 y1 x2 y2
+//	polygon x1 y1 x2 y2 x3 y3 ...
+//	divider x gap
+//	divider gap
+//
+//Shapes are obstacles unless the line starts with 'bounds', for example 'bounds circle 37 37 36'. If there
+//is more than one bounds shape Colliders can be in any of them. A divider is a wall down column x with a gap in the
+//middle, Colliders are counted on either side of it. Without an x the divider is in the middle of the world. Blank lines and lines starting with ';' are ignored
+func ParseCollisionLayout(name string, r io.Reader, d Dimensions) (CollisionLayout, error) {
+
+	layout := CollisionLayout{Name: name}
+	bounds := geometry.Union{}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+
+	for scanner.Scan() {
+
+		lineNumber++
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) == 0 || strings.HasPrefix(fields[0], collisionLayoutComment) {
+			continue
+		}
+
+		isBounds := fields[0] == collisionLayoutBounds
+		if isBounds {
+			fields = fields[1:]
+		}
+
+		if len(fields) == 0 {
+			return CollisionLayout{}, fmt.Errorf("collision layout %q line %d: missing shape", name, lineNumber)
+		}
+
+		numbers := make([]int, len(fields)-1)
+
+		for i, field := range fields[1:] {
+			number, err := strconv.Atoi(field)
+			if err != nil {
+				return CollisionLayout{}, fmt.Errorf("collision layout %q line %d: %q is not a number", name, lineNumber, field)
+			}
+			numbers[i] = number
+		}
+
+		if fields[0] == "divider" {
+			if isBounds || len(numbers) < 1 || len(numbers) > 2 {
+				return CollisionLayout{}, fmt.Errorf("collision layout %q line %d: divider needs a gap and an optional x", name, lineNumber)
+			}
+			if len(numbers) == 1 {
+				numbers = []int{d.Width / 2, numbers[0]}
+			}
+			layout.addDivider(d, numbers[0], numbers[1])
+			continue
+		}
+
+		shape, err := parseLayoutShape(fields[0], numbers)
+
+		if err != nil {
+			return CollisionLayout{}, fmt.Errorf("collision layout %q line %d: %v", name, lineNumber, err)
+		}
+
+		if isBounds {
+			bounds = append(bounds, shape)
+		} else {
+			layout.Obstacles = append(layout.Obstacles, shape)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return CollisionLayout{}, err
+	}
+
+	if len(bounds) > 0 {
+		layout.Bounds = bounds
+	}
+
+	return layout, nil
+}
+
+func parseLayoutShape(shape string, numbers []int) (geometry.Shape, error) {
+
+	switch shape {
+	case "rectangle":
+		if len(numbers) == 4 {
+			rect := geometry.NewRectangle(numbers[0], numbers[1], numbers[2], numbers[3])
+			return &rect, nil
+		}
+		return nil, fmt.Errorf("rectangle needs x, y, width and height")
+	case "circle":
+		if len(numbers) == 3 {
+			return geometry.NewCircle(numbers[0], numbers[1], float64(numbers[2])), nil
+		}
+		return nil, fmt.Errorf("circle needs x, y and radius")
+	case "line":
+		if len(numbers) == 4 {
+			return geometry.NewLine(numbers[0], numbers[1], numbers[2], numbers[3]), nil
+		}
+		return nil, fmt.Errorf("line needs x1, y1, x2 and y2")
+	case "polygon":
+		if len(numbers) >= 6 && len(numbers)%2 == 0 {
+			points := make([]geometry.Coordinates, 0, len(numbers)/2)
+			for i := 0; i < len(numbers); i += 2 {
+				points = append(points, geometry.NewCoordinate(numbers[i], numbers[i+1]))
+			}
+			return geometry.NewPolygon(points...), nil
+		}
+		return nil, fmt.Errorf("polygon needs at least three x and y pairs")
+	default:
+		return nil, fmt.Errorf("unknown shape %q", shape)
+	}
+}
+
+//maxSideCounts the number of ticks of SideCounts a CollisionWorld keeps
+const maxSideCounts = 1000
+
+//SideCount the number of Colliders on either side of the world's split on a tick
+type SideCount struct {
+	Tick  int
+	Left  int
+	Right int
+}
+
+//SplitX Returns the column dividing the left of the world from the right. Colliders in the column are on the right
+func (collisionMap *CollisionWorld) SplitX() int {
+	if collisionMap.Layout != nil && collisionMap.Layout.SplitX > 0 {
+		return collisionMap.Layout.SplitX
+	}
+	return collisionMap.Width / 2
+}
+
+//countSides Records how many Colliders are on each side of the split, keeping the last maxSideCounts ticks
+func (collisionMap *CollisionWorld) countSides() {
+
+	count := SideCount{Tick: collisionMap.Tick}
+	splitX := collisionMap.SplitX()
+
+	collisionMap.eachCollider(func(c *Collider) {
+		if c.X < splitX {
+			count.Left++
+		} else {
+			count.Right++
+		}
+	})
+
+	if len(collisionMap.SideCounts) >= maxSideCounts {
+		collisionMap.SideCounts = collisionMap.SideCounts[1:]
+	}
+
+	collisionMap.SideCounts = append(collisionMap.SideCounts, count)
+}
@@ -0,0 +1,225 @@
+package world
+
+import (
+	"fmt"
+	"gopherlife/colors"
+	"gopherlife/geometry"
+	"image/color"
+	"math"
+)
+
+//ConservationTolerance the largest error in momentum or energy allowed per tick before a ConservationReport fails
+const ConservationTolerance = 1e-9
+
+//speedColors Elastic Colliders are coloured by speed from slowest to fastest, twice the reference speed or faster is the last color
+var speedColors = []color.RGBA{colors.Blue, colors.Cyan, colors.Green, colors.Yellow, colors.Orange, colors.Red}
+
+//contactNormals half of the neighbouring tiles. Checking these from every Collider visits each neighbouring pair once
+var contactNormals = []geometry.Coordinates{{X: 1, Y: -1}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}}
+
+//ConservationReport the total momentum and kinetic energy of every Collider before and after a tick.
+//Collisions between Colliders conserve both, the boundary and obstacles only conserve energy so the impulse they give is recorded
+type ConservationReport struct {
+	Tick           int
+	MomentumBefore geometry.Vector
+	MomentumAfter  geometry.Vector
+	WallImpulse    geometry.Vector
+	EnergyBefore   float64
+	EnergyAfter    float64
+	Collisions     int
+}
+
+//MomentumError Returns how far the momentum after the tick is from the momentum before plus the impulse from the boundary
+func (report ConservationReport) MomentumError() float64 {
+	return report.MomentumAfter.Sub(report.MomentumBefore.Add(report.WallImpulse)).Length()
+}
+
+//EnergyError Returns how far the kinetic energy after the tick is from the kinetic energy before
+func (report ConservationReport) EnergyError() float64 {
+	return math.Abs(report.EnergyAfter - report.EnergyBefore)
+}
+
+//Check Returns an error if momentum or energy was not conserved within the given tolerance
+func (report ConservationReport) Check(tolerance float64) error {
+
+	if err := report.MomentumError(); err > tolerance {
+		return fmt.Errorf("tick %d: momentum changed by %g", report.Tick, err)
+	}
+
+	if err := report.EnergyError(); err > tolerance {
+		return fmt.Errorf("tick %d: energy changed by %g", report.Tick, err)
+	}
+
+	return nil
+}
+
+//Momentum Returns the Collider's mass multiplied by its velocity
+func (collider *Collider) Momentum() geometry.Vector {
+	return collider.Velocity.Scale(collider.Mass)
+}
+
+//KineticEnergy Returns half the Collider's mass multiplied by its speed squared
+func (collider *Collider) KineticEnergy() float64 {
+	return 0.5 * collider.Mass * collider.Velocity.Dot(collider.Velocity)
+}
+
+//updateSpeedColor Colors the Collider by its speed compared to the reference speed so hot and cold areas of the gas can be seen
+func (collider *Collider) updateSpeedColor(referenceSpeed float64) {
+
+	i := len(speedColors) - 1
+
+	if referenceSpeed > 0 {
+		i = int(collider.Velocity.Length() / (2 * referenceSpeed) * float64(len(speedColors)))
+	}
+
+	if i > len(speedColors)-1 {
+		i = len(speedColors) - 1
+	}
+
+	collider.Color = speedColors[i]
+}
+
+//ResolveElasticCollision Exchanges momentum between two Colliders along the contact normal, which points from c1 to c2.
+//Nothing happens if the Colliders are moving apart. Returns true if the Colliders collided
+func ResolveElasticCollision(c1 *Collider, c2 *Collider, normal geometry.Vector) bool {
+
+	normal = normal.Normalize()
+	approachSpeed := c1.Velocity.Sub(c2.Velocity).Dot(normal)
+
+	if approachSpeed <= 0 || c1.Mass+c2.Mass <= 0 {
+		return false
+	}
+
+	impulse := 2 * c1.Mass * c2.Mass / (c1.Mass + c2.Mass) * approachSpeed
+
+	c1.Velocity = c1.Velocity.Sub(normal.Scale(impulse / c1.Mass))
+	c2.Velocity = c2.Velocity.Add(normal.Scale(impulse / c2.Mass))
+
+	return true
+}
+
+//Momentum Returns the total momentum of every Collider in the world
+func (collisionMap *CollisionWorld) Momentum() geometry.Vector {
+
+	var total geometry.Vector
+	collisionMap.eachCollider(func(c *Collider) {
+		total = total.Add(c.Momentum())
+	})
+	return total
+}
+
+//KineticEnergy Returns the total kinetic energy of every Collider in the world
+func (collisionMap *CollisionWorld) KineticEnergy() float64 {
+
+	total := 0.0
+	collisionMap.eachCollider(func(c *Collider) {
+		total += c.KineticEnergy()
+	})
+	return total
+}
+
+func (collisionMap *CollisionWorld) eachCollider(f func(c *Collider)) {
+	for _, column := range collisionMap.grid {
+		for _, tile := range column {
+			if tile.HasCollider() {
+				f(tile.c)
+			}
+		}
+	}
+}
+
+//updateElastic Runs one tick of the elastic physics mode. Unlike the default mode this runs on a single goroutine,
+//each collision changes the velocity of both Colliders so they have to be resolved one pair at a time
+func (collisionMap *CollisionWorld) updateElastic() bool {
+
+	colliders := make([]*Collider, 0, len(collisionMap.ActiveColliders))
+
+	for len(collisionMap.ActiveColliders) > 0 {
+		colliders = append(colliders, <-collisionMap.ActiveColliders)
+	}
+
+	report := ConservationReport{
+		Tick:           collisionMap.Tick,
+		MomentumBefore: collisionMap.Momentum(),
+		EnergyBefore:   collisionMap.KineticEnergy(),
+	}
+
+	for _, c := range colliders {
+		for _, normal := range contactNormals {
+			if other, ok := collisionMap.HasCollider(c.X+normal.X, c.Y+normal.Y); ok {
+				if ResolveElasticCollision(c, other, normal.ToVector()) {
+					report.Collisions++
+				}
+			}
+		}
+	}
+
+	for _, c := range colliders {
+		report.WallImpulse = report.WallImpulse.Add(collisionMap.moveElasticCollider(c))
+		c.updateSpeedColor(collisionMap.referenceSpeed())
+		collisionMap.ActiveColliders <- c
+	}
+
+	report.MomentumAfter = collisionMap.Momentum()
+	report.EnergyAfter = collisionMap.KineticEnergy()
+
+	collisionMap.LastReport = report
+	collisionMap.Tick++
+	collisionMap.countSides()
+
+	return true
+}
+
+//moveElasticCollider Moves the Collider by its velocity, stopping next to anything in the way. The Collider's exact
+//position is kept so that speeds of less than a tile per tick still move it. Colliders that stop against the edge of
+//the world or an obstacle bounce off it, the impulse the bounce gave the Collider is returned
+func (collisionMap *CollisionWorld) moveElasticCollider(c *Collider) geometry.Vector {
+
+	destination, blockedBy, blocked := collisionMap.sweep(c, c.Velocity)
+	tile := destination.Round()
+
+	if tile.X != c.X || tile.Y != c.Y {
+		oldTile := collisionMap.grid[c.X][c.Y]
+		collisionMap.grid[tile.X][tile.Y].Insert(c)
+		oldTile.Clear()
+	}
+
+	c.Position = destination
+
+	//Colliders in the way are collided with on the next tick, once they are next to each other
+	if !blocked || collisionMap.isOpen(blockedBy.X, blockedBy.Y) {
+		return geometry.Vector{}
+	}
+
+	return collisionMap.reflect(c, blockedBy)
+}
+
+//reflect Bounces the Collider off the wall at the given tile, which is next to it. The velocity is reversed along
+//each axis where the wall is. If the wall is only diagonally next to the Collider it bounces straight back off the corner
+func (collisionMap *CollisionWorld) reflect(c *Collider, wall geometry.Coordinates) geometry.Vector {
+
+	before := c.Momentum()
+	dx, dy := collisionMap.surface.Difference(c.Coordinates, wall)
+
+	flipX := dx != 0 && !collisionMap.isOpen(c.X+dx, c.Y)
+	flipY := dy != 0 && !collisionMap.isOpen(c.X, c.Y+dy)
+
+	if !flipX && !flipY {
+		flipX, flipY = dx != 0, dy != 0
+	}
+
+	if flipX {
+		c.Velocity.X = -c.Velocity.X
+	}
+
+	if flipY {
+		c.Velocity.Y = -c.Velocity.Y
+	}
+
+	return c.Momentum().Sub(before)
+}
+
+//referenceSpeed Returns the speed of a Collider with a mass of 1 at the world's Temperature
+func (collisionMap *CollisionWorld) referenceSpeed() float64 {
+	return ConstantSpeed.Speed(collisionMap.Temperature, 1)
+}
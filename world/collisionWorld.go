@@ -4,38 +4,61 @@ import (
 	"gopherlife/colors"
 	"gopherlife/geometry"
 	"image/color"
+	"math"
 	"math/rand"
 	"sync"
 )
 
-var collisionMapSpeed = 1
-
 var colliderColors = []color.RGBA{colors.Red, colors.Blue, colors.Cyan, colors.Pink, colors.Yellow, colors.White}
 
 type CollisionWorldSettings struct {
 	Dimensions
 	Population
 	IsDiagonal bool
+
+	//IsElastic Colliders have a mass and a velocity vector and exchange momentum when they collide
+	IsElastic bool
+	//MaxMass elastic Colliders are given a random whole number mass between 1 and MaxMass
+	MaxMass int
+
+	//Temperature the average kinetic energy of a Collider. A Collider with a mass of 1 moves one tile per tick at a Temperature of 0.5
+	Temperature float64
+	//SpeedDistribution how the starting speed of each Collider is chosen for the Temperature
+	SpeedDistribution SpeedDistribution
+
+	//Layout the bounds and obstacles of the world, if nil the world is an empty rectangle
+	Layout *CollisionLayout
+	//StartOnLeft Colliders are only placed on the left side of the world, for diffusion experiments
+	StartOnLeft bool
+
+	//Topology how the edges of the world are joined together, Colliders leaving a joined edge come back on the opposite edge
+	Topology geometry.Topology
 }
 
 type CollisionWorld struct {
-	grid [][]*ColliderTile
+	grid    [][]*ColliderTile
+	surface *geometry.Surface
 	Container
 	ActionQueuer
 	CollisionWorldSettings
 	*sync.WaitGroup
 
 	ActiveColliders chan *Collider
+
+	Tick       int
+	LastReport ConservationReport
+	SideCounts []SideCount
 }
 
 type ColliderTile struct {
 	geometry.Coordinates
-	c *Collider
+	c          *Collider
+	IsObstacle bool
 }
 
 //Insert Adds the Collider to the Tile, if Empty
 func (tile *ColliderTile) Insert(c *Collider) bool {
-	if tile.c == nil {
+	if tile.c == nil && !tile.IsObstacle {
 		c.X = tile.X
 		c.Y = tile.Y
 		tile.c = c
@@ -54,18 +77,24 @@ func (tile *ColliderTile) Clear() {
 	tile.c = nil
 }
 
-//NewEmptyCollisionWorld Creates an Empty Collision Map
-func NewEmptyCollisionWorld(settings CollisionWorldSettings) CollisionWorld {
+//NewEmptyCollisionWorld Creates an Empty Collision Map whose metrics are kept under the name
+func NewEmptyCollisionWorld(name string, settings CollisionWorldSettings) CollisionWorld {
 
-	qa := NewFiniteActionQueue(settings.InitialPopulation * 2)
+	qa := NewFiniteActionQueue(settings.InitialPopulation*2, name)
 	var wg sync.WaitGroup
 
-	rect := geometry.NewRectangle(0, 0, settings.Width, settings.Height)
+	surface := geometry.NewSurface(0, 0, settings.Width, settings.Height, settings.Topology)
+
+	var container Container = &surface
+	if settings.Layout != nil && settings.Layout.Bounds != nil {
+		container = geometry.Intersection{&surface, settings.Layout.Bounds}
+	}
 
 	collisionMap := CollisionWorld{
+		surface:                &surface,
 		ActionQueuer:           &qa,
 		WaitGroup:              &wg,
-		Container:              &rect,
+		Container:              container,
 		ActiveColliders:        make(chan *Collider, settings.InitialPopulation*2),
 		CollisionWorldSettings: settings,
 	}
@@ -82,6 +111,11 @@ func NewEmptyCollisionWorld(settings CollisionWorldSettings) CollisionWorld {
 					Y: j,
 				},
 			}
+
+			if settings.Layout != nil {
+				tile.IsObstacle = geometry.Union(settings.Layout.Obstacles).Contains(i, j)
+			}
+
 			collisionMap.grid[i][j] = &tile
 		}
 	}
@@ -89,52 +123,63 @@ func NewEmptyCollisionWorld(settings CollisionWorldSettings) CollisionWorld {
 	return collisionMap
 }
 
-//NewCollisionWorld Creates a Populated Collision Map
-func NewCollisionWorld(settings CollisionWorldSettings) CollisionWorld {
+//NewCollisionWorld Creates a Populated Collision Map whose metrics are kept under the name
+func NewCollisionWorld(name string, settings CollisionWorldSettings) CollisionWorld {
 
-	collisionMap := NewEmptyCollisionWorld(settings)
+	collisionMap := NewEmptyCollisionWorld(name, settings)
 
 	keys := geometry.GenerateRandomizedCoordinateArray(0, 0,
 		settings.Width, settings.Height)
 
 	count := 0
 
-	for i := 0; i < settings.InitialPopulation; i++ {
+	for _, pos := range keys {
 
-		var velX, velY int
+		if count >= settings.InitialPopulation {
+			break
+		}
 
-		if collisionMap.IsDiagonal {
-			velX = getNegativeOrPositiveSpeed(collisionMapSpeed)
-			velY = getNegativeOrPositiveSpeed(collisionMapSpeed)
-		} else {
-			if rand.Intn(2) == 0 {
-				velX = getNegativeOrPositiveSpeed(collisionMapSpeed)
-			} else {
-				velY = getNegativeOrPositiveSpeed(collisionMapSpeed)
-			}
+		if !collisionMap.isOpen(pos.GetX(), pos.GetY()) || (settings.StartOnLeft && pos.GetX() >= collisionMap.SplitX()) {
+			continue
+		}
 
+		mass := 1.0
+		if collisionMap.IsElastic && settings.MaxMass > 1 {
+			mass += float64(rand.Intn(settings.MaxMass))
 		}
 
-		pos := keys[count]
+		speed := settings.SpeedDistribution.Speed(settings.Temperature, mass)
+
 		var c = Collider{
-			velX:                 velX,
-			velY:                 velY,
 			ColliderWorldActions: &collisionMap,
 			IsDiagonal:           collisionMap.IsDiagonal,
+			Mass:                 mass,
+			Velocity:             collisionMap.randomDirection().Scale(speed),
 		}
 
 		collisionMap.InsertCollider(pos.GetX(), pos.GetY(), &c)
+
+		if collisionMap.IsElastic {
+			c.updateSpeedColor(collisionMap.referenceSpeed())
+		}
+
 		collisionMap.ActiveColliders <- &c
 
 		count++
 	}
 
+	collisionMap.countSides()
+
 	return collisionMap
 }
 
 //Update all Active Colliders inside the Map
 func (collisionMap *CollisionWorld) Update() bool {
 
+	if collisionMap.IsElastic {
+		return collisionMap.updateElastic()
+	}
+
 	numColliders := len(collisionMap.ActiveColliders)
 
 	for i := 0; i < numColliders; i++ {
@@ -149,12 +194,14 @@ func (collisionMap *CollisionWorld) Update() bool {
 
 	collisionMap.WaitGroup.Wait()
 	collisionMap.Process()
+	collisionMap.Tick++
+	collisionMap.countSides()
 
 	return true
 
 }
 
-func getNegativeOrPositiveSpeed(speed int) int {
+func getNegativeOrPositiveSpeed(speed float64) float64 {
 	if rand.Intn(2) == 0 {
 		return speed
 	} else {
@@ -162,15 +209,32 @@ func getNegativeOrPositiveSpeed(speed int) int {
 	}
 }
 
+//randomDirection Returns a Vector in a random direction. Diagonal worlds only use diagonal directions, moving a whole
+//tile along both axes each tick, other worlds move one tile along the axes unless they are elastic
+func (collisionMap *CollisionWorld) randomDirection() geometry.Vector {
+
+	switch {
+	case collisionMap.IsDiagonal:
+		return geometry.Vector{X: getNegativeOrPositiveSpeed(1), Y: getNegativeOrPositiveSpeed(1)}
+	case collisionMap.IsElastic:
+		return geometry.VectorFromAngle(rand.Float64()*2*math.Pi, 1)
+	case rand.Intn(2) == 0:
+		return geometry.Vector{X: getNegativeOrPositiveSpeed(1)}
+	default:
+		return geometry.Vector{Y: getNegativeOrPositiveSpeed(1)}
+	}
+}
+
 type ColliderWorldActions interface {
-	MoveCollider(moveX int, moveY int, c *Collider) bool
+	MoveCollider(velocity geometry.Vector, c *Collider) bool
 }
 
 //InsertCollider Sets x and y of Collider and places it into map
 func (collisionMap *CollisionWorld) InsertCollider(x int, y int, c *Collider) bool {
 
-	if collisionMap.Contains(x, y) {
-		return collisionMap.grid[x][y].Insert(c)
+	if tile, ok := collisionMap.Tile(x, y); ok && tile.Insert(c) {
+		c.Position = c.Coordinates.ToVector()
+		return true
 	}
 
 	return false
@@ -179,39 +243,103 @@ func (collisionMap *CollisionWorld) InsertCollider(x int, y int, c *Collider) bo
 //HasCollider Checks if a colliders exists at X and Y and returns the Collider
 func (collisionMap *CollisionWorld) HasCollider(x int, y int) (*Collider, bool) {
 
-	if collisionMap.Contains(x, y) {
-		tile := collisionMap.grid[x][y]
+	if tile, ok := collisionMap.Tile(x, y); ok && tile.HasCollider() {
+		return tile.c, true
+	}
 
-		if tile.HasCollider() {
-			return tile.c, true
-		}
+	return nil, false
+}
+
+//Tile Returns the tile at x and y, across any joined edges. Returns false if x and y are outside the world's bounds
+func (collisionMap *CollisionWorld) Tile(x int, y int) (*ColliderTile, bool) {
+
+	x, y = collisionMap.wrap(x, y)
+
+	if collisionMap.Contains(x, y) {
+		return collisionMap.grid[x][y], true
 	}
 
 	return nil, false
 }
 
-func (collisionMap *CollisionWorld) MoveCollider(moveX int, moveY int, c *Collider) bool {
+//Surface Returns how the edges of the world are joined together
+func (collisionMap *CollisionWorld) Surface() *geometry.Surface {
+	return collisionMap.surface
+}
+
+//wrap Returns x and y moved across any joined edges of the world
+func (collisionMap *CollisionWorld) wrap(x int, y int) (int, int) {
+	x, y, _ = collisionMap.surface.Wrap(x, y)
+	return x, y
+}
+
+//MoveCollider Moves the Collider along the velocity as far as it can go before it reaches another Collider or the
+//edge of the map. Returns false if the Collider was stopped
+func (collisionMap *CollisionWorld) MoveCollider(velocity geometry.Vector, c *Collider) bool {
+
+	destination, _, blocked := collisionMap.sweep(c, velocity)
+	tile := destination.Round()
+
+	if tile.X == c.X && tile.Y == c.Y {
+		c.Position = destination
+		return !blocked
+	}
+
+	oldTile := collisionMap.grid[c.X][c.Y]
+	newTile := collisionMap.grid[tile.X][tile.Y]
+
+	collisionMap.ActionQueuer.Add(func() {
+		if newTile.Insert(c) {
+			oldTile.Clear()
+			c.Position = destination
+		}
+	})
+
+	return !blocked
+}
+
+//sweep Steps the Collider along the velocity, at most half a tile at a time so that no tile on the way is skipped.
+//Returns the furthest position reached before another Collider, an obstacle or the edge of the map. If the Collider
+//was stopped the tile that stopped it is returned with true
+func (collisionMap *CollisionWorld) sweep(c *Collider, velocity geometry.Vector) (geometry.Vector, geometry.Coordinates, bool) {
+
+	steps := int(math.Ceil(math.Max(math.Abs(velocity.X), math.Abs(velocity.Y)) * 2))
 
-	newX, newY := c.X+moveX, c.Y+moveY
+	reached := c.Position
+	reachedTile := c.Coordinates
 
-	if collisionMap.Contains(newX, newY) {
+	for i := 1; i <= steps; i++ {
 
-		oldTile := collisionMap.grid[c.X][c.Y]
-		newTile := collisionMap.grid[newX][newY]
+		next := c.Position.Add(velocity.Scale(float64(i) / float64(steps)))
+		tile := next.Round()
 
-		if !newTile.HasCollider() {
+		//Positions past a joined edge are moved the same distance in from the opposite edge
+		x, y := collisionMap.wrap(tile.X, tile.Y)
+		next = next.Add(geometry.Vector{X: float64(x - tile.X), Y: float64(y - tile.Y)})
+		tile = geometry.Coordinates{X: x, Y: y}
 
-			collisionMap.ActionQueuer.Add(func() {
-				if newTile.Insert(c) {
-					oldTile.Clear()
-				}
-			})
-			return true
+		if tile != reachedTile {
+			if !collisionMap.isOpen(tile.X, tile.Y) || collisionMap.grid[tile.X][tile.Y].HasCollider() {
+				return reached, tile, true
+			}
 		}
+
+		reached, reachedTile = next, tile
 	}
 
-	return false
+	return reached, reachedTile, false
+}
+
+//HasObstacle Returns true if there is an obstacle at x and y
+func (collisionMap *CollisionWorld) HasObstacle(x int, y int) bool {
+	tile, ok := collisionMap.Tile(x, y)
+	return ok && tile.IsObstacle
+}
 
+//isOpen Returns true if x and y are inside the world's bounds and not an obstacle
+func (collisionMap *CollisionWorld) isOpen(x int, y int) bool {
+	tile, ok := collisionMap.Tile(x, y)
+	return ok && !tile.IsObstacle
 }
 
 type Collider struct {
@@ -221,20 +349,24 @@ type Collider struct {
 
 	IsDiagonal bool
 
-	velX           int
-	velY           int
+	//Position is the Collider's exact position, the Collider is in the tile Position rounds to.
+	//Velocity is how far it moves each tick
+	Mass     float64
+	Velocity geometry.Vector
+	Position geometry.Vector
+
 	colorSelection int
 }
 
 func (collider *Collider) Update() {
 
-	if !collider.MoveCollider(collider.velX, collider.velY, collider) {
+	if !collider.MoveCollider(collider.Velocity, collider) {
 		collider.ChangeDirection()
 		collider.ChangeColor()
-		collider.MoveCollider(collider.velX, collider.velY, collider)
 	}
 }
 
+//ChangeDirection Randomly reverses or turns the Collider, keeping its speed
 func (collider *Collider) ChangeDirection() {
 
 	if collider.IsDiagonal {
@@ -242,23 +374,25 @@ func (collider *Collider) ChangeDirection() {
 		i := rand.Intn(3)
 
 		if i == 0 || i == 2 {
-			collider.velX = -1 * collider.velX
+			collider.Velocity.X = -1 * collider.Velocity.X
 		}
 
 		if i == 1 || i == 2 {
-			collider.velY = -1 * collider.velY
+			collider.Velocity.Y = -1 * collider.Velocity.Y
 		}
 
 	} else {
 
-		if geometry.Abs(collider.velX) > 0 {
+		speed := collider.Velocity.Length()
+
+		if collider.Velocity.X != 0 {
+
 			i := rand.Intn(2)
 
 			if i == 0 {
-				collider.velX = -1 * collider.velX
+				collider.Velocity.X = -1 * collider.Velocity.X
 			} else {
-				collider.velX = 0
-				collider.velY = getNegativeOrPositiveSpeed(collisionMapSpeed)
+				collider.Velocity = geometry.Vector{Y: getNegativeOrPositiveSpeed(speed)}
 			}
 
 		} else {
@@ -266,10 +400,9 @@ func (collider *Collider) ChangeDirection() {
 			i := rand.Intn(2)
 
 			if i == 0 {
-				collider.velY = -1 * collider.velY
+				collider.Velocity.Y = -1 * collider.Velocity.Y
 			} else {
-				collider.velY = 0
-				collider.velX = getNegativeOrPositiveSpeed(collisionMapSpeed)
+				collider.Velocity = geometry.Vector{X: getNegativeOrPositiveSpeed(speed)}
 			}
 
 		}
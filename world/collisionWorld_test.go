@@ -1,8 +1,11 @@
 package world
 
 import (
+	"gopherlife/geometry"
 	"image/color"
+	"math"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -10,7 +13,7 @@ func TestCollisionWorld_InsertCollider(t *testing.T) {
 
 	width := 100
 	height := 100
-	collisionMap := NewEmptyCollisionWorld(CollisionWorldSettings{
+	collisionMap := NewEmptyCollisionWorld("collision", CollisionWorldSettings{
 		Dimensions: Dimensions{Width: width, Height: height},
 		IsDiagonal: false},
 	)
@@ -52,7 +55,7 @@ func TestCollisionWorld_HasCollider(t *testing.T) {
 
 	width := 5
 	height := 5
-	collisionMap := NewEmptyCollisionWorld(CollisionWorldSettings{
+	collisionMap := NewEmptyCollisionWorld("collision", CollisionWorldSettings{
 		Dimensions: Dimensions{Width: width, Height: height},
 		IsDiagonal: false},
 	)
@@ -91,9 +94,8 @@ func TestCollisionWorld_HasCollider(t *testing.T) {
 
 func TestCollisionWorld_MoveCollider(t *testing.T) {
 	type args struct {
-		moveX int
-		moveY int
-		c     *Collider
+		velocity geometry.Vector
+		c        *Collider
 	}
 	type expected struct {
 		x          int
@@ -103,7 +105,7 @@ func TestCollisionWorld_MoveCollider(t *testing.T) {
 
 	width := 5
 	height := 5
-	collisionMap := NewEmptyCollisionWorld(CollisionWorldSettings{
+	collisionMap := NewEmptyCollisionWorld("collision", CollisionWorldSettings{
 		Dimensions: Dimensions{Width: width, Height: height},
 		Population: Population{InitialPopulation: 100, MaxPopulation: 100},
 		IsDiagonal: false},
@@ -112,6 +114,7 @@ func TestCollisionWorld_MoveCollider(t *testing.T) {
 	insertX, insertY := 1, 1
 	collider := &Collider{}
 	collisionMap.InsertCollider(insertX, insertY, collider)
+	collisionMap.InsertCollider(4, 4, &Collider{})
 
 	tests := []struct {
 		name         string
@@ -119,11 +122,14 @@ func TestCollisionWorld_MoveCollider(t *testing.T) {
 		args         args
 		want         expected
 	}{
-		{"Move into Empty Space", &collisionMap, args{0, 1, collider}, expected{insertX, insertY + 1, true}},
+		{"Move into Empty Space", &collisionMap, args{geometry.Vector{Y: 1}, collider}, expected{insertX, insertY + 1, true}},
+		{"Move Less Than a Tile", &collisionMap, args{geometry.Vector{Y: 0.4}, collider}, expected{insertX, insertY + 1, true}},
+		{"Fast Move Stops at Edge", &collisionMap, args{geometry.Vector{Y: 2.6}, collider}, expected{insertX, 4, false}},
+		{"Fast Move Stops Before Collider", &collisionMap, args{geometry.Vector{X: 5}, collider}, expected{3, 4, false}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := tt.collisionMap.MoveCollider(tt.args.moveX, tt.args.moveY, tt.args.c); got != tt.want.funcReturn {
+			if got := tt.collisionMap.MoveCollider(tt.args.velocity, tt.args.c); got != tt.want.funcReturn {
 				t.Errorf("CollisionWorld.MoveCollider() = %v, want %v", got, tt.want.funcReturn)
 			}
 
@@ -160,3 +166,260 @@ func TestCollider_ChangeColor(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveElasticCollision(t *testing.T) {
+
+	type expected struct {
+		collided bool
+		v1       geometry.Vector
+		v2       geometry.Vector
+	}
+
+	tests := []struct {
+		name   string
+		c1     *Collider
+		c2     *Collider
+		normal geometry.Vector
+		want   expected
+	}{
+		{"Head On Equal Mass",
+			&Collider{Mass: 1, Velocity: geometry.Vector{X: 1}},
+			&Collider{Mass: 1, Velocity: geometry.Vector{X: -1}},
+			geometry.Vector{X: 1},
+			expected{true, geometry.Vector{X: -1}, geometry.Vector{X: 1}}},
+		{"Moving Apart",
+			&Collider{Mass: 1, Velocity: geometry.Vector{X: -1}},
+			&Collider{Mass: 1, Velocity: geometry.Vector{X: 1}},
+			geometry.Vector{X: 1},
+			expected{false, geometry.Vector{X: -1}, geometry.Vector{X: 1}}},
+		{"Diagonal Contact",
+			&Collider{Mass: 1, Velocity: geometry.Vector{X: 1}},
+			&Collider{Mass: 1},
+			geometry.Vector{X: 1, Y: 1},
+			expected{true, geometry.Vector{X: 0.5, Y: -0.5}, geometry.Vector{X: 0.5, Y: 0.5}}},
+		{"Heavy Hits Still Light",
+			&Collider{Mass: 3, Velocity: geometry.Vector{Y: 1}},
+			&Collider{Mass: 1},
+			geometry.Vector{Y: 1},
+			expected{true, geometry.Vector{Y: 0.5}, geometry.Vector{Y: 1.5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveElasticCollision(tt.c1, tt.c2, tt.normal); got != tt.want.collided {
+				t.Errorf("ResolveElasticCollision() = %v, want %v", got, tt.want.collided)
+			}
+
+			if tt.c1.Velocity.Sub(tt.want.v1).Length() > 1e-9 || tt.c2.Velocity.Sub(tt.want.v2).Length() > 1e-9 {
+				t.Errorf("Velocities = %v, %v, want %v, %v", tt.c1.Velocity, tt.c2.Velocity, tt.want.v1, tt.want.v2)
+			}
+		})
+	}
+}
+
+func TestCollisionWorld_ElasticConservation(t *testing.T) {
+
+	collisionMap := NewCollisionWorld("collision", CollisionWorldSettings{
+		Dimensions:        Dimensions{Width: 30, Height: 30},
+		Population:        Population{InitialPopulation: 300},
+		IsElastic:         true,
+		MaxMass:           5,
+		Temperature:       2,
+		SpeedDistribution: MaxwellBoltzmannSpeed,
+	})
+
+	collisions := 0
+
+	for i := 0; i < 200; i++ {
+		collisionMap.Update()
+
+		if err := collisionMap.LastReport.Check(ConservationTolerance); err != nil {
+			t.Fatal(err)
+		}
+
+		collisions += collisionMap.LastReport.Collisions
+	}
+
+	if collisions == 0 {
+		t.Errorf("Expected Colliders to collide")
+	}
+
+	count := 0
+	collisionMap.eachCollider(func(c *Collider) {
+		count++
+		if tile := c.Position.Round(); tile.X != c.X || tile.Y != c.Y {
+			t.Errorf("Collider at (%d, %d) has Position %v", c.X, c.Y, c.Position)
+		}
+	})
+
+	if count != 300 {
+		t.Errorf("Collider count = %d, want 300", count)
+	}
+}
+
+func TestSpeedDistribution_Speed(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		distribution SpeedDistribution
+		temperature  float64
+		mass         float64
+	}{
+		{"Constant", ConstantSpeed, 0.5, 1},
+		{"Uniform", UniformSpeed, 0.5, 1},
+		{"Maxwell-Boltzmann", MaxwellBoltzmannSpeed, 0.5, 1},
+		{"Heavy Maxwell-Boltzmann", MaxwellBoltzmannSpeed, 2, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			samples := 20000
+			totalEnergy := 0.0
+
+			for i := 0; i < samples; i++ {
+				speed := tt.distribution.Speed(tt.temperature, tt.mass)
+				totalEnergy += 0.5 * tt.mass * speed * speed
+			}
+
+			if meanEnergy := totalEnergy / float64(samples); math.Abs(meanEnergy-tt.temperature) > tt.temperature*0.05 {
+				t.Errorf("Mean Kinetic Energy = %v, want %v", meanEnergy, tt.temperature)
+			}
+		})
+	}
+
+	if got := ConstantSpeed.Speed(0.5, 1); got != 1 {
+		t.Errorf("ConstantSpeed.Speed(0.5, 1) = %v, want 1", got)
+	}
+}
+
+func TestParseCollisionLayout(t *testing.T) {
+
+	d := Dimensions{Width: 20, Height: 20}
+
+	tests := []struct {
+		name          string
+		layout        string
+		wantErr       bool
+		wantObstacles int
+		hasBounds     bool
+		splitX        int
+	}{
+		{"Shapes", "; comment\n\nrectangle 1 1 2 2\ncircle 10 10 3\nline 0 0 5 5\npolygon 1 1 4 1 1 4\n", false, 4, false, 0},
+		{"Bounds", "bounds circle 10 10 9\nbounds rectangle 0 0 5 5\n", false, 0, true, 0},
+		{"Divider", "divider 8 4\n", false, 2, false, 8},
+		{"Divider in the Middle", "divider 4\n", false, 2, false, 10},
+		{"Unknown Shape", "star 1 2 3\n", true, 0, false, 0},
+		{"Bad Number", "circle 1 a 3\n", true, 0, false, 0},
+		{"Too Few Numbers", "line 1 2 3\n", true, 0, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			got, err := ParseCollisionLayout(tt.name, strings.NewReader(tt.layout), d)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCollisionLayout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if len(got.Obstacles) != tt.wantObstacles || (got.Bounds != nil) != tt.hasBounds || got.SplitX != tt.splitX {
+				t.Errorf("ParseCollisionLayout() = %d obstacles, bounds %v, split %d, want %d, %v, %d",
+					len(got.Obstacles), got.Bounds != nil, got.SplitX, tt.wantObstacles, tt.hasBounds, tt.splitX)
+			}
+		})
+	}
+}
+
+func TestCollisionWorld_SlitDiffusion(t *testing.T) {
+
+	d := Dimensions{Width: 30, Height: 30}
+	layout := NewSlitLayout(d, 6)
+
+	collisionMap := NewCollisionWorld("collision", CollisionWorldSettings{
+		Dimensions:  d,
+		Population:  Population{InitialPopulation: 200},
+		IsElastic:   true,
+		Temperature: 0.5,
+		Layout:      &layout,
+		StartOnLeft: true,
+	})
+
+	if first := collisionMap.SideCounts[0]; first.Left != 200 || first.Right != 0 {
+		t.Fatalf("Starting SideCount = %+v, want all 200 on the left", first)
+	}
+
+	for i := 0; i < 300; i++ {
+		collisionMap.Update()
+
+		if err := collisionMap.LastReport.Check(ConservationTolerance); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	collisionMap.eachCollider(func(c *Collider) {
+		if collisionMap.HasObstacle(c.X, c.Y) {
+			t.Errorf("Collider inside obstacle at (%d, %d)", c.X, c.Y)
+		}
+	})
+
+	last := collisionMap.SideCounts[len(collisionMap.SideCounts)-1]
+
+	if last.Left+last.Right != 200 || last.Right == 0 {
+		t.Errorf("Final SideCount = %+v, want 200 Colliders with some through the slit", last)
+	}
+}
+
+func TestCollisionWorld_MoveColliderTorus(t *testing.T) {
+
+	collisionMap := NewEmptyCollisionWorld("collision", CollisionWorldSettings{
+		Dimensions: Dimensions{Width: 10, Height: 10},
+		Population: Population{InitialPopulation: 1},
+		Topology:   geometry.Torus,
+	})
+
+	c := Collider{ColliderWorldActions: &collisionMap, Velocity: geometry.Vector{X: -2}}
+	collisionMap.InsertCollider(0, 5, &c)
+
+	if !collisionMap.MoveCollider(c.Velocity, &c) {
+		t.Fatalf("CollisionWorld.MoveCollider() across the edge = false, want true")
+	}
+	collisionMap.Process()
+
+	if c.GetX() != 8 || c.GetY() != 5 {
+		t.Errorf("Collider = (%d, %d), want (8, 5)", c.GetX(), c.GetY())
+	}
+
+	if _, ok := collisionMap.HasCollider(-2, 5); !ok {
+		t.Errorf("CollisionWorld.HasCollider(-2, 5) = false, want the Collider")
+	}
+}
+
+func TestCollisionWorld_DiagonalSpeed(t *testing.T) {
+
+	collisionMap := NewEmptyCollisionWorld("collision", CollisionWorldSettings{
+		Dimensions: Dimensions{Width: 10, Height: 10},
+		IsDiagonal: true,
+	})
+
+	for i := 0; i < 20; i++ {
+		if direction := collisionMap.randomDirection(); math.Abs(direction.X) != 1 || math.Abs(direction.Y) != 1 {
+			t.Fatalf("CollisionWorld.randomDirection() = %v, want one tile along each axis", direction)
+		}
+	}
+}
+
+func TestCollisionWorld_MetricsPerWorld(t *testing.T) {
+
+	settings := CollisionWorldSettings{
+		Dimensions: Dimensions{Width: 10, Height: 10},
+		Population: Population{InitialPopulation: 1},
+	}
+
+	plain, slit := NewEmptyCollisionWorld("collision", settings), NewEmptyCollisionWorld("collision-slit", settings)
+
+	if plain.ActionQueuer.(*FiniteActionQueue).queued == slit.ActionQueuer.(*FiniteActionQueue).queued {
+		t.Errorf("CollisionWorlds with different names share the same metrics, want them kept apart")
+	}
+}
@@ -29,21 +29,46 @@ type Container interface {
 	Contains(x int, y int) bool
 }
 
+//SurfaceContainer a container whose edges may be joined together, positions past a joined edge are wrapped round to
+//the opposite edge
+type SurfaceContainer interface {
+	Surface() *geometry.Surface
+}
+
+//surfaceOf Returns the Surface of the container, which may be a Surface itself. Any other container is Bounded
+func surfaceOf(container interface{}) *geometry.Surface {
+	switch c := container.(type) {
+	case SurfaceContainer:
+		return c.Surface()
+	case *geometry.Surface:
+		return c
+	}
+	return &geometry.Surface{}
+}
+
+//wrap Returns x and y moved across any joined edges of the container
+func wrap(container interface{}, x int, y int) (int, int) {
+	x, y, _ = surfaceOf(container).Wrap(x, y)
+	return x, y
+}
+
 type Basic2DContainer struct {
-	grid   [][]*GopherWorldTile
-	x      int
-	y      int
-	width  int
-	height int
+	grid    [][]*GopherWorldTile
+	x       int
+	y       int
+	width   int
+	height  int
+	surface geometry.Surface
 }
 
 func NewBasic2DContainer(x int, y int, width int, height int) Basic2DContainer {
 
 	container := Basic2DContainer{
-		x:      x,
-		y:      y,
-		width:  width,
-		height: height}
+		x:       x,
+		y:       y,
+		width:   width,
+		height:  height,
+		surface: geometry.NewSurface(x, y, width, height, geometry.Bounded)}
 
 	container.grid = make([][]*GopherWorldTile, width)
 
@@ -60,13 +85,26 @@ func NewBasic2DContainer(x int, y int, width int, height int) Basic2DContainer {
 }
 
 func (container *Basic2DContainer) Tile(x int, y int) (*GopherWorldTile, bool) {
-	if x < container.x || x >= container.width+container.x || y < container.y || y >= container.height+container.y {
+
+	x, y, ok := container.surface.Wrap(x, y)
+
+	if !ok {
 		return nil, false
 	}
 
 	return container.grid[x-container.x][y-container.y], true
 }
 
+//Surface Returns how the edges of the container are joined together
+func (container *Basic2DContainer) Surface() *geometry.Surface {
+	return &container.surface
+}
+
+//SetTopology Joins the edges of the container together
+func (container *Basic2DContainer) SetTopology(topology geometry.Topology) {
+	container.surface.Topology = topology
+}
+
 type TrackedTileContainer struct {
 	b2dc                *Basic2DContainer
 	gopherTileLocations map[int]*GopherWorldTile
@@ -87,51 +125,193 @@ func (container *TrackedTileContainer) ConvertToTrackedTileCoordinates(x int, y
 	return (x - container.b2dc.x), (y - container.b2dc.y)
 }
 
+//key Returns the key of the tile at x and y in the tracked locations. Each tile in the container has its own key
+func (container *TrackedTileContainer) key(x int, y int) int {
+	x, y = container.ConvertToTrackedTileCoordinates(x, y)
+	return x*container.b2dc.height + y
+}
+
+//DefaultPartitionSize the width and height of the partitions of a BasicGridContainer when none is given
+const DefaultPartitionSize = 5
+
+const (
+	//splitOccupancy partitions are split when they hold more than this many entities on average
+	splitOccupancy = 64
+	//mergeOccupancy partitions are merged when they hold less than this many entities on average
+	mergeOccupancy = 8
+	//minimumPartitionSize partitions are never split smaller than this
+	minimumPartitionSize = 4
+)
+
+//BasicGridContainer splits its tiles into partitions that each track where their gophers and food are. The tiles are
+//shared between the partitions so they can be resized without moving any tiles
 type BasicGridContainer struct {
+	tiles      [][]*GopherWorldTile
 	containers [][]*TrackedTileContainer
 	gridWidth  int
 	gridHeight int
 	width      int
 	height     int
+	surface    geometry.Surface
 }
 
+//NewBasicGridContainer Returns a BasicGridContainer split into partitions of gridWidth and gridHeight. Sizes less than
+//one use the DefaultPartitionSize
 func NewBasicGridContainer(width int, height int, gridWidth int, gridHeight int) BasicGridContainer {
 
-	numberOfGridsX, numberOfGridsY := width/gridWidth, height/gridHeight
+	if gridWidth < 1 {
+		gridWidth = DefaultPartitionSize
+	}
+
+	if gridHeight < 1 {
+		gridHeight = DefaultPartitionSize
+	}
+
+	b2dc := NewBasic2DContainer(0, 0, width, height)
 
-	if numberOfGridsX*gridWidth < width {
+	container := BasicGridContainer{
+		tiles:   b2dc.grid,
+		width:   width,
+		height:  height,
+		surface: b2dc.surface,
+	}
+
+	container.partition(gridWidth, gridHeight)
+
+	return container
+}
+
+//partition Replaces the partitions with partitions of the given size. Gophers and food are tracked by their new partition
+func (container *BasicGridContainer) partition(gridWidth int, gridHeight int) {
+
+	numberOfGridsX, numberOfGridsY := container.width/gridWidth, container.height/gridHeight
+
+	if numberOfGridsX*gridWidth < container.width {
 		numberOfGridsX++
 	}
 
-	if numberOfGridsY*gridHeight < height {
+	if numberOfGridsY*gridHeight < container.height {
 		numberOfGridsY++
 	}
 
-	containers := make([][]*TrackedTileContainer, numberOfGridsX)
+	previous := container.containers
+
+	container.gridWidth, container.gridHeight = gridWidth, gridHeight
+	container.containers = make([][]*TrackedTileContainer, numberOfGridsX)
 
 	for i := 0; i < numberOfGridsX; i++ {
-		containers[i] = make([]*TrackedTileContainer, numberOfGridsY)
+		container.containers[i] = make([]*TrackedTileContainer, numberOfGridsY)
 
 		for j := 0; j < numberOfGridsY; j++ {
-			ttc := NewTrackedTileContainer(i*gridWidth,
-				j*gridHeight,
-				gridWidth,
-				gridHeight)
-			containers[i][j] = &ttc
+
+			x, y := i*gridWidth, j*gridHeight
+			width, height := gridWidth, gridHeight
+
+			if x+width > container.width {
+				width = container.width - x
+			}
+
+			if y+height > container.height {
+				height = container.height - y
+			}
+
+			b2dc := Basic2DContainer{
+				grid:    make([][]*GopherWorldTile, width),
+				x:       x,
+				y:       y,
+				width:   width,
+				height:  height,
+				surface: geometry.NewSurface(x, y, width, height, geometry.Bounded),
+			}
+
+			for k := 0; k < width; k++ {
+				b2dc.grid[k] = container.tiles[x+k][y : y+height]
+			}
+
+			container.containers[i][j] = &TrackedTileContainer{
+				b2dc:                &b2dc,
+				gopherTileLocations: make(map[int]*GopherWorldTile),
+				foodTileLocations:   make(map[int]*GopherWorldTile),
+			}
+		}
+	}
+
+	for _, column := range previous {
+		for _, ttc := range column {
+
+			for _, tile := range ttc.gopherTileLocations {
+				if tile.HasGopher() {
+					x, y := tile.Gopher.Position.GetX(), tile.Gopher.Position.GetY()
+					if grid, ok := container.Grid(x, y); ok {
+						grid.gopherTileLocations[grid.key(x, y)] = tile
+					}
+				}
+			}
+
+			for _, tile := range ttc.foodTileLocations {
+				if tile.HasFood() {
+					x, y := tile.Food.Position.GetX(), tile.Food.Position.GetY()
+					if grid, ok := container.Grid(x, y); ok {
+						grid.foodTileLocations[grid.key(x, y)] = tile
+					}
+				}
+			}
+		}
+	}
+}
+
+//PartitionSize Returns the width and height of the partitions
+func (container *BasicGridContainer) PartitionSize() (int, int) {
+	return container.gridWidth, container.gridHeight
+}
+
+//Rebalance Splits every partition in four when the partitions hold too many gophers and food on average, or merges
+//them back together when they hold too few. Returns true if the partitions changed size
+func (container *BasicGridContainer) Rebalance() bool {
+
+	entities, partitions := 0, 0
+
+	for _, column := range container.containers {
+		for _, ttc := range column {
+			entities += len(ttc.gopherTileLocations) + len(ttc.foodTileLocations)
+			partitions++
 		}
 	}
 
-	return BasicGridContainer{
-		containers: containers,
-		gridWidth:  gridWidth,
-		gridHeight: gridHeight,
-		width:      width,
-		height:     height,
+	if partitions == 0 {
+		return false
+	}
+
+	average := entities / partitions
+
+	switch {
+	case average > splitOccupancy && container.gridWidth/2 >= minimumPartitionSize && container.gridHeight/2 >= minimumPartitionSize:
+		container.partition(container.gridWidth/2, container.gridHeight/2)
+		return true
+	case average < mergeOccupancy && (container.gridWidth < container.width || container.gridHeight < container.height):
+		container.partition(container.gridWidth*2, container.gridHeight*2)
+		return true
 	}
+
+	return false
+}
+
+//Occupancy Returns how full the partition holding x and y is, the number of gophers and food in it divided by the
+//number of tiles it has
+func (container *BasicGridContainer) Occupancy(x int, y int) (float64, bool) {
+
+	if grid, ok := container.Grid(x, y); ok {
+		entities := len(grid.gopherTileLocations) + len(grid.foodTileLocations)
+		return float64(entities) / float64(grid.b2dc.width*grid.b2dc.height), true
+	}
+
+	return 0, false
 }
 
 func (container *BasicGridContainer) Tile(x int, y int) (*GopherWorldTile, bool) {
 
+	x, y = wrap(container, x, y)
+
 	if grid, ok := container.Grid(x, y); ok {
 		if tile, ok := grid.b2dc.Tile(x, y); ok {
 			return tile, ok
@@ -140,10 +320,23 @@ func (container *BasicGridContainer) Tile(x int, y int) (*GopherWorldTile, bool)
 	return nil, false
 }
 
+//Surface Returns how the edges of the container are joined together
+func (container *BasicGridContainer) Surface() *geometry.Surface {
+	return &container.surface
+}
+
+//SetTopology Joins the edges of the container together. The partitions themselves are always Bounded, positions are
+//wrapped before they are passed to a partition
+func (container *BasicGridContainer) SetTopology(topology geometry.Topology) {
+	container.surface.Topology = topology
+}
+
 //Takes an X and Y Position, and finds which grid it should be in
 func (container *BasicGridContainer) Grid(x int, y int) (*TrackedTileContainer, bool) {
 
-	if x < 0 || x >= container.width || y < 0 || y >= container.height {
+	x, y, ok := container.surface.Wrap(x, y)
+
+	if !ok {
 		return nil, false
 	}
 
@@ -184,6 +377,8 @@ type GopherAndFoodInserterAndRemover interface {
 //InsertGopher Inserts the given gopher into the tileMap at the specified co-ordinate
 func (container *Basic2DContainer) InsertGopher(x int, y int, gopher *Gopher) bool {
 
+	x, y = wrap(container, x, y)
+
 	if tile, ok := container.Tile(x, y); ok {
 		if !tile.HasGopher() {
 			gopher.Position.SetXY(x, y)
@@ -199,6 +394,8 @@ func (container *Basic2DContainer) InsertGopher(x int, y int, gopher *Gopher) bo
 //InsertFood Inserts the given food into the tileMap at the specified co-ordinate
 func (container *Basic2DContainer) InsertFood(x int, y int, food *Food) bool {
 
+	x, y = wrap(container, x, y)
+
 	if tile, ok := container.Tile(x, y); ok {
 		if !tile.HasFood() {
 			food.Position.SetXY(x, y)
@@ -261,8 +458,7 @@ func (container *TrackedTileContainer) InsertGopher(x int, y int, gopher *Gopher
 	if tile, ok := container.b2dc.Tile(x, y); ok {
 		container.b2dc.InsertGopher(x, y, gopher)
 
-		x, y = container.ConvertToTrackedTileCoordinates(x, y)
-		container.gopherTileLocations[geometry.Hashcode(x, y)] = tile
+		container.gopherTileLocations[container.key(x, y)] = tile
 		return true
 	}
 	return false
@@ -272,8 +468,7 @@ func (container *TrackedTileContainer) InsertFood(x int, y int, food *Food) bool
 	if tile, ok := container.b2dc.Tile(x, y); ok {
 		container.b2dc.InsertFood(x, y, food)
 
-		x, y = container.ConvertToTrackedTileCoordinates(x, y)
-		container.foodTileLocations[geometry.Hashcode(x, y)] = tile
+		container.foodTileLocations[container.key(x, y)] = tile
 		return true
 	}
 	return false
@@ -281,8 +476,7 @@ func (container *TrackedTileContainer) InsertFood(x int, y int, food *Food) bool
 
 func (container *TrackedTileContainer) RemoveGopher(x int, y int) (*Gopher, bool) {
 	if gopher, ok := container.b2dc.RemoveGopher(x, y); ok {
-		x, y = container.ConvertToTrackedTileCoordinates(x, y)
-		delete(container.gopherTileLocations, geometry.Hashcode(x, y))
+		delete(container.gopherTileLocations, container.key(x, y))
 		return gopher, true
 	}
 	return nil, false
@@ -290,14 +484,14 @@ func (container *TrackedTileContainer) RemoveGopher(x int, y int) (*Gopher, bool
 
 func (container *TrackedTileContainer) RemoveFood(x int, y int) (*Food, bool) {
 	if food, ok := container.b2dc.RemoveFood(x, y); ok {
-		x, y = container.ConvertToTrackedTileCoordinates(x, y)
-		delete(container.foodTileLocations, geometry.Hashcode(x, y))
+		delete(container.foodTileLocations, container.key(x, y))
 		return food, true
 	}
 	return nil, false
 }
 
 func (container *BasicGridContainer) InsertGopher(x int, y int, gopher *Gopher) bool {
+	x, y = wrap(container, x, y)
 	if grid, ok := container.Grid(x, y); ok {
 		return grid.InsertGopher(x, y, gopher)
 	}
@@ -305,6 +499,7 @@ func (container *BasicGridContainer) InsertGopher(x int, y int, gopher *Gopher)
 }
 
 func (container *BasicGridContainer) InsertFood(x int, y int, food *Food) bool {
+	x, y = wrap(container, x, y)
 	if grid, ok := container.Grid(x, y); ok {
 		return grid.InsertFood(x, y, food)
 	}
@@ -312,6 +507,7 @@ func (container *BasicGridContainer) InsertFood(x int, y int, food *Food) bool {
 }
 
 func (container *BasicGridContainer) RemoveGopher(x int, y int) (*Gopher, bool) {
+	x, y = wrap(container, x, y)
 	if grid, ok := container.Grid(x, y); ok {
 		return grid.RemoveGopher(x, y)
 	}
@@ -319,6 +515,7 @@ func (container *BasicGridContainer) RemoveGopher(x int, y int) (*Gopher, bool)
 }
 
 func (container *BasicGridContainer) RemoveFood(x int, y int) (*Food, bool) {
+	x, y = wrap(container, x, y)
 	if grid, ok := container.Grid(x, y); ok {
 		return grid.RemoveFood(x, y)
 	}
@@ -326,6 +523,7 @@ func (container *BasicGridContainer) RemoveFood(x int, y int) (*Food, bool) {
 }
 
 func (container *BasicGridContainer) HasGopher(x int, y int) (*Gopher, bool) {
+	x, y = wrap(container, x, y)
 	if grid, ok := container.Grid(x, y); ok {
 		return grid.b2dc.HasGopher(x, y)
 	}
@@ -333,6 +531,7 @@ func (container *BasicGridContainer) HasGopher(x int, y int) (*Gopher, bool) {
 }
 
 func (container *BasicGridContainer) HasFood(x int, y int) (*Food, bool) {
+	x, y = wrap(container, x, y)
 	if grid, ok := container.Grid(x, y); ok {
 		return grid.b2dc.HasFood(x, y)
 	}
@@ -1,6 +1,7 @@
 package world
 
 import (
+	"gopherlife/geometry"
 	"reflect"
 	"testing"
 )
@@ -148,3 +149,102 @@ func TestBasicGridContainer_HasFood(t *testing.T) {
 		})
 	}
 }
+
+func TestBasicGridContainer_LargePartitions(t *testing.T) {
+
+	gridC := NewBasicGridContainer(100, 100, 50, 50)
+
+	//Tiles that shared a key when keys did not account for the partition height
+	gridC.InsertFood(0, 31, &Food{})
+	gridC.InsertFood(1, 0, &Food{})
+
+	grid, _ := gridC.Grid(0, 0)
+
+	if len(grid.foodTileLocations) != 2 {
+		t.Errorf("BasicGridContainer tracked %v food, want 2", len(grid.foodTileLocations))
+	}
+}
+
+func TestBasicGridContainer_Rebalance(t *testing.T) {
+
+	gridC := NewBasicGridContainer(64, 64, 32, 32)
+
+	for x := 0; x < 64; x++ {
+		for y := 0; y < 32; y++ {
+			gridC.InsertFood(x, y, &Food{})
+		}
+	}
+
+	if !gridC.Rebalance() {
+		t.Fatalf("BasicGridContainer.Rebalance() = false, want partitions to split")
+	}
+
+	if w, h := gridC.PartitionSize(); w != 16 || h != 16 {
+		t.Errorf("BasicGridContainer.PartitionSize() = %v, %v, want 16, 16", w, h)
+	}
+
+	if food, ok := gridC.HasFood(40, 20); !ok || food == nil {
+		t.Errorf("BasicGridContainer.HasFood() after Rebalance = %v, want food", ok)
+	}
+
+	if occupancy, _ := gridC.Occupancy(40, 20); occupancy != 1 {
+		t.Errorf("BasicGridContainer.Occupancy() = %v, want 1", occupancy)
+	}
+
+	if occupancy, _ := gridC.Occupancy(40, 40); occupancy != 0 {
+		t.Errorf("BasicGridContainer.Occupancy() = %v, want 0", occupancy)
+	}
+
+	for x := 0; x < 64; x++ {
+		for y := 0; y < 32; y++ {
+			gridC.RemoveFood(x, y)
+		}
+	}
+	gridC.InsertFood(40, 20, &Food{})
+
+	for gridC.Rebalance() {
+	}
+
+	if w, h := gridC.PartitionSize(); w != 64 || h != 64 {
+		t.Errorf("BasicGridContainer.PartitionSize() = %v, %v, want 64, 64", w, h)
+	}
+
+	if _, ok := gridC.RemoveFood(40, 20); !ok {
+		t.Errorf("BasicGridContainer.RemoveFood() after merging = false, want true")
+	}
+
+	grid, _ := gridC.Grid(0, 0)
+
+	if len(grid.foodTileLocations) != 0 {
+		t.Errorf("BasicGridContainer tracked %v food after RemoveFood, want 0", len(grid.foodTileLocations))
+	}
+}
+
+func TestBasicGridContainer_Torus(t *testing.T) {
+
+	gridC := NewBasicGridContainer(10, 10, 5, 5)
+	gridC.SetTopology(geometry.Torus)
+
+	gopher := &Gopher{}
+
+	if !gridC.InsertGopher(-1, 12, gopher) {
+		t.Fatalf("BasicGridContainer.InsertGopher() across the edge = false, want true")
+	}
+
+	if gopher.Position.GetX() != 9 || gopher.Position.GetY() != 2 {
+		t.Errorf("Gopher Position = %v, want (9, 2)", gopher.Position)
+	}
+
+	if got, ok := gridC.HasGopher(9, 2); !ok || got != gopher {
+		t.Errorf("BasicGridContainer.HasGopher() = %v, %v, want the gopher", got, ok)
+	}
+
+	search := GridTileSearch{BasicGridContainer: &gridC}
+	gridC.InsertFood(0, 0, &Food{})
+
+	found := search.Search(geometry.Coordinates{X: 9, Y: 9}, 3, 3, 1, SearchForFood)
+
+	if len(found) != 1 || found[0] != (geometry.Coordinates{X: 0, Y: 0}) {
+		t.Errorf("GridTileSearch.Search() across the corner = %v, want [(0, 0)]", found)
+	}
+}
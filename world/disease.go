@@ -0,0 +1,219 @@
+package world
+
+import "math/rand"
+
+//InfectionState how far through a disease a Gopher is
+type InfectionState int
+
+//InfectionStates a Gopher moves through in order, a Gopher that has recovered cannot catch the disease again
+const (
+	Susceptible InfectionState = iota
+	Exposed
+	Infected
+	Recovered
+)
+
+func (state InfectionState) String() string {
+	switch state {
+	case Exposed:
+		return "Exposed"
+	case Infected:
+		return "Infected"
+	case Recovered:
+		return "Recovered"
+	}
+	return "Susceptible"
+}
+
+//MarshalText Writes the InfectionState by name, so it reads well in JSON
+func (state InfectionState) MarshalText() ([]byte, error) {
+	return []byte(state.String()), nil
+}
+
+//Disease how an infection spreads between gophers and what it does to them. Chances are out of 100, except DeathChance
+//which is out of 1000 as it is rolled every tick a Gopher is infected
+type Disease struct {
+	//Enabled gophers can only be infected while the disease is enabled
+	Enabled bool
+	//TransmissionRadius how close a susceptible Gopher has to be to an infected one to catch the disease
+	TransmissionRadius int
+	//TransmissionChance the chance each tick that an infected Gopher passes the disease to each Gopher in range
+	TransmissionChance int
+	//IncubationPeriod the number of ticks a Gopher is exposed before it becomes infectious
+	IncubationPeriod int
+	//InfectiousPeriod the number of ticks a Gopher is infectious before it recovers
+	InfectiousPeriod int
+	//MovementPenalty the chance each tick that an infected Gopher is too ill to do anything
+	MovementPenalty int
+	//ExtraHunger the extra hunger an infected Gopher gets each tick
+	ExtraHunger int
+	//DeathChance the chance out of 1000 each tick that an infected Gopher dies
+	DeathChance int
+}
+
+//DefaultDisease a disease that spreads quickly through a crowd and kills a few of the gophers it infects. It is not
+//enabled, so nothing can catch it until it is turned on
+var DefaultDisease = Disease{
+	TransmissionRadius: 2,
+	TransmissionChance: 20,
+	IncubationPeriod:   30,
+	InfectiousPeriod:   100,
+	MovementPenalty:    50,
+	ExtraHunger:        1,
+	DeathChance:        2,
+}
+
+//Infect Exposes the Gopher to the disease. Returns false if it has already caught it
+func (gopher *Gopher) Infect() bool {
+
+	if gopher.Infection != Susceptible {
+		return false
+	}
+
+	gopher.Infection = Exposed
+	gopher.InfectionTimer = 0
+	return true
+}
+
+//progress Moves the Gopher on through the disease by one tick. Returns true if the Gopher is infectious
+func (disease *Disease) progress(gopher *Gopher) bool {
+
+	switch gopher.Infection {
+	case Exposed:
+		gopher.InfectionTimer++
+		if gopher.InfectionTimer >= disease.IncubationPeriod {
+			gopher.Infection = Infected
+			gopher.InfectionTimer = 0
+		}
+	case Infected:
+		gopher.InfectionTimer++
+		if gopher.InfectionTimer >= disease.InfectiousPeriod {
+			gopher.Infection = Recovered
+			gopher.InfectionTimer = 0
+		}
+	}
+
+	return gopher.Infection == Infected
+}
+
+//harm Applies the hunger and the chance of death of the disease to an infected Gopher
+func (disease *Disease) harm(gopher *Gopher) {
+
+	gopher.Hunger -= disease.ExtraHunger
+
+	if disease.DeathChance > 0 && rand.Intn(1000) < disease.DeathChance {
+		gopher.IsDead = true
+	}
+}
+
+//isTooIllToMove Returns true if an infected Gopher spends this tick resting
+func (disease *Disease) isTooIllToMove(gopher *Gopher) bool {
+	return gopher.Infection == Infected && disease.MovementPenalty > 0 && rand.Intn(100) < disease.MovementPenalty
+}
+
+//handleDisease Moves the Gopher on through the disease and, if it is infectious, queues passing the disease on to the
+//susceptible gophers around it. Gophers are updated in parallel so the spreading waits for the queue, where only one
+//action runs at a time
+func (actor *GopherActor) handleDisease(gopher *Gopher) {
+
+	if gopher.IsDead || !actor.Disease.progress(gopher) {
+		return
+	}
+
+	actor.Disease.harm(gopher)
+
+	if actor.Disease.TransmissionChance <= 0 {
+		return
+	}
+
+	actor.Add(func() {
+
+		radius := actor.Disease.TransmissionRadius
+		x, y := gopher.Position.GetX(), gopher.Position.GetY()
+
+		for dy := -radius; dy <= radius; dy++ {
+			for dx := -radius; dx <= radius; dx++ {
+
+				neighbour, ok := actor.HasGopher(x+dx, y+dy)
+
+				if !ok || neighbour == gopher || neighbour.IsDead || neighbour.Infection != Susceptible {
+					continue
+				}
+
+				if actor.Movement.IsInRange(gopher.Position, neighbour.Position, radius, radius) &&
+					rand.Intn(100) < actor.Disease.TransmissionChance {
+					neighbour.Infect()
+				}
+			}
+		}
+	})
+}
+
+//maxEpidemicCounts the number of ticks of EpidemicCounts a GopherWorld keeps
+const maxEpidemicCounts = 1000
+
+//EpidemicCount the number of living gophers in each InfectionState on a tick
+type EpidemicCount struct {
+	Tick        int
+	Susceptible int
+	Exposed     int
+	Infected    int
+	Recovered   int
+}
+
+//countInfections Records how many living gophers are in each InfectionState, keeping the last maxEpidemicCounts ticks
+func (gw *GopherWorld) countInfections() {
+
+	count := EpidemicCount{Tick: gw.frame}
+
+	for _, gopher := range gw.ActiveArray {
+
+		if gopher == nil || gopher.IsDead {
+			continue
+		}
+
+		switch gopher.Infection {
+		case Susceptible:
+			count.Susceptible++
+		case Exposed:
+			count.Exposed++
+		case Infected:
+			count.Infected++
+		case Recovered:
+			count.Recovered++
+		}
+	}
+
+	if len(gw.EpidemicCounts) >= maxEpidemicCounts {
+		gw.EpidemicCounts = gw.EpidemicCounts[1:]
+	}
+
+	gw.EpidemicCounts = append(gw.EpidemicCounts, count)
+}
+
+//HasOutbreak Returns true if any living Gopher was exposed or infected on the last tick
+func (gw *GopherWorld) HasOutbreak() bool {
+
+	if len(gw.EpidemicCounts) == 0 {
+		return false
+	}
+
+	last := gw.EpidemicCounts[len(gw.EpidemicCounts)-1]
+	return last.Exposed+last.Infected > 0
+}
+
+//InfectGopher Makes the Gopher at x and y patient zero of a new outbreak. Returns false if the disease is not enabled,
+//there is no susceptible Gopher there or there is already an outbreak
+func (gw *GopherWorld) InfectGopher(x int, y int) bool {
+
+	if !gw.Disease.Enabled || gw.HasOutbreak() {
+		return false
+	}
+
+	if gopher, ok := gw.HasGopher(x, y); ok && !gopher.IsDead && gopher.Infect() {
+		gw.PatientZero = gopher
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,104 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"testing"
+)
+
+func TestDisease_Progress(t *testing.T) {
+
+	disease := Disease{IncubationPeriod: 2, InfectiousPeriod: 3}
+	gopher := NewGopher("a", geometry.NewCoordinate(0, 0))
+
+	if disease.progress(&gopher) || gopher.Infection != Susceptible {
+		t.Errorf("Susceptible gopher progressed to %v", gopher.Infection)
+	}
+
+	gopher.Infect()
+
+	states := []InfectionState{}
+	for i := 0; i < 6; i++ {
+		disease.progress(&gopher)
+		states = append(states, gopher.Infection)
+	}
+
+	want := []InfectionState{Exposed, Infected, Infected, Infected, Recovered, Recovered}
+
+	for i := range want {
+		if states[i] != want[i] {
+			t.Errorf("Infection after %d ticks = %v, want %v", i+1, states[i], want[i])
+		}
+	}
+
+	if gopher.Infect() {
+		t.Errorf("Recovered gopher caught the disease again")
+	}
+}
+
+func TestGopherWorld_DiseaseSpreads(t *testing.T) {
+
+	settings := GopherWorldSettings{
+		Dimensions: Dimensions{Width: 6, Height: 6},
+		Population: Population{InitialPopulation: 20, MaxPopulation: 100},
+		Disease: Disease{
+			Enabled:            true,
+			TransmissionRadius: 2,
+			TransmissionChance: 100,
+			InfectiousPeriod:   1000,
+		},
+	}
+
+	gw := CreateGopherWorldSpiralSearch(settings)
+	patientZero := gw.SelectedGopher
+
+	if !gw.InfectGopher(patientZero.Position.GetX(), patientZero.Position.GetY()) {
+		t.Fatalf("GopherWorld.InfectGopher() did not infect patient zero")
+	}
+
+	for i := 0; i < 10; i++ {
+		gw.Update()
+	}
+
+	if gw.PatientZero != patientZero {
+		t.Errorf("GopherWorld.PatientZero is not the infected gopher")
+	}
+
+	last := gw.EpidemicCounts[len(gw.EpidemicCounts)-1]
+
+	if last.Exposed+last.Infected <= 1 {
+		t.Errorf("EpidemicCount = %+v, want the disease to have spread", last)
+	}
+
+	if total := last.Susceptible + last.Exposed + last.Infected + last.Recovered; total != gw.NumberOfGophers {
+		t.Errorf("EpidemicCount adds up to %d gophers, want %d", total, gw.NumberOfGophers)
+	}
+
+	for _, gopher := range gw.ActiveArray {
+		if gopher.Infection == Susceptible {
+			if gw.InfectGopher(gopher.Position.GetX(), gopher.Position.GetY()) {
+				t.Errorf("GopherWorld.InfectGopher() started a second outbreak during the first")
+			}
+			break
+		}
+	}
+}
+
+func TestGopherWorld_DiseaseOffByDefault(t *testing.T) {
+
+	settings := GopherWorldSettings{
+		Dimensions: Dimensions{Width: 6, Height: 6},
+		Population: Population{InitialPopulation: 20, MaxPopulation: 100},
+		Disease:    DefaultDisease,
+	}
+
+	gw := CreateGopherWorldSpiralSearch(settings)
+	gopher := gw.SelectedGopher
+
+	if gw.InfectGopher(gopher.Position.GetX(), gopher.Position.GetY()) {
+		t.Errorf("GopherWorld.InfectGopher() infected a gopher while the disease was not enabled")
+	}
+
+	if gopher.Infection != Susceptible || gw.PatientZero != nil {
+		t.Errorf("Gopher.Infection = %v, want it to still be Susceptible", gopher.Infection)
+	}
+}
@@ -0,0 +1,73 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"math"
+)
+
+//FieldOfView Returns every position within the radius that can be seen from the given position. Tiles matching the
+//opaque query can be seen but hide the tiles behind them, positions outside the container hide what is behind them too
+func FieldOfView(t TileContainer, position geometry.Coordinates, radius int, opaque TileQuery) []geometry.Coordinates {
+
+	seen := map[geometry.Coordinates]bool{}
+	visible := []geometry.Coordinates{}
+
+	geometry.FieldOfView(position, radius,
+		func(x int, y int) bool {
+			tile, ok := t.Tile(x, y)
+			return !ok || opaque(tile)
+		},
+		func(x int, y int) {
+			c := geometry.Coordinates{X: x, Y: y}
+			if _, ok := t.Tile(x, y); ok && !seen[c] {
+				seen[c] = true
+				visible = append(visible, c)
+			}
+		},
+	)
+
+	return visible
+}
+
+//VisibleTileSearch a GopherWorldSearcher that only finds what can be seen from the searching position. Tiles matching
+//Opaque block the view of the tiles behind them
+type VisibleTileSearch struct {
+	GopherWorldSearcher
+	TileContainer
+	Opaque TileQuery
+}
+
+//blocksSight Returns true if the tile hides what is behind it from a gopher, other gophers block the view
+func blocksSight(tile *GopherWorldTile) bool {
+	return tile.HasGopher()
+}
+
+//Search Returns up to max of the positions found by the wrapped searcher that are in sight of the position, nearest
+//first. The wrapped searcher is not limited to max, otherwise hidden positions could crowd out the visible ones
+func (search *VisibleTileSearch) Search(position geometry.Coordinates, width int, height int, max int, searchType SearchType) []geometry.Coordinates {
+
+	found := search.GopherWorldSearcher.Search(position, width, height, math.MaxInt32, searchType)
+
+	if len(found) == 0 || max <= 0 {
+		return []geometry.Coordinates{}
+	}
+
+	radius := width
+	if height > radius {
+		radius = height
+	}
+
+	visible := geometry.NewPoints(FieldOfView(search.TileContainer, position, radius, search.Opaque))
+	inSight := make([]geometry.Coordinates, 0, len(found))
+
+	for _, c := range found {
+		if visible.Contains(c.X, c.Y) {
+			inSight = append(inSight, c)
+			if len(inSight) == max {
+				break
+			}
+		}
+	}
+
+	return inSight
+}
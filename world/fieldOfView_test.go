@@ -0,0 +1,78 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"testing"
+)
+
+func TestVisibleTileSearch_Search(t *testing.T) {
+
+	b2dc := NewBasic2DContainer(0, 0, 20, 20)
+	b2dc.InsertGopher(5, 2, &Gopher{})
+	b2dc.InsertFood(8, 2, &Food{})
+	b2dc.InsertFood(2, 6, &Food{})
+
+	search := VisibleTileSearch{
+		GopherWorldSearcher: &SpiralTileSearch{TileContainer: &b2dc},
+		TileContainer:       &b2dc,
+		Opaque: func(tile *GopherWorldTile) bool {
+			return tile.HasGopher()
+		},
+	}
+
+	got := search.Search(geometry.NewCoordinate(2, 2), 15, 15, 5, SearchForFood)
+	want := []geometry.Coordinates{{X: 2, Y: 6}}
+
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("VisibleTileSearch.Search() = %v, want %v. Food behind the gopher should be hidden", got, want)
+	}
+}
+
+func TestVisibleTileSearch_SearchHiddenNearest(t *testing.T) {
+
+	b2dc := NewBasic2DContainer(0, 0, 20, 20)
+	b2dc.InsertGopher(3, 2, &Gopher{})
+	b2dc.InsertFood(5, 2, &Food{})
+	b2dc.InsertFood(6, 2, &Food{})
+	b2dc.InsertFood(2, 9, &Food{})
+
+	search := VisibleTileSearch{
+		GopherWorldSearcher: &SpiralTileSearch{TileContainer: &b2dc},
+		TileContainer:       &b2dc,
+		Opaque:              blocksSight,
+	}
+
+	got := search.Search(geometry.NewCoordinate(2, 2), 15, 15, 1, SearchForFood)
+	want := geometry.Coordinates{X: 2, Y: 9}
+
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("VisibleTileSearch.Search() = %v, want [%v]. The nearest food is hidden, the visible food should be found",
+			got, want)
+	}
+}
+
+func TestGopherWorld_LineOfSight(t *testing.T) {
+
+	settings := GopherWorldSettings{
+		Dimensions:  Dimensions{Width: 10, Height: 10},
+		Population:  Population{InitialPopulation: 1, MaxPopulation: 10},
+		LineOfSight: true,
+	}
+
+	gw := CreateGopherWorldSpiralSearch(settings)
+
+	if _, ok := gw.GopherWorldSearcher.(*VisibleTileSearch); !ok {
+		t.Errorf("GopherWorld searcher = %T, want a *VisibleTileSearch when LineOfSight is on", gw.GopherWorldSearcher)
+	}
+}
+
+func TestFieldOfView_Edges(t *testing.T) {
+
+	b2dc := NewBasic2DContainer(0, 0, 5, 5)
+
+	visible := FieldOfView(&b2dc, geometry.NewCoordinate(0, 0), 10, func(*GopherWorldTile) bool { return false })
+
+	if len(visible) != 25 {
+		t.Errorf("FieldOfView() of an empty 5x5 container saw %d tiles, want 25", len(visible))
+	}
+}
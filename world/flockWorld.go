@@ -0,0 +1,405 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"math"
+	"math/rand"
+	"time"
+)
+
+//FlockRules how strongly each Boid steers by each rule. They can be changed while the world is running
+type FlockRules struct {
+	//Separation steers away from Boids closer than the SeparationRadius
+	Separation float64
+	//Alignment steers towards the average heading of the Boid and the Boids it can see
+	Alignment float64
+	//Cohesion steers towards the average position of the Boids that can be seen
+	Cohesion float64
+	//Avoidance steers away from Obstacles, walls and Predators
+	Avoidance float64
+
+	//ViewRadius how far a Boid can see other Boids
+	ViewRadius float64
+	//SeparationRadius how close a Boid lets other Boids get
+	SeparationRadius float64
+	//MaxSpeed the most tiles a Boid moves each update, a Boid never slows below half of it
+	MaxSpeed float64
+	//MaxForce the most a Boid's velocity can change each update
+	MaxForce float64
+}
+
+//DefaultFlockRules rules that give a lively flock
+var DefaultFlockRules = FlockRules{
+	Separation:       1.5,
+	Alignment:        1,
+	Cohesion:         1,
+	Avoidance:        3,
+	ViewRadius:       8,
+	SeparationRadius: 3,
+	MaxSpeed:         1,
+	MaxForce:         0.05,
+}
+
+//predatorSpeed how much faster than a Boid a Predator can move
+const predatorSpeed = 1.2
+
+//wallMargin how far from a wall that is not joined a Boid starts to turn away from it
+const wallMargin = 4
+
+//FlockWorldSettings sets up a FlockWorld
+type FlockWorldSettings struct {
+	Dimensions
+	Topology geometry.Topology
+	FlockRules
+
+	NumberOfBoids     int
+	NumberOfPredators int
+	NumberOfObstacles int
+}
+
+//Boid a member of a flock
+type Boid struct {
+	Position geometry.Vector
+	Velocity geometry.Vector
+	//IsPredator Predators chase the nearest Boid instead of flocking, other Boids flee from them
+	IsPredator bool
+}
+
+//Obstacle a round area Boids steer around
+type Obstacle struct {
+	Center geometry.Vector
+	Radius float64
+}
+
+//FlockTile what a tile of a FlockWorld shows once it has been rasterized
+type FlockTile uint8
+
+//FlockTiles
+const (
+	EmptyFlockTile FlockTile = iota
+	BoidFlockTile
+	PredatorFlockTile
+	ObstacleFlockTile
+)
+
+//FlockWorld Boids with float positions and velocities that flock together following separation, alignment and
+//cohesion rules. Each update the Boids are bucketed into a SpatialGrid so each only looks at the Boids near it
+type FlockWorld struct {
+	FlockWorldSettings
+
+	Boids     []*Boid
+	Obstacles []Obstacle
+
+	surface geometry.Surface
+	grid    SpatialGrid
+	tiles   []FlockTile
+	random  *rand.Rand
+	Tick    int
+}
+
+//NewFlockWorld Returns a FlockWorld with the Boids, Predators and Obstacles placed at random
+func NewFlockWorld(settings FlockWorldSettings) FlockWorld {
+
+	if settings.Width < 1 {
+		settings.Width = 1
+	}
+
+	if settings.Height < 1 {
+		settings.Height = 1
+	}
+
+	flockWorld := FlockWorld{
+		FlockWorldSettings: settings,
+		surface:            geometry.NewSurface(0, 0, settings.Width, settings.Height, settings.Topology),
+		tiles:              make([]FlockTile, settings.Width*settings.Height),
+		random:             rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+
+	for i := 0; i < settings.NumberOfObstacles; i++ {
+		flockWorld.Obstacles = append(flockWorld.Obstacles, Obstacle{
+			Center: flockWorld.randomPosition(),
+			Radius: float64(2 + flockWorld.random.Intn(4)),
+		})
+	}
+
+	for i := 0; i < settings.NumberOfBoids+settings.NumberOfPredators; i++ {
+		flockWorld.Boids = append(flockWorld.Boids, &Boid{
+			Position:   flockWorld.randomPosition(),
+			Velocity:   geometry.VectorFromAngle(flockWorld.random.Float64()*2*math.Pi, settings.MaxSpeed),
+			IsPredator: i >= settings.NumberOfBoids,
+		})
+	}
+
+	flockWorld.rasterize()
+
+	return flockWorld
+}
+
+func (flockWorld *FlockWorld) randomPosition() geometry.Vector {
+	return geometry.NewVector(flockWorld.random.Float64()*float64(flockWorld.Width), flockWorld.random.Float64()*float64(flockWorld.Height))
+}
+
+//Surface Returns the shape of the world
+func (flockWorld *FlockWorld) Surface() *geometry.Surface {
+	return &flockWorld.surface
+}
+
+//Tile Returns what the tile shows, false if it is off the world
+func (flockWorld *FlockWorld) Tile(x int, y int) (FlockTile, bool) {
+
+	x, y, ok := flockWorld.surface.Wrap(x, y)
+
+	if !ok {
+		return EmptyFlockTile, false
+	}
+
+	return flockWorld.tiles[y*flockWorld.Width+x], true
+}
+
+//TickRate Returns the time between each Update
+func (flockWorld *FlockWorld) TickRate() time.Duration {
+	return time.Millisecond * FrameSpeedMultiplier * 3
+}
+
+//difference Returns the offset from one position to another, the shortest way round any joined edges
+func (flockWorld *FlockWorld) difference(from geometry.Vector, to geometry.Vector) geometry.Vector {
+
+	d := to.Sub(from)
+	width, height := float64(flockWorld.Width), float64(flockWorld.Height)
+
+	if flockWorld.Topology.WrapsX() {
+		d.X -= width * math.Round(d.X/width)
+	}
+
+	if flockWorld.Topology.WrapsY() {
+		d.Y -= height * math.Round(d.Y/height)
+	}
+
+	return d
+}
+
+//Update Steers every Boid by the FlockRules then moves it by its velocity
+func (flockWorld *FlockWorld) Update() bool {
+
+	rules := flockWorld.FlockRules
+
+	//The grid is kept between updates and only made again if the ViewRadius changes the size of its cells
+	if cellSize := math.Max(rules.ViewRadius, 1); flockWorld.grid.cellSize != cellSize {
+		flockWorld.grid = NewSpatialGrid(flockWorld.Dimensions, cellSize, flockWorld.Topology)
+	} else {
+		flockWorld.grid.Clear()
+	}
+
+	for i, boid := range flockWorld.Boids {
+		flockWorld.grid.Insert(i, boid.Position)
+	}
+
+	velocities := make([]geometry.Vector, len(flockWorld.Boids))
+
+	for i, boid := range flockWorld.Boids {
+
+		maxSpeed := rules.MaxSpeed
+		if boid.IsPredator {
+			maxSpeed *= predatorSpeed
+		}
+
+		steer := limit(flockWorld.steer(i, boid), rules.MaxForce)
+		velocity := limit(boid.Velocity.Add(steer), maxSpeed)
+
+		if speed := velocity.Length(); speed < maxSpeed/2 {
+			//A Boid steered to a standstill sets off the way it was steered
+			if speed == 0 {
+				velocity = steer
+			}
+			velocity = velocity.Normalize().Scale(maxSpeed / 2)
+		}
+
+		velocities[i] = velocity
+	}
+
+	for i, boid := range flockWorld.Boids {
+		boid.Velocity = velocities[i]
+		flockWorld.move(boid)
+	}
+
+	flockWorld.rasterize()
+	flockWorld.Tick++
+
+	return true
+}
+
+//steer Returns the change in velocity the rules ask of the Boid
+func (flockWorld *FlockWorld) steer(index int, boid *Boid) geometry.Vector {
+
+	rules := flockWorld.FlockRules
+
+	var separation, centre, flee, chase geometry.Vector
+	heading := boid.Velocity
+	seen := 0
+	nearestPrey := math.Inf(1)
+
+	flockWorld.grid.Near(boid.Position, rules.ViewRadius, func(i int) {
+
+		if i == index {
+			return
+		}
+
+		other := flockWorld.Boids[i]
+		offset := flockWorld.difference(boid.Position, other.Position)
+		distance := offset.Length()
+
+		if distance > rules.ViewRadius || distance == 0 {
+			return
+		}
+
+		switch {
+		case boid.IsPredator && !other.IsPredator:
+			if distance < nearestPrey {
+				nearestPrey = distance
+				chase = offset
+			}
+		case !boid.IsPredator && other.IsPredator:
+			flee = flee.Sub(offset.Scale(1 / distance))
+		case !boid.IsPredator:
+			seen++
+			heading = heading.Add(other.Velocity)
+			centre = centre.Add(offset)
+
+			if distance < rules.SeparationRadius {
+				separation = separation.Sub(offset.Scale(1 / (distance * distance)))
+			}
+		}
+	})
+
+	force := flockWorld.avoid(boid).Scale(rules.Avoidance)
+
+	if boid.IsPredator {
+		return force.Add(flockWorld.desire(chase, boid))
+	}
+
+	force = force.Add(flockWorld.desire(flee, boid).Scale(rules.Avoidance))
+	force = force.Add(flockWorld.desire(separation, boid).Scale(rules.Separation))
+
+	if seen > 0 {
+		force = force.Add(flockWorld.desire(heading, boid).Scale(rules.Alignment))
+		force = force.Add(flockWorld.desire(centre, boid).Scale(rules.Cohesion))
+	}
+
+	return force
+}
+
+//desire Returns the change in velocity that would turn the Boid to travel at full speed in the direction
+func (flockWorld *FlockWorld) desire(direction geometry.Vector, boid *Boid) geometry.Vector {
+
+	if direction.Length() == 0 {
+		return direction
+	}
+
+	return direction.Normalize().Scale(flockWorld.MaxSpeed).Sub(boid.Velocity)
+}
+
+//avoid Returns the direction away from any Obstacles and walls the Boid is close to
+func (flockWorld *FlockWorld) avoid(boid *Boid) geometry.Vector {
+
+	var away geometry.Vector
+
+	for _, obstacle := range flockWorld.Obstacles {
+
+		offset := flockWorld.difference(obstacle.Center, boid.Position)
+		gap := offset.Length() - obstacle.Radius
+
+		if gap < wallMargin {
+			away = away.Add(offset.Normalize().Scale(1 / math.Max(gap, 0.5)))
+		}
+	}
+
+	width, height := float64(flockWorld.Width), float64(flockWorld.Height)
+
+	if !flockWorld.Topology.WrapsX() {
+		if boid.Position.X < wallMargin {
+			away.X += 1 / math.Max(boid.Position.X, 0.5)
+		} else if boid.Position.X > width-wallMargin {
+			away.X -= 1 / math.Max(width-boid.Position.X, 0.5)
+		}
+	}
+
+	if !flockWorld.Topology.WrapsY() {
+		if boid.Position.Y < wallMargin {
+			away.Y += 1 / math.Max(boid.Position.Y, 0.5)
+		} else if boid.Position.Y > height-wallMargin {
+			away.Y -= 1 / math.Max(height-boid.Position.Y, 0.5)
+		}
+	}
+
+	if away.Length() == 0 {
+		return away
+	}
+
+	return away.Normalize()
+}
+
+//move Moves the Boid by its velocity, wrapping it across joined edges and stopping it at walls
+func (flockWorld *FlockWorld) move(boid *Boid) {
+
+	position := boid.Position.Add(boid.Velocity)
+	width, height := float64(flockWorld.Width), float64(flockWorld.Height)
+
+	if flockWorld.Topology.WrapsX() {
+		position.X -= width * math.Floor(position.X/width)
+	} else if position.X < 0 || position.X >= width {
+		position.X = math.Min(math.Max(position.X, 0), math.Nextafter(width, 0))
+		boid.Velocity.X = -boid.Velocity.X
+	}
+
+	if flockWorld.Topology.WrapsY() {
+		position.Y -= height * math.Floor(position.Y/height)
+	} else if position.Y < 0 || position.Y >= height {
+		position.Y = math.Min(math.Max(position.Y, 0), math.Nextafter(height, 0))
+		boid.Velocity.Y = -boid.Velocity.Y
+	}
+
+	boid.Position = position
+}
+
+//limit Returns the Vector shortened to the length if it is longer
+func limit(v geometry.Vector, length float64) geometry.Vector {
+	if v.Length() > length {
+		return v.Normalize().Scale(length)
+	}
+	return v
+}
+
+//rasterize Draws the Obstacles and Boids into the tiles. Each Boid is drawn as a short line trailing behind it so its
+//heading can be seen
+func (flockWorld *FlockWorld) rasterize() {
+
+	for i := range flockWorld.tiles {
+		flockWorld.tiles[i] = EmptyFlockTile
+	}
+
+	set := func(c geometry.Coordinates, tile FlockTile) {
+		if x, y, ok := flockWorld.surface.Wrap(c.GetX(), c.GetY()); ok {
+			flockWorld.tiles[y*flockWorld.Width+x] = tile
+		}
+	}
+
+	for _, obstacle := range flockWorld.Obstacles {
+		for _, c := range geometry.CirclePoints(obstacle.Center.Round(), int(obstacle.Radius)) {
+			set(c, ObstacleFlockTile)
+		}
+	}
+
+	for _, boid := range flockWorld.Boids {
+
+		tile := BoidFlockTile
+		if boid.IsPredator {
+			tile = PredatorFlockTile
+		}
+
+		tail := boid.Position.Sub(boid.Velocity.Normalize().Scale(2))
+
+		for _, c := range geometry.LinePoints(boid.Position.Round(), tail.Round()) {
+			set(c, tile)
+		}
+	}
+}
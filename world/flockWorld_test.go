@@ -0,0 +1,93 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestSpatialGrid_Near(t *testing.T) {
+
+	grid := NewSpatialGrid(Dimensions{Width: 100, Height: 100}, 10, geometry.Torus)
+
+	positions := []geometry.Vector{{X: 5, Y: 5}, {X: 12, Y: 5}, {X: 50, Y: 50}, {X: 98, Y: 98}}
+	for i, p := range positions {
+		grid.Insert(i, p)
+	}
+
+	found := []int{}
+	grid.Near(geometry.NewVector(2, 2), 5, func(i int) { found = append(found, i) })
+	sort.Ints(found)
+
+	want := []int{0, 1, 3}
+
+	if len(found) != len(want) {
+		t.Fatalf("SpatialGrid.Near() = %v, want %v. The point across the joined corner should be found", found, want)
+	}
+
+	for i := range want {
+		if found[i] != want[i] {
+			t.Errorf("SpatialGrid.Near() = %v, want %v", found, want)
+		}
+	}
+}
+
+func TestFlockWorld_Alignment(t *testing.T) {
+
+	rules := FlockRules{Alignment: 1, ViewRadius: 10, MaxSpeed: 1, MaxForce: 0.5}
+	flockWorld := NewFlockWorld(FlockWorldSettings{Dimensions: Dimensions{Width: 50, Height: 50}, Topology: geometry.Torus, FlockRules: rules})
+
+	flockWorld.Boids = []*Boid{
+		{Position: geometry.NewVector(25, 25), Velocity: geometry.NewVector(1, 0)},
+		{Position: geometry.NewVector(27, 25), Velocity: geometry.NewVector(0, 1)},
+	}
+
+	for i := 0; i < 10; i++ {
+		flockWorld.Update()
+	}
+
+	a, b := flockWorld.Boids[0].Velocity.Normalize(), flockWorld.Boids[1].Velocity.Normalize()
+
+	if a.Dot(b) < 0.99 {
+		t.Errorf("Boids heading %v and %v, want them to have lined up", a, b)
+	}
+
+	for _, boid := range flockWorld.Boids {
+		if speed := boid.Velocity.Length(); speed > rules.MaxSpeed+1e-9 || speed < rules.MaxSpeed/2-1e-9 {
+			t.Errorf("Boid speed = %v, want between %v and %v", speed, rules.MaxSpeed/2, rules.MaxSpeed)
+		}
+	}
+}
+
+func TestFlockWorld_FleesPredator(t *testing.T) {
+
+	rules := FlockRules{Avoidance: 1, ViewRadius: 10, MaxSpeed: 1, MaxForce: 1}
+	flockWorld := NewFlockWorld(FlockWorldSettings{Dimensions: Dimensions{Width: 50, Height: 50}, Topology: geometry.Torus, FlockRules: rules})
+
+	flockWorld.Boids = []*Boid{
+		{Position: geometry.NewVector(25, 25), Velocity: geometry.NewVector(1, 0)},
+		{Position: geometry.NewVector(28, 25), Velocity: geometry.NewVector(0, 1), IsPredator: true},
+	}
+
+	flockWorld.Update()
+
+	if flockWorld.Boids[0].Velocity.X >= 0 {
+		t.Errorf("Boid velocity = %v, want it to have turned away from the Predator", flockWorld.Boids[0].Velocity)
+	}
+}
+
+func TestFlockWorld_StaysInsideWalls(t *testing.T) {
+
+	flockWorld := NewFlockWorld(FlockWorldSettings{Dimensions: Dimensions{Width: 30, Height: 30}, FlockRules: DefaultFlockRules, NumberOfBoids: 50, NumberOfObstacles: 2})
+
+	for i := 0; i < 200; i++ {
+		flockWorld.Update()
+	}
+
+	for _, boid := range flockWorld.Boids {
+		if math.IsNaN(boid.Position.X) || !flockWorld.Surface().Contains(int(boid.Position.X), int(boid.Position.Y)) {
+			t.Errorf("Boid at %v has left the world", boid.Position)
+		}
+	}
+}
@@ -11,3 +11,18 @@ type Food struct {
 func NewPotato() Food {
 	return Food{Name: "Patato", Energy: 50}
 }
+
+//PlaceFood Inserts a potato at each of the positions in turn until number have been placed or the positions run out.
+//Returns how many of the positions were used
+func PlaceFood(inserter FoodInserterAndRemover, positions []geometry.Coordinates, number int) int {
+
+	count := 0
+
+	for ; count < number && count < len(positions); count++ {
+		pos := positions[count]
+		var food = NewPotato()
+		inserter.InsertFood(pos.GetX(), pos.GetY(), &food)
+	}
+
+	return count
+}
@@ -0,0 +1,331 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"math/bits"
+	"math/rand"
+	"time"
+)
+
+//ForestTile what is growing on a tile of a ForestFire
+type ForestTile uint8
+
+const (
+	NoTree ForestTile = iota
+	Tree
+	Burning
+	//Burnt ash left behind by a fire, trees can grow on it again
+	Burnt
+)
+
+//fireClusterSizes the number of trees burnt by each fire that has burnt out, across every ForestFire
+var fireClusterSizes = worldMetrics("forest-fire").Histogram("forestfire_cluster_size", "Number of trees burnt by each fire")
+
+//ForestFireSettings sets up a ForestFire
+type ForestFireSettings struct {
+	Dimensions
+	Seeded
+	Topology geometry.Topology
+	//GrowthChance the chance each tick that a tree grows on a tile without one, often called p
+	GrowthChance float64
+	//LightningChance the chance each tick that lightning sets a tree alight, often called f
+	LightningChance float64
+	//Density the fraction of tiles planted with a tree when the forest starts
+	Density float64
+	//Percolation instead of growing and being struck by lightning, the forest is planted at the Density and the left
+	//edge is set alight. Once the fire burns out the forest is planted again, and how often the fire reached the right
+	//edge is counted
+	Percolation bool
+}
+
+//ForestFireStats how the fires of a ForestFire have burnt
+type ForestFireStats struct {
+	//Fires the number of fires that have burnt out
+	Fires int
+	//TreesBurnt the number of trees burnt by those fires
+	TreesBurnt int
+	//ClusterSizes the number of fires that burnt between 2^i and 2^(i+1)-1 trees, at index i
+	ClusterSizes []int
+	//PercolationTrials the number of forests planted in Percolation mode that have burnt out, and Percolated how many
+	//of them the fire crossed from the left edge to the right
+	PercolationTrials int
+	Percolated        int
+}
+
+//PercolationChance Returns the fraction of the percolation trials the fire crossed the forest in
+func (stats *ForestFireStats) PercolationChance() float64 {
+
+	if stats.PercolationTrials == 0 {
+		return 0
+	}
+
+	return float64(stats.Percolated) / float64(stats.PercolationTrials)
+}
+
+//fireCluster a single fire, started by lightning or by hand, and the trees it has burnt
+type fireCluster struct {
+	burning int
+	size    int
+}
+
+//ForestFire a forest fire model. Trees grow on empty tiles, lightning sets trees alight and fire spreads from a
+//burning tree to the trees above, below and either side of it before burning out
+type ForestFire struct {
+	ForestFireSettings
+	ForestFireStats
+	Tick int
+
+	surface geometry.Surface
+	tiles   []ForestTile
+	next    []ForestTile
+	//fires the fire burning each tile is part of
+	fires    []int
+	clusters map[int]*fireCluster
+	nextFire int
+	random   *rand.Rand
+}
+
+//NewForestFire Returns a ForestFire planted at the Density. In Percolation mode the left edge is already alight
+func NewForestFire(settings ForestFireSettings) ForestFire {
+
+	if settings.Width < 1 {
+		settings.Width = 1
+	}
+
+	if settings.Height < 1 {
+		settings.Height = 1
+	}
+
+	random := settings.random()
+
+	//Fire wrapping round from the right edge to the left would make crossing the forest meaningless
+	if settings.Percolation {
+		settings.Topology = geometry.Bounded
+	}
+
+	cells := settings.Width * settings.Height
+
+	forestFire := ForestFire{
+		ForestFireSettings: settings,
+		surface:            geometry.NewSurface(0, 0, settings.Width, settings.Height, settings.Topology),
+		tiles:              make([]ForestTile, cells),
+		next:               make([]ForestTile, cells),
+		fires:              make([]int, cells),
+		clusters:           map[int]*fireCluster{},
+		random:             random,
+	}
+
+	forestFire.plant()
+
+	return forestFire
+}
+
+//plant Clears the forest and plants trees at the Density. In Percolation mode the trees on the left edge are set alight
+func (forestFire *ForestFire) plant() {
+
+	for i := range forestFire.tiles {
+		forestFire.tiles[i] = NoTree
+		if forestFire.random.Float64() < forestFire.Density {
+			forestFire.tiles[i] = Tree
+		}
+	}
+
+	forestFire.clusters = map[int]*fireCluster{}
+
+	if forestFire.Percolation {
+		fire := forestFire.startFire()
+		for y := 0; y < forestFire.Height; y++ {
+			forestFire.ignite(y*forestFire.Width, fire)
+		}
+	}
+}
+
+//Surface Returns the shape of the forest
+func (forestFire *ForestFire) Surface() *geometry.Surface {
+	return &forestFire.surface
+}
+
+//index Returns where the tile is once it has been wrapped across any joined edges, false if it is off the forest
+func (forestFire *ForestFire) index(x int, y int) (int, bool) {
+
+	x, y, ok := forestFire.surface.Wrap(x, y)
+
+	if !ok {
+		return 0, false
+	}
+
+	return y*forestFire.Width + x, true
+}
+
+//Tile Returns what is on the tile at x and y, false if it is off the forest
+func (forestFire *ForestFire) Tile(x int, y int) (ForestTile, bool) {
+
+	if i, ok := forestFire.index(x, y); ok {
+		return forestFire.tiles[i], true
+	}
+
+	return NoTree, false
+}
+
+//Count Returns the number of tiles with the ForestTile on them
+func (forestFire *ForestFire) Count(tile ForestTile) int {
+
+	count := 0
+
+	for _, t := range forestFire.tiles {
+		if t == tile {
+			count++
+		}
+	}
+
+	return count
+}
+
+//Ignite Starts a new fire at x and y. Returns false if there is no tree there, or in Percolation mode where only the
+//fire lit along the left edge may burn so that Crossed means it spread all the way over
+func (forestFire *ForestFire) Ignite(x int, y int) bool {
+
+	i, ok := forestFire.index(x, y)
+
+	if !ok || forestFire.Percolation || forestFire.tiles[i] != Tree {
+		return false
+	}
+
+	forestFire.ignite(i, forestFire.startFire())
+
+	return true
+}
+
+//startFire Returns the id of a new fire
+func (forestFire *ForestFire) startFire() int {
+	forestFire.nextFire++
+	forestFire.clusters[forestFire.nextFire] = &fireCluster{}
+	return forestFire.nextFire
+}
+
+//ignite Sets the tree alight as part of the fire
+func (forestFire *ForestFire) ignite(i int, fire int) {
+
+	if forestFire.tiles[i] != Tree {
+		return
+	}
+
+	forestFire.tiles[i] = Burning
+	forestFire.fires[i] = fire
+	forestFire.clusters[fire].burning++
+	forestFire.clusters[fire].size++
+}
+
+//burningNeighbour Returns the fire burning next to the tile, false if none of the tiles next to it are burning
+func (forestFire *ForestFire) burningNeighbour(x int, y int) (int, bool) {
+
+	for _, n := range [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
+		if i, ok := forestFire.index(x+n[0], y+n[1]); ok && forestFire.tiles[i] == Burning {
+			return forestFire.fires[i], true
+		}
+	}
+
+	return 0, false
+}
+
+//Update Burns out the burning trees, spreads the fire to the trees next to them, then grows new trees and strikes
+//trees with lightning. In Percolation mode a forest is planted again once its fire has burnt out
+func (forestFire *ForestFire) Update() bool {
+
+	width := forestFire.Width
+	ignited := []int{}
+
+	for y := 0; y < forestFire.Height; y++ {
+		for x := 0; x < width; x++ {
+
+			i := y*width + x
+			tile := forestFire.tiles[i]
+
+			switch tile {
+			case Burning:
+				tile = Burnt
+				forestFire.clusters[forestFire.fires[i]].burning--
+			case Tree:
+				if fire, ok := forestFire.burningNeighbour(x, y); ok {
+					ignited = append(ignited, i, fire)
+				} else if !forestFire.Percolation && forestFire.random.Float64() < forestFire.LightningChance {
+					ignited = append(ignited, i, 0)
+				}
+			case NoTree, Burnt:
+				if !forestFire.Percolation && forestFire.random.Float64() < forestFire.GrowthChance {
+					tile = Tree
+				}
+			}
+
+			forestFire.next[i] = tile
+		}
+	}
+
+	forestFire.tiles, forestFire.next = forestFire.next, forestFire.tiles
+
+	for j := 0; j < len(ignited); j += 2 {
+		fire := ignited[j+1]
+		if fire == 0 {
+			fire = forestFire.startFire()
+		}
+		forestFire.ignite(ignited[j], fire)
+	}
+
+	forestFire.recordBurntOut()
+	forestFire.Tick++
+
+	if forestFire.Percolation && len(forestFire.clusters) == 0 {
+		forestFire.PercolationTrials++
+		if forestFire.Crossed() {
+			forestFire.Percolated++
+		}
+		forestFire.plant()
+	}
+
+	return true
+}
+
+//TickRate Returns the time between each Update
+func (forestFire *ForestFire) TickRate() time.Duration {
+	return time.Millisecond * FrameSpeedMultiplier * 2
+}
+
+//recordBurntOut Adds the size of every fire that has burnt out to the ClusterSizes
+func (forestFire *ForestFire) recordBurntOut() {
+
+	for fire, cluster := range forestFire.clusters {
+
+		if cluster.burning > 0 {
+			continue
+		}
+
+		delete(forestFire.clusters, fire)
+
+		if cluster.size == 0 {
+			continue
+		}
+
+		bin := bits.Len(uint(cluster.size)) - 1
+		for len(forestFire.ClusterSizes) <= bin {
+			forestFire.ClusterSizes = append(forestFire.ClusterSizes, 0)
+		}
+
+		forestFire.ClusterSizes[bin]++
+		forestFire.Fires++
+		forestFire.TreesBurnt += cluster.size
+		fireClusterSizes.Record(int64(cluster.size))
+	}
+}
+
+//Crossed Returns true if fire has reached the right edge of the forest, only meaningful in Percolation mode where
+//every tile that burns was reached from the left edge
+func (forestFire *ForestFire) Crossed() bool {
+
+	for y := 0; y < forestFire.Height; y++ {
+		if tile := forestFire.tiles[y*forestFire.Width+forestFire.Width-1]; tile == Burning || tile == Burnt {
+			return true
+		}
+	}
+
+	return false
+}
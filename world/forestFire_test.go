@@ -0,0 +1,100 @@
+package world
+
+import "testing"
+
+func TestForestFire_FireBurnsCluster(t *testing.T) {
+
+	forestFire := NewForestFire(ForestFireSettings{Dimensions: Dimensions{Width: 10, Height: 10}, Density: 1, Seeded: Seeded{Seed: 1}})
+
+	if !forestFire.Ignite(5, 5) {
+		t.Fatalf("ForestFire.Ignite() did not set the tree alight")
+	}
+
+	for i := 0; i < 30; i++ {
+		forestFire.Update()
+	}
+
+	if trees := forestFire.Count(Tree); trees != 0 {
+		t.Errorf("%d trees were left in a full forest, want them all burnt", trees)
+	}
+
+	if forestFire.Fires != 1 || forestFire.TreesBurnt != 100 {
+		t.Errorf("Fires = %d burning %d trees, want 1 fire burning 100", forestFire.Fires, forestFire.TreesBurnt)
+	}
+
+	//100 trees is between 2^6 and 2^7
+	if len(forestFire.ClusterSizes) != 7 || forestFire.ClusterSizes[6] != 1 {
+		t.Errorf("ClusterSizes = %v, want a single fire in bin 6", forestFire.ClusterSizes)
+	}
+}
+
+func TestForestFire_GrowthAndLightning(t *testing.T) {
+
+	forestFire := NewForestFire(ForestFireSettings{Dimensions: Dimensions{Width: 10, Height: 10}, GrowthChance: 1, Seeded: Seeded{Seed: 1}})
+	forestFire.Update()
+
+	if trees := forestFire.Count(Tree); trees != 100 {
+		t.Errorf("%d trees grew with a growth chance of 1, want 100", trees)
+	}
+
+	forestFire.LightningChance = 1
+	forestFire.Update()
+
+	if burning := forestFire.Count(Burning); burning != 100 {
+		t.Errorf("%d trees were struck by lightning with a chance of 1, want 100", burning)
+	}
+
+	if forestFire.Ignite(0, 0) {
+		t.Errorf("ForestFire.Ignite() set alight a tree that was already burning")
+	}
+}
+
+func TestForestFire_Percolation(t *testing.T) {
+
+	for _, test := range []struct {
+		density float64
+		crosses bool
+	}{
+		{1, true},
+		{0.3, false},
+	} {
+
+		forestFire := NewForestFire(ForestFireSettings{
+			Dimensions:  Dimensions{Width: 50, Height: 50},
+			Density:     test.density,
+			Percolation: true,
+			Seeded:      Seeded{Seed: 1},
+		})
+
+		for forestFire.PercolationTrials < 5 {
+			forestFire.Update()
+		}
+
+		want := 0
+		if test.crosses {
+			want = forestFire.PercolationTrials
+		}
+
+		if forestFire.Percolated != want {
+			t.Errorf("Density %v percolated %d times in %d trials, want %d", test.density, forestFire.Percolated, forestFire.PercolationTrials, want)
+		}
+	}
+}
+
+func TestForestFire_PercolationIgnoresIgnite(t *testing.T) {
+
+	forestFire := NewForestFire(ForestFireSettings{
+		Dimensions:  Dimensions{Width: 10, Height: 10},
+		Density:     1,
+		Percolation: true,
+		Seeded:      Seeded{Seed: 1},
+	})
+
+	if forestFire.Ignite(9, 5) {
+		t.Errorf("ForestFire.Ignite() set the right edge alight in Percolation mode")
+	}
+
+	if forestFire.Crossed() {
+		t.Errorf("ForestFire.Crossed() = true before the fire from the left edge has spread")
+	}
+}
@@ -28,6 +28,11 @@ type Gopher struct {
 
 	Gender Gender
 
+	//Infection how far through the disease of its world the Gopher is, and InfectionTimer how many ticks it has been
+	//in that state
+	Infection      InfectionState
+	InfectionTimer int
+
 	FoodTargets   []geometry.Coordinates
 	GopherTargets []geometry.Coordinates
 	MovementPath  []geometry.Coordinates
@@ -151,12 +156,72 @@ type GopherActor struct {
 	MoveableGophers
 	ActorGeneration
 	GopherBirthRate int
+
+	//Disease how an infection spreads between gophers and what it does to them
+	Disease Disease
+
+	//Movement how gophers step from tile to tile, they head the shortest way to their targets
+	Movement Movement
+}
+
+//Movement how gophers step between the tiles of a world
+type Movement interface {
+	IsInRange(c geometry.Coordinates, c2 geometry.Coordinates, maxX int, maxY int) bool
+	FindNextStep(start geometry.Coordinates, end geometry.Coordinates) (int, int)
+	RandomStep() (int, int)
+}
+
+//SquareMovement gophers step onto any of the 8 tiles around them, the shortest way round the Surface
+type SquareMovement struct {
+	*geometry.Surface
+}
+
+//RandomStep Returns a step onto one of the tiles around the gopher, or no step at all
+func (m SquareMovement) RandomStep() (int, int) {
+	return rand.Intn(3) - 1, rand.Intn(3) - 1
+}
+
+//HexMovement gophers step onto any of the 6 hexagons around them. Positions are axial hex coordinates
+type HexMovement struct{}
+
+//IsInRange Checks if one position is within the larger of maxX and maxY steps of the other
+func (m HexMovement) IsInRange(c geometry.Coordinates, c2 geometry.Coordinates, maxX int, maxY int) bool {
+
+	max := maxX
+	if maxY > max {
+		max = maxY
+	}
+
+	return geometry.HexDistance(geometry.HexFromCoordinates(c), geometry.HexFromCoordinates(c2)) <= max
+}
+
+//FindNextStep Returns the step to the neighbouring hexagon along the line from start to end
+func (m HexMovement) FindNextStep(start geometry.Coordinates, end geometry.Coordinates) (int, int) {
+	step := geometry.HexFromCoordinates(start).NextStep(geometry.HexFromCoordinates(end))
+	return step.Q, step.R
+}
+
+//RandomStep Returns a step onto one of the hexagons around the gopher, or no step at all
+func (m HexMovement) RandomStep() (int, int) {
+
+	direction := rand.Intn(len(geometry.HexDirections) + 1)
+
+	if direction == len(geometry.HexDirections) {
+		return 0, 0
+	}
+
+	return geometry.HexDirections[direction].Q, geometry.HexDirections[direction].R
 }
 
 func (actor *GopherActor) Update(gopher *Gopher) {
+
+	actor.handleDisease(gopher)
+
 	switch {
 	case gopher.IsDead:
 		gopher.Decay++
+	case actor.Disease.isTooIllToMove(gopher):
+		//The gopher rests this tick
 	case gopher.IsHungry:
 		actor.handleHunger(gopher)
 	case !gopher.IsHungry:
@@ -171,11 +236,11 @@ func (actor *GopherActor) Update(gopher *Gopher) {
 
 					target := gopher.GopherTargets[0]
 
-					if gopher.Position.IsInRange(target, 1, 1) {
+					if actor.Movement.IsInRange(gopher.Position, target, 1, 1) {
 						actor.QueueMating(gopher, target)
 						break
 					}
-					moveX, moveY := geometry.FindNextStep(gopher.Position, target)
+					moveX, moveY := actor.Movement.FindNextStep(gopher.Position, target)
 					actor.QueueGopherMove(moveX, moveY, gopher)
 					gopher.ClearFoodTargets()
 				}
@@ -210,13 +275,13 @@ func (actor *GopherActor) moveTowardsFood(gopher *Gopher) {
 
 		if _, ok := actor.HasFood(target.GetX(), target.GetY()); ok {
 
-			if gopher.Position.IsInRange(target, 0, 0) {
+			if actor.Movement.IsInRange(gopher.Position, target, 0, 0) {
 				actor.QueuePickUpFood(gopher)
 				gopher.ClearFoodTargets()
 				return
 			}
 
-			moveX, moveY := geometry.FindNextStep(gopher.Position, target)
+			moveX, moveY := actor.Movement.FindNextStep(gopher.Position, target)
 			actor.QueueGopherMove(moveX, moveY, gopher)
 		} else {
 			gopher.ClearFoodTargets()
@@ -230,7 +295,7 @@ func (actor *GopherActor) moveTowardsFood(gopher *Gopher) {
 
 //Wander Randomly decides a diretion for the gopher to move in
 func (actor *GopherActor) Wander(gopher *Gopher) {
-	x, y := rand.Intn(3)-1, rand.Intn(3)-1
+	x, y := actor.Movement.RandomStep()
 	actor.QueueGopherMove(x, y, gopher)
 }
 
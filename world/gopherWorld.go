@@ -11,9 +11,19 @@ import (
 type GopherWorldSettings struct {
 	Dimensions
 	Population
+	Partition
 
 	GopherBirthRate int
 	NumberOfFood    int
+
+	//Disease how an infection spreads between the gophers once a patient zero is infected
+	Disease Disease
+
+	//Topology how the edges of the world are joined together
+	Topology geometry.Topology
+
+	//LineOfSight gophers only find what they can see, other gophers hide what is behind them
+	LineOfSight bool
 }
 
 //GopherWorld A map for Gophers!
@@ -32,16 +42,33 @@ type GopherWorld struct {
 
 	Actor *GopherActor
 
+	//Rebalancer resizes the partitions of the World as it fills up or empties, if it is nil they are left alone
+	Rebalancer Rebalancer
+
 	GopherWaitGroup *sync.WaitGroup
 	IsPaused        bool
 	SelectedGopher  *Gopher
 	diagnostics     Diagnostics
 
+	//PatientZero the Gopher the latest outbreak started with, and EpidemicCounts how many gophers were in each
+	//InfectionState for the last ticks
+	PatientZero    *Gopher
+	EpidemicCounts []EpidemicCount
+
 	NumberOfGophers int
+	frame           int
 
 	*GopherWorldSettings
 }
 
+//rebalanceInterval the number of updates between each time a GopherWorld checks if its partitions need resizing
+const rebalanceInterval = 30
+
+//Rebalancer a container that resizes its partitions to suit how many entities it holds
+type Rebalancer interface {
+	Rebalance() bool
+}
+
 type GopherWorldTile struct {
 	Gopher *Gopher
 	Food   *Food
@@ -82,10 +109,11 @@ func (tile *GopherWorldTile) ClearFood() {
 	tile.Food = nil
 }
 
-//NewGopherWorld Creates a new GopherWorld a GopherWorld contains food and gophers and can use different actors to update the state of the map
-func NewGopherWorld(settings *GopherWorldSettings, s GopherWorldSearcher, t TileContainer, g GopherContainer, f FoodContainer, ig GopherInserterAndRemover, iff FoodInserterAndRemover) GopherWorld {
+//NewGopherWorld Creates a new GopherWorld a GopherWorld contains food and gophers and can use different actors to update the state of the map.
+//Its metrics are kept under the name
+func NewGopherWorld(name string, settings *GopherWorldSettings, s GopherWorldSearcher, t TileContainer, g GopherContainer, f FoodContainer, ig GopherInserterAndRemover, iff FoodInserterAndRemover) GopherWorld {
 
-	qa := NewFiniteActionQueue(settings.MaxPopulation * 2)
+	qa := NewFiniteActionQueue(settings.MaxPopulation*2, name)
 
 	gsac := GopherSliceAndChannel{
 		ActiveActors: make(chan *Gopher, settings.MaxPopulation*2),
@@ -98,6 +126,10 @@ func NewGopherWorld(settings *GopherWorldSettings, s GopherWorldSearcher, t Tile
 		GopherSliceAndChannel:    &gsac,
 	}
 
+	if settings.LineOfSight {
+		s = &VisibleTileSearch{GopherWorldSearcher: s, TileContainer: t, Opaque: blocksSight}
+	}
+
 	var wg sync.WaitGroup
 
 	return GopherWorld{
@@ -114,6 +146,7 @@ func NewGopherWorld(settings *GopherWorldSettings, s GopherWorldSearcher, t Tile
 		GopherSliceAndChannel: &gsac,
 
 		GopherWaitGroup: &wg,
+		diagnostics:     NewDiagnostics(name),
 
 		GopherWorldSettings: settings,
 
@@ -123,11 +156,22 @@ func NewGopherWorld(settings *GopherWorldSettings, s GopherWorldSearcher, t Tile
 }
 
 func CreateGopherWorldSpiralSearch(settings GopherWorldSettings) *GopherWorld {
+	return createGopherWorldSpiralSearch("spiral-search", settings)
+}
+
+//CreateGopherWorldFireWorks Creates a GopherWorld that searches like CreateGopherWorldSpiralSearch, with its metrics kept
+//apart from it
+func CreateGopherWorldFireWorks(settings GopherWorldSettings) *GopherWorld {
+	return createGopherWorldSpiralSearch("fireworks", settings)
+}
+
+func createGopherWorldSpiralSearch(name string, settings GopherWorldSettings) *GopherWorld {
 
 	b2dc := NewBasic2DContainer(0, 0, settings.Width, settings.Height)
+	b2dc.SetTopology(settings.Topology)
 	sts := SpiralTileSearch{TileContainer: &b2dc}
 
-	gw := NewGopherWorld(&settings, &sts, &b2dc, &b2dc, &b2dc, &b2dc, &b2dc)
+	gw := NewGopherWorld(name, &settings, &sts, &b2dc, &b2dc, &b2dc, &b2dc, &b2dc)
 
 	gw.setUpTiles()
 	return &gw
@@ -136,30 +180,76 @@ func CreateGopherWorldSpiralSearch(settings GopherWorldSettings) *GopherWorld {
 
 func CreateGopherWorldGridPartition(settings GopherWorldSettings) *GopherWorld {
 
-	gridWidth := 5
-	gridHeight := 5
-
 	gc := NewBasicGridContainer(settings.Width,
 		settings.Height,
-		gridWidth,
-		gridHeight,
+		settings.PartitionWidth,
+		settings.PartitionHeight,
 	)
+	gc.SetTopology(settings.Topology)
 
 	search := GridTileSearch{
 		BasicGridContainer: &gc,
 	}
 
-	tileMap := NewGopherWorld(&settings, &search, &gc, &gc, &gc, &gc, &gc)
+	tileMap := NewGopherWorld("partition", &settings, &search, &gc, &gc, &gc, &gc, &gc)
+
+	if settings.AdaptivePartitions {
+		tileMap.Rebalancer = &gc
+	}
 
 	tileMap.setUpTiles()
 	return &tileMap
 }
 
+//CreateGopherWorldIndexedSearch Creates a GopherWorld where gophers find food and partners using a QuadTree index
+func CreateGopherWorldIndexedSearch(settings GopherWorldSettings) *GopherWorld {
+
+	b2dc := NewBasic2DContainer(0, 0, settings.Width, settings.Height)
+	b2dc.SetTopology(settings.Topology)
+	search := NewIndexedTileSearch(&b2dc, &b2dc, 0, 0, settings.Width, settings.Height)
+
+	gw := NewGopherWorld("indexed-search", &settings, &search, &b2dc, &b2dc, &b2dc, &search, &search)
+
+	gw.setUpTiles()
+	return &gw
+}
+
+//CreateGopherWorldInfinite Creates a GopherWorld with no edges. Gophers and food start inside the width and height of
+//the settings and can spread outwards forever, only the chunks of the world they are in are kept in memory
+func CreateGopherWorldInfinite(settings GopherWorldSettings) *GopherWorld {
+
+	cc := NewChunkedContainer()
+	sts := SpiralTileSearch{TileContainer: &cc}
+
+	gw := NewGopherWorld("infinite", &settings, &sts, &cc, &cc, &cc, &cc, &cc)
+
+	gw.setUpTiles()
+	return &gw
+}
+
+//CreateGopherWorldHex Creates a GopherWorld of hexagons where gophers step onto any of the 6 hexagons around them
+func CreateGopherWorldHex(settings GopherWorldSettings) *GopherWorld {
+
+	hc := NewHexContainer(settings.Width, settings.Height)
+	search := HexTileSearch{TileContainer: &hc}
+
+	gw := NewGopherWorld("hex", &settings, &search, &hc, &hc, &hc, &hc, &hc)
+
+	gw.setUpTiles()
+	return &gw
+}
+
 func (gw *GopherWorld) setUpTiles() {
 
 	keys := geometry.GenerateRandomizedCoordinateArray(0, 0,
 		gw.Width, gw.Height)
 
+	if oc, ok := gw.TileContainer.(OffsetContainer); ok {
+		for i, key := range keys {
+			keys[i] = oc.FromOffset(key.X, key.Y)
+		}
+	}
+
 	count := 0
 
 	for i := 0; i < gw.InitialPopulation; i++ {
@@ -180,6 +270,7 @@ func (gw *GopherWorld) setUpTiles() {
 
 	actor := GopherActor{
 		GopherBirthRate:     gw.GopherBirthRate,
+		Disease:             gw.Disease,
 		ActionQueuer:        gw.ActionQueuer,
 		GopherWorldSearcher: gw.GopherWorldSearcher,
 		GopherContainer:     gw.GopherContainer,
@@ -187,17 +278,12 @@ func (gw *GopherWorld) setUpTiles() {
 		FoodPicker:          gw,
 		MoveableGophers:     gw,
 		ActorGeneration:     gw.GopherGeneration,
+		Movement:            gw.Movement(),
 	}
 
 	gw.Actor = &actor
 
-	for i := 0; i < gw.NumberOfFood; i++ {
-		pos := keys[count]
-		var food = NewPotato()
-		gw.InsertFood(pos.GetX(), pos.GetY(), &food)
-		count++
-	}
-
+	PlaceFood(gw, keys[count:], gw.NumberOfFood)
 }
 
 //SelectEntity Uses the given co-ordinates to select and return a gopher in the GopherWorld
@@ -238,6 +324,21 @@ func (gw *GopherWorld) UnSelectGopher() {
 	gw.SelectedGopher = nil
 }
 
+//Surface Returns how the edges of the world are joined together
+func (gw *GopherWorld) Surface() *geometry.Surface {
+	return surfaceOf(gw.TileContainer)
+}
+
+//Movement Returns how gophers step between the tiles of the world
+func (gw *GopherWorld) Movement() Movement {
+
+	if _, ok := gw.TileContainer.(*HexContainer); ok {
+		return HexMovement{}
+	}
+
+	return SquareMovement{Surface: gw.Surface()}
+}
+
 func (gw *GopherWorld) Diagnostics() *Diagnostics {
 	return &gw.diagnostics
 }
@@ -326,12 +427,24 @@ func (gw *GopherWorld) Update() bool {
 		gw.diagnostics.GlobalStopWatch.Start()
 	}
 
+	if gw.diagnostics.TickStopWatch.IsStarted() {
+		gw.diagnostics.TickStopWatch.Stop()
+	}
+	gw.diagnostics.TickStopWatch.Start()
+
 	gw.diagnostics.ProcessStopWatch.Start()
 	gw.processGophers()
 
 	gw.processQueuedTasks()
 
+	if gw.Rebalancer != nil && gw.frame%rebalanceInterval == 0 {
+		gw.Rebalancer.Rebalance()
+	}
+	gw.countInfections()
+	gw.frame++
+
 	gw.NumberOfGophers = len(gw.ActiveActors)
+	gw.diagnostics.GophersAlive.Set(int64(gw.NumberOfGophers))
 
 	gw.diagnostics.ProcessStopWatch.Stop()
 
@@ -393,7 +506,8 @@ func (spiralTileSearch *SpiralTileSearch) Search(position geometry.Coordinates,
 
 	var coordsArray = []geometry.Coordinates{}
 
-	spiral := geometry.NewSpiral(width, height)
+	surface := surfaceOf(spiralTileSearch.TileContainer)
+	spiral := geometry.NewSpiral(surface.SearchSize(width, height))
 
 	var query TileQuery
 
@@ -418,12 +532,13 @@ func (spiralTileSearch *SpiralTileSearch) Search(position geometry.Coordinates,
 
 		if tile, ok := spiralTileSearch.Tile(relativeCoords.GetX(), relativeCoords.GetY()); ok {
 			if query(tile) {
-				coordsArray = append(coordsArray, relativeCoords)
+				x, y := wrap(spiralTileSearch.TileContainer, relativeCoords.GetX(), relativeCoords.GetY())
+				coordsArray = append(coordsArray, geometry.Coordinates{X: x, Y: y})
 			}
 		}
 	}
 
-	geometry.SortByNearestFromCoordinate(position, coordsArray)
+	surface.SortByNearest(position, coordsArray)
 
 	return coordsArray
 }
@@ -434,21 +549,31 @@ type GridTileSearch struct {
 
 func (searcher *GridTileSearch) Search(position geometry.Coordinates, width int, height int, maximumFind int, searchType SearchType) []geometry.Coordinates {
 
-	x, y := position.GetX(), position.GetY()
-
-	var locations []geometry.Coordinates
+	var query func(*GridTileSearch, int, int, int, int) []geometry.Coordinates
 
 	switch searchType {
 	case SearchForFood:
-		locations = queryForFood(searcher, width, height, x, y)
+		query = queryForFood
 	case SearchForFemaleGopher:
-		locations = queryForFemalePartner(searcher, width, height, x, y)
+		query = queryForFemalePartner
 	case SearchForEmptySpace:
 		sts := SpiralTileSearch{TileContainer: searcher.BasicGridContainer}
 		return sts.Search(position, width, height, maximumFind, searchType)
 	}
 
-	geometry.SortByNearestFromCoordinate(position, locations)
+	surface := searcher.Surface()
+
+	//The grid is searched width and height either side of the position, across each joined edge
+	searchWidth, searchHeight := surface.SearchSize(width*2, height*2)
+	width, height = searchWidth/2, searchHeight/2
+
+	var locations []geometry.Coordinates
+
+	for _, offset := range surface.Offsets() {
+		locations = append(locations, query(searcher, width, height, position.GetX()+offset.X, position.GetY()+offset.Y)...)
+	}
+
+	surface.SortByNearest(position, locations)
 
 	if len(locations) >= maximumFind {
 		return locations[:maximumFind]
@@ -64,3 +64,28 @@ func TestPartitionGopherWorld_RemoveGopher(t *testing.T) {
 		t.Errorf("Gopher is not removed")
 	}
 }
+
+func TestGopherWorld_DiagnosticsPerWorld(t *testing.T) {
+
+	settings := GopherWorldSettings{
+		Dimensions: Dimensions{Width: 10, Height: 10},
+		Population: Population{InitialPopulation: 5, MaxPopulation: 100},
+	}
+
+	hex := CreateGopherWorldHex(settings)
+	spiral := CreateGopherWorldSpiralSearch(settings)
+
+	ticks := hex.Diagnostics().TickStopWatch.Histogram.Count()
+
+	for i := 0; i < 3; i++ {
+		hex.Update()
+	}
+
+	if recorded := hex.Diagnostics().TickStopWatch.Histogram.Count() - ticks; recorded != 2 {
+		t.Errorf("3 updates recorded %d tick durations, want 2", recorded)
+	}
+
+	if hex.Diagnostics().ProcessStopWatch.Histogram == spiral.Diagnostics().ProcessStopWatch.Histogram {
+		t.Errorf("Hex and spiral search GopherWorlds share the same metrics, want them kept apart")
+	}
+}
@@ -0,0 +1,335 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+)
+
+//GrayScottPreset a named pair of feed and kill rates known to grow an interesting pattern
+type GrayScottPreset int
+
+const (
+	//CustomPreset uses the Feed and Kill of the GrayScottSettings as they are
+	CustomPreset GrayScottPreset = iota
+	Mitosis
+	Coral
+	Spots
+	Worms
+	Labyrinth
+	Holes
+	Chaos
+)
+
+//grayScottPresetRates the feed and kill rates of each GrayScottPreset
+var grayScottPresetRates = map[GrayScottPreset][2]float64{
+	Mitosis:   {0.0367, 0.0649},
+	Coral:     {0.0545, 0.062},
+	Spots:     {0.03, 0.062},
+	Worms:     {0.058, 0.065},
+	Labyrinth: {0.029, 0.057},
+	Holes:     {0.039, 0.058},
+	Chaos:     {0.026, 0.051},
+}
+
+func (preset GrayScottPreset) String() string {
+	switch preset {
+	case Mitosis:
+		return "Mitosis"
+	case Coral:
+		return "Coral"
+	case Spots:
+		return "Spots"
+	case Worms:
+		return "Worms"
+	case Labyrinth:
+		return "Labyrinth"
+	case Holes:
+		return "Holes"
+	case Chaos:
+		return "Chaos"
+	}
+	return "Custom"
+}
+
+//Rates Returns the feed and kill rates of the preset, false for the CustomPreset
+func (preset GrayScottPreset) Rates() (float64, float64, bool) {
+	rates, ok := grayScottPresetRates[preset]
+	return rates[0], rates[1], ok
+}
+
+//GrayScottSettings sets up a GrayScott
+type GrayScottSettings struct {
+	Dimensions
+	Seeded
+	Topology geometry.Topology
+	//Preset replaces the Feed and Kill with its own rates unless it is the CustomPreset
+	Preset GrayScottPreset
+	//Feed how quickly A is added back to the grid, often called f
+	Feed float64
+	//Kill how quickly B is removed from the grid, often called k
+	Kill float64
+	//DiffusionA and DiffusionB how quickly each chemical spreads out, B is usually half as fast as A
+	DiffusionA float64
+	DiffusionB float64
+	//StepsPerUpdate the number of times the equations are stepped forward each Update
+	StepsPerUpdate int
+}
+
+//GrayScott a reaction diffusion model of two chemicals, A and B, whose concentrations are held on a grid. A is fed
+//in, B turns A into more B and is killed off, and both spread out to their neighbours. The rows are split into stripes
+//that are updated in parallel
+type GrayScott struct {
+	GrayScottSettings
+	Step int
+
+	surface geometry.Surface
+	a       []float64
+	b       []float64
+	nextA   []float64
+	nextB   []float64
+	stripes int
+	random  *rand.Rand
+}
+
+//NewGrayScott Returns a GrayScott full of A with a few patches of B dropped into it
+func NewGrayScott(settings GrayScottSettings) GrayScott {
+
+	if settings.Width < 1 {
+		settings.Width = 1
+	}
+
+	if settings.Height < 1 {
+		settings.Height = 1
+	}
+
+	random := settings.random()
+
+	if feed, kill, ok := settings.Preset.Rates(); ok {
+		settings.Feed, settings.Kill = feed, kill
+	}
+
+	if settings.StepsPerUpdate < 1 {
+		settings.StepsPerUpdate = 1
+	}
+
+	stripes := runtime.NumCPU()
+	if stripes > settings.Height {
+		stripes = settings.Height
+	}
+
+	cells := settings.Width * settings.Height
+
+	grayScott := GrayScott{
+		GrayScottSettings: settings,
+		surface:           geometry.NewSurface(0, 0, settings.Width, settings.Height, settings.Topology),
+		a:                 make([]float64, cells),
+		b:                 make([]float64, cells),
+		nextA:             make([]float64, cells),
+		nextB:             make([]float64, cells),
+		stripes:           stripes,
+		random:            random,
+	}
+
+	for i := range grayScott.a {
+		grayScott.a[i] = 1
+	}
+
+	patches := cells/2000 + 1
+	for i := 0; i < patches; i++ {
+		grayScott.AddB(grayScott.random.Intn(settings.Width), grayScott.random.Intn(settings.Height), 5)
+	}
+
+	return grayScott
+}
+
+//Surface Returns the shape of the GrayScott
+func (grayScott *GrayScott) Surface() *geometry.Surface {
+	return &grayScott.surface
+}
+
+//index Returns where the cell is once it has been wrapped across any joined edges, false if it is off the grid
+func (grayScott *GrayScott) index(x int, y int) (int, bool) {
+
+	x, y, ok := grayScott.surface.Wrap(x, y)
+
+	if !ok {
+		return 0, false
+	}
+
+	return y*grayScott.Width + x, true
+}
+
+//Concentrations Returns the amount of A and B in the cell, false if it is off the grid
+func (grayScott *GrayScott) Concentrations(x int, y int) (float64, float64, bool) {
+
+	if i, ok := grayScott.index(x, y); ok {
+		return grayScott.a[i], grayScott.b[i], true
+	}
+
+	return 0, 0, false
+}
+
+//ScalarValue Returns the amount of B in the cell, false if it is off the grid
+func (grayScott *GrayScott) ScalarValue(x int, y int) (float64, bool) {
+	_, b, ok := grayScott.Concentrations(x, y)
+	return b, ok
+}
+
+//AddB Turns half of the A in the square of cells within the radius of x and y into B
+func (grayScott *GrayScott) AddB(x int, y int, radius int) {
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			if i, ok := grayScott.index(x+dx, y+dy); ok {
+				grayScott.a[i] = 0.5
+				grayScott.b[i] = 0.25
+			}
+		}
+	}
+}
+
+//TotalB Returns the amount of B across the whole grid
+func (grayScott *GrayScott) TotalB() float64 {
+
+	total := 0.0
+	for _, b := range grayScott.b {
+		total += b
+	}
+
+	return total
+}
+
+//RangeB Returns the smallest and largest amount of B in any cell
+func (grayScott *GrayScott) RangeB() (float64, float64) {
+
+	min, max := grayScott.b[0], grayScott.b[0]
+
+	for _, b := range grayScott.b {
+		if b < min {
+			min = b
+		}
+		if b > max {
+			max = b
+		}
+	}
+
+	return min, max
+}
+
+//Update Steps the equations forward StepsPerUpdate times
+func (grayScott *GrayScott) Update() bool {
+
+	for i := 0; i < grayScott.StepsPerUpdate; i++ {
+		grayScott.step()
+	}
+
+	return true
+}
+
+//neighbouringLine Returns the row or column next to i, wrapped round if the edge is joined. Past an edge that is a
+//wall the cell is its own neighbour, so nothing flows out of the grid
+func neighbouringLine(i int, length int, wraps bool) int {
+
+	switch {
+	case i < 0 && wraps:
+		return length - 1
+	case i < 0:
+		return 0
+	case i >= length && wraps:
+		return 0
+	case i >= length:
+		return length - 1
+	}
+
+	return i
+}
+
+//step Moves the concentrations of every cell on by one step of the Gray-Scott equations
+func (grayScott *GrayScott) step() {
+
+	width, height := grayScott.Width, grayScott.Height
+	wrapsX, wrapsY := grayScott.Topology.WrapsX(), grayScott.Topology.WrapsY()
+	feed, kill := grayScott.Feed, grayScott.Kill
+	rowsPerStripe := (height + grayScott.stripes - 1) / grayScott.stripes
+
+	var wg sync.WaitGroup
+
+	for stripe := 0; stripe < grayScott.stripes; stripe++ {
+
+		wg.Add(1)
+
+		go func(stripe int) {
+
+			defer wg.Done()
+
+			start := stripe * rowsPerStripe
+			end := start + rowsPerStripe
+			if end > height {
+				end = height
+			}
+
+			for y := start; y < end; y++ {
+
+				above, below := neighbouringLine(y-1, height, wrapsY), neighbouringLine(y+1, height, wrapsY)
+				rows := [3]int{above * width, y * width, below * width}
+
+				for x := 0; x < width; x++ {
+
+					columns := [3]int{neighbouringLine(x-1, width, wrapsX), x, neighbouringLine(x+1, width, wrapsX)}
+
+					//The laplacian weights the four sides by 0.2, the four corners by 0.05 and the cell itself by -1
+					laplaceA, laplaceB := 0.0, 0.0
+					for row := 0; row < 3; row++ {
+						for column := 0; column < 3; column++ {
+
+							weight := 0.05
+							if row == 1 && column == 1 {
+								weight = -1
+							} else if row == 1 || column == 1 {
+								weight = 0.2
+							}
+
+							j := rows[row] + columns[column]
+							laplaceA += grayScott.a[j] * weight
+							laplaceB += grayScott.b[j] * weight
+						}
+					}
+
+					i := rows[1] + x
+					a, b := grayScott.a[i], grayScott.b[i]
+					reaction := a * b * b
+
+					grayScott.nextA[i] = clampConcentration(a + grayScott.DiffusionA*laplaceA - reaction + feed*(1-a))
+					grayScott.nextB[i] = clampConcentration(b + grayScott.DiffusionB*laplaceB + reaction - (kill+feed)*b)
+				}
+			}
+		}(stripe)
+	}
+
+	wg.Wait()
+
+	grayScott.a, grayScott.nextA = grayScott.nextA, grayScott.a
+	grayScott.b, grayScott.nextB = grayScott.nextB, grayScott.b
+	grayScott.Step++
+}
+
+//clampConcentration Returns the concentration limited to between 0 and 1
+func clampConcentration(value float64) float64 {
+
+	if value < 0 {
+		return 0
+	}
+
+	if value > 1 {
+		return 1
+	}
+
+	return value
+}
+
+//TickRate Returns the time between each Update
+func (grayScott *GrayScott) TickRate() time.Duration {
+	return time.Millisecond * FrameSpeedMultiplier * 2
+}
@@ -0,0 +1,86 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"testing"
+)
+
+func TestGrayScott_EmptyGridStaysEmpty(t *testing.T) {
+
+	grayScott := NewGrayScott(GrayScottSettings{Dimensions: Dimensions{Width: 10, Height: 10}, Preset: Coral,
+		DiffusionA: 1, DiffusionB: 0.5, StepsPerUpdate: 10, Seeded: Seeded{Seed: 1}})
+
+	for i := range grayScott.b {
+		grayScott.a[i], grayScott.b[i] = 1, 0
+	}
+
+	grayScott.Update()
+
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			if a, b, _ := grayScott.Concentrations(x, y); a != 1 || b != 0 {
+				t.Fatalf("Concentrations(%d, %d) = %v, %v, want a grid of only A to stay as it is", x, y, a, b)
+			}
+		}
+	}
+}
+
+func TestGrayScott_PatternGrows(t *testing.T) {
+
+	grayScott := NewGrayScott(GrayScottSettings{Dimensions: Dimensions{Width: 40, Height: 40}, Topology: geometry.Torus,
+		Preset: Mitosis, DiffusionA: 1, DiffusionB: 0.5, StepsPerUpdate: 100, Seeded: Seeded{Seed: 1}})
+
+	before := grayScott.TotalB()
+
+	for i := 0; i < 20; i++ {
+		grayScott.Update()
+	}
+
+	if after := grayScott.TotalB(); after <= before {
+		t.Errorf("TotalB() = %v after 2000 steps, want more than the %v dropped in", after, before)
+	}
+
+	for i := range grayScott.b {
+		if grayScott.a[i] < 0 || grayScott.a[i] > 1 || grayScott.b[i] < 0 || grayScott.b[i] > 1 {
+			t.Fatalf("Concentrations %v, %v, want both between 0 and 1", grayScott.a[i], grayScott.b[i])
+		}
+	}
+}
+
+func TestGrayScott_StripesMatchSingleThread(t *testing.T) {
+
+	settings := GrayScottSettings{Dimensions: Dimensions{Width: 30, Height: 17}, Preset: Worms,
+		DiffusionA: 1, DiffusionB: 0.5, StepsPerUpdate: 50, Seeded: Seeded{Seed: 7}}
+
+	parallel := NewGrayScott(settings)
+	parallel.stripes = 4
+
+	single := NewGrayScott(settings)
+	single.stripes = 1
+
+	parallel.Update()
+	single.Update()
+
+	for i := range single.b {
+		if parallel.a[i] != single.a[i] || parallel.b[i] != single.b[i] {
+			t.Fatalf("Cell %d differs between 4 stripes and 1 stripe", i)
+		}
+	}
+}
+
+func TestGrayScottPreset_Rates(t *testing.T) {
+
+	grayScott := NewGrayScott(GrayScottSettings{Dimensions: Dimensions{Width: 5, Height: 5}, Preset: Coral, Feed: 1,
+		Kill: 1, Seeded: Seeded{Seed: 1}})
+
+	if grayScott.Feed != 0.0545 || grayScott.Kill != 0.062 {
+		t.Errorf("Feed, Kill = %v, %v, want the Coral rates", grayScott.Feed, grayScott.Kill)
+	}
+
+	custom := NewGrayScott(GrayScottSettings{Dimensions: Dimensions{Width: 5, Height: 5}, Feed: 0.01, Kill: 0.02,
+		Seeded: Seeded{Seed: 1}})
+
+	if custom.Feed != 0.01 || custom.Kill != 0.02 {
+		t.Errorf("Feed, Kill = %v, %v, want the custom rates kept", custom.Feed, custom.Kill)
+	}
+}
@@ -0,0 +1,118 @@
+package world
+
+import (
+	"gopherlife/geometry"
+)
+
+//OffsetContainer a container whose positions are not the same as the columns and rows its tiles are laid out in
+type OffsetContainer interface {
+	FromOffset(col int, row int) geometry.Coordinates
+}
+
+//HexContainer a rectangle of pointy topped hexagons, every odd row is shifted half a hexagon right. Tiles are accessed
+//using axial hex coordinates, X is Q and Y is R
+type HexContainer struct {
+	b2dc Basic2DContainer
+}
+
+//NewHexContainer Returns a HexContainer of the given number of columns and rows
+func NewHexContainer(width int, height int) HexContainer {
+	return HexContainer{b2dc: NewBasic2DContainer(0, 0, width, height)}
+}
+
+//FromOffset Returns the axial position of the hexagon in the given column and row
+func (container *HexContainer) FromOffset(col int, row int) geometry.Coordinates {
+	return geometry.HexFromOffset(col, row).Coordinates()
+}
+
+//offset Returns the column and row of the hexagon at q and r
+func (container *HexContainer) offset(q int, r int) (int, int) {
+	return geometry.NewHex(q, r).Offset()
+}
+
+//Tile Returns the tile of the hexagon at q and r
+func (container *HexContainer) Tile(q int, r int) (*GopherWorldTile, bool) {
+	return container.b2dc.Tile(container.offset(q, r))
+}
+
+//InsertGopher Inserts the given gopher into the hexagon at q and r
+func (container *HexContainer) InsertGopher(q int, r int, gopher *Gopher) bool {
+
+	col, row := container.offset(q, r)
+
+	if container.b2dc.InsertGopher(col, row, gopher) {
+		gopher.Position.SetXY(q, r)
+		return true
+	}
+
+	return false
+}
+
+//InsertFood Inserts the given food into the hexagon at q and r
+func (container *HexContainer) InsertFood(q int, r int, food *Food) bool {
+
+	col, row := container.offset(q, r)
+
+	if container.b2dc.InsertFood(col, row, food) {
+		food.Position.SetXY(q, r)
+		return true
+	}
+
+	return false
+}
+
+//RemoveGopher Removes the gopher from the hexagon at q and r
+func (container *HexContainer) RemoveGopher(q int, r int) (*Gopher, bool) {
+	return container.b2dc.RemoveGopher(container.offset(q, r))
+}
+
+//RemoveFood Removes the food from the hexagon at q and r
+func (container *HexContainer) RemoveFood(q int, r int) (*Food, bool) {
+	return container.b2dc.RemoveFood(container.offset(q, r))
+}
+
+//HasGopher Returns the gopher in the hexagon at q and r, if there is one
+func (container *HexContainer) HasGopher(q int, r int) (*Gopher, bool) {
+	return container.b2dc.HasGopher(container.offset(q, r))
+}
+
+//HasFood Returns the food in the hexagon at q and r, if there is one
+func (container *HexContainer) HasFood(q int, r int) (*Food, bool) {
+	return container.b2dc.HasFood(container.offset(q, r))
+}
+
+//HexTileSearch searches the hexagons around a position ring by ring, so the nearest are always found first
+type HexTileSearch struct {
+	TileContainer
+}
+
+//Search Returns up to max positions of the search type within half the larger of width and height steps of the position
+func (search *HexTileSearch) Search(position geometry.Coordinates, width int, height int, max int, searchType SearchType) []geometry.Coordinates {
+
+	var query TileQuery
+
+	switch searchType {
+	case SearchForFood:
+		query = CheckMapPointForFood
+	case SearchForEmptySpace:
+		query = CheckMapPointForEmptySpace
+	case SearchForFemaleGopher:
+		query = CheckMapPointForFemaleGopher
+	}
+
+	radius := width
+	if height > radius {
+		radius = height
+	}
+
+	found := []geometry.Coordinates{}
+	spiral := geometry.NewHexSpiral(geometry.HexFromCoordinates(position), radius/2)
+
+	for h, ok := spiral.Next(); ok && len(found) < max; h, ok = spiral.Next() {
+		if tile, ok := search.Tile(h.Q, h.R); ok && query(tile) {
+			found = append(found, h.Coordinates())
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,67 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"testing"
+)
+
+func TestHexContainer_InsertGopher(t *testing.T) {
+
+	hc := NewHexContainer(10, 10)
+	gopher := &Gopher{}
+
+	//Column 0 of row 4 is at q -2
+	if !hc.InsertGopher(-2, 4, gopher) {
+		t.Fatalf("HexContainer.InsertGopher() failed inside the rectangle")
+	}
+
+	if gopher.Position != geometry.NewCoordinate(-2, 4) {
+		t.Errorf("HexContainer.InsertGopher() position = %v, want axial (-2, 4)", gopher.Position)
+	}
+
+	if _, ok := hc.b2dc.HasGopher(0, 4); !ok {
+		t.Errorf("HexContainer.InsertGopher() should store the gopher in column 0 of row 4")
+	}
+
+	if hc.InsertGopher(-3, 4, &Gopher{}) {
+		t.Errorf("HexContainer.InsertGopher() should fail outside the rectangle")
+	}
+
+	if got, ok := hc.RemoveGopher(-2, 4); !ok || got != gopher {
+		t.Errorf("HexContainer.RemoveGopher() = %v, %v want %v", got, ok, gopher)
+	}
+}
+
+func TestHexTileSearch_Search(t *testing.T) {
+
+	hc := NewHexContainer(20, 20)
+	search := HexTileSearch{TileContainer: &hc}
+
+	hc.InsertFood(8, 5, &Food{})
+	hc.InsertFood(5, 6, &Food{})
+	hc.InsertFood(15, 15, &Food{})
+
+	got := search.Search(geometry.NewCoordinate(5, 5), 10, 10, 5, SearchForFood)
+	want := []geometry.Coordinates{{X: 5, Y: 6}, {X: 8, Y: 5}}
+
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("HexTileSearch.Search() = %v, want %v", got, want)
+	}
+}
+
+func TestHexMovement_FindNextStep(t *testing.T) {
+
+	movement := HexMovement{}
+	start, end := geometry.NewCoordinate(0, 0), geometry.NewCoordinate(2, 2)
+
+	x, y := movement.FindNextStep(start, end)
+	next := geometry.NewCoordinate(x, y)
+
+	if geometry.HexDistance(geometry.HexFromCoordinates(next), geometry.HexFromCoordinates(end)) != 3 {
+		t.Errorf("HexMovement.FindNextStep() = %v, should be one step nearer to %v", next, end)
+	}
+
+	if !movement.IsInRange(start, geometry.NewCoordinate(1, -1), 1, 1) || movement.IsInRange(start, geometry.NewCoordinate(1, 1), 1, 1) {
+		t.Errorf("HexMovement.IsInRange() should only include the six neighbours")
+	}
+}
@@ -0,0 +1,119 @@
+package world
+
+import (
+	"gopherlife/geometry"
+)
+
+//IndexedTileSearch a GopherWorldSearcher that keeps the position of every Gopher and Food in a QuadTree. It wraps the
+//inserts and removes of a container so the QuadTrees stay up to date, searches only visit the part of the tree around
+//the searching position. Inserts and removes must not run at the same time as searches
+type IndexedTileSearch struct {
+	TileContainer
+	GopherAndFoodInserterAndRemover
+
+	gophers geometry.QuadTree
+	food    geometry.QuadTree
+}
+
+//NewIndexedTileSearch Returns an IndexedTileSearch for a container covering the given area. Gophers and Food should
+//only be inserted and removed through the IndexedTileSearch
+func NewIndexedTileSearch(t TileContainer, iar GopherAndFoodInserterAndRemover, x int, y int, width int, height int) IndexedTileSearch {
+	return IndexedTileSearch{
+		TileContainer:                   t,
+		GopherAndFoodInserterAndRemover: iar,
+		gophers:                         geometry.NewQuadTree(x, y, width, height, geometry.DefaultQuadTreeCapacity),
+		food:                            geometry.NewQuadTree(x, y, width, height, geometry.DefaultQuadTreeCapacity),
+	}
+}
+
+//InsertGopher Inserts the gopher into the container and adds its position to the index
+func (search *IndexedTileSearch) InsertGopher(x int, y int, gopher *Gopher) bool {
+	if search.GopherAndFoodInserterAndRemover.InsertGopher(x, y, gopher) {
+		x, y = wrap(search.TileContainer, x, y)
+		search.gophers.Insert(geometry.Coordinates{X: x, Y: y})
+		return true
+	}
+	return false
+}
+
+//RemoveGopher Removes the gopher from the container and its position from the index
+func (search *IndexedTileSearch) RemoveGopher(x int, y int) (*Gopher, bool) {
+	if gopher, ok := search.GopherAndFoodInserterAndRemover.RemoveGopher(x, y); ok {
+		x, y = wrap(search.TileContainer, x, y)
+		search.gophers.Remove(geometry.Coordinates{X: x, Y: y})
+		return gopher, true
+	}
+	return nil, false
+}
+
+//InsertFood Inserts the food into the container and adds its position to the index
+func (search *IndexedTileSearch) InsertFood(x int, y int, food *Food) bool {
+	if search.GopherAndFoodInserterAndRemover.InsertFood(x, y, food) {
+		x, y = wrap(search.TileContainer, x, y)
+		search.food.Insert(geometry.Coordinates{X: x, Y: y})
+		return true
+	}
+	return false
+}
+
+//RemoveFood Removes the food from the container and its position from the index
+func (search *IndexedTileSearch) RemoveFood(x int, y int) (*Food, bool) {
+	if food, ok := search.GopherAndFoodInserterAndRemover.RemoveFood(x, y); ok {
+		x, y = wrap(search.TileContainer, x, y)
+		search.food.Remove(geometry.Coordinates{X: x, Y: y})
+		return food, true
+	}
+	return nil, false
+}
+
+//Search Returns up to max positions of the search type, nearest first, inside the same width and height around the
+//position as SpiralTileSearch. Empty spaces are not indexed and are found with a SpiralTileSearch
+func (search *IndexedTileSearch) Search(position geometry.Coordinates, width int, height int, max int, searchType SearchType) []geometry.Coordinates {
+
+	switch searchType {
+	case SearchForFood:
+		return search.nearest(&search.food, position, width, height, max, CheckMapPointForFood)
+	case SearchForFemaleGopher:
+		return search.nearest(&search.gophers, position, width, height, max, CheckMapPointForFemaleGopher)
+	}
+
+	sts := SpiralTileSearch{TileContainer: search.TileContainer}
+	return sts.Search(position, width, height, max, searchType)
+}
+
+//nearest Searches the tree around the position and around each copy of the position across a joined edge
+func (search *IndexedTileSearch) nearest(tree *geometry.QuadTree, position geometry.Coordinates, width int, height int, max int, query TileQuery) []geometry.Coordinates {
+
+	surface := surfaceOf(search.TileContainer)
+	width, height = surface.SearchSize(width, height)
+	offsets := surface.Offsets()
+
+	if len(offsets) == 1 {
+		area := geometry.NewRectangle(position.X-(width-1)/2, position.Y-(height-1)/2, width, height)
+		return tree.Nearest(position, max, area, search.filter(query))
+	}
+
+	found := []geometry.Coordinates{}
+
+	for _, offset := range offsets {
+		shifted := position.RelativeCoordinate(offset.X, offset.Y)
+		area := geometry.NewRectangle(shifted.X-(width-1)/2, shifted.Y-(height-1)/2, width, height)
+		found = append(found, tree.Nearest(shifted, max, area, search.filter(query))...)
+	}
+
+	surface.SortByNearest(position, found)
+
+	if len(found) > max {
+		found = found[:max]
+	}
+
+	return found
+}
+
+//filter Turns a TileQuery into a filter for the index
+func (search *IndexedTileSearch) filter(query TileQuery) func(geometry.Coordinates) bool {
+	return func(c geometry.Coordinates) bool {
+		tile, ok := search.Tile(c.X, c.Y)
+		return ok && query(tile)
+	}
+}
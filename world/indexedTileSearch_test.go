@@ -0,0 +1,146 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"math/rand"
+	"testing"
+)
+
+func TestIndexedTileSearch_Search(t *testing.T) {
+
+	b2dc := NewBasic2DContainer(0, 0, 50, 50)
+	search := NewIndexedTileSearch(&b2dc, &b2dc, 0, 0, 50, 50)
+
+	search.InsertFood(10, 10, &Food{})
+	search.InsertFood(12, 10, &Food{})
+	search.InsertFood(40, 40, &Food{})
+	search.InsertFood(20, 20, &Food{})
+	search.RemoveFood(20, 20)
+
+	//Food under a gopher is not searched for
+	search.InsertFood(11, 11, &Food{})
+	search.InsertGopher(11, 11, &Gopher{})
+
+	female := NewGopher("female", geometry.Coordinates{})
+	female.Gender = Female
+	female.Lifespan = 150
+	search.InsertGopher(14, 10, &female)
+
+	male := NewGopher("male", geometry.Coordinates{})
+	male.Gender = Male
+	male.Lifespan = 150
+	search.InsertGopher(9, 10, &male)
+
+	tests := []struct {
+		name       string
+		position   geometry.Coordinates
+		max        int
+		searchType SearchType
+		want       []geometry.Coordinates
+	}{
+		{"Nearest food", geometry.Coordinates{X: 11, Y: 10}, 1, SearchForFood, []geometry.Coordinates{{X: 10, Y: 10}}},
+		{"All food in range", geometry.Coordinates{X: 13, Y: 10}, 5, SearchForFood, []geometry.Coordinates{{X: 12, Y: 10}, {X: 10, Y: 10}}},
+		{"Nothing in range", geometry.Coordinates{X: 35, Y: 5}, 1, SearchForFood, []geometry.Coordinates{}},
+		{"Female gopher", geometry.Coordinates{X: 10, Y: 10}, 1, SearchForFemaleGopher, []geometry.Coordinates{{X: 14, Y: 10}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := search.Search(tt.position, 25, 25, tt.max, tt.searchType)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("IndexedTileSearch.Search() = %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("IndexedTileSearch.Search() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestIndexedTileSearch_MoveGopher(t *testing.T) {
+
+	settings := GopherWorldSettings{
+		Dimensions:      Dimensions{10, 10},
+		Population:      Population{0, 100},
+		NumberOfFood:    0,
+		GopherBirthRate: 7,
+	}
+
+	gw := CreateGopherWorldIndexedSearch(settings)
+
+	gopher := NewGopher("a", geometry.NewCoordinate(1, 2))
+	gopher.Gender = Female
+	gopher.Lifespan = 150
+	gw.InsertGopher(1, 2, &gopher)
+
+	gw.MoveGopher(&gopher, 0, 1)
+
+	got := gw.Search(geometry.NewCoordinate(5, 5), 10, 10, 5, SearchForFemaleGopher)
+
+	if len(got) != 1 || got[0] != geometry.NewCoordinate(1, 3) {
+		t.Errorf("IndexedTileSearch.Search() after MoveGopher = %v, want [(1,3)]", got)
+	}
+}
+
+//benchmarkSearch Fills a world with food and searches for the nearest food from random positions
+func benchmarkSearch(b *testing.B, create func(GopherWorldSettings) *GopherWorld, numberOfFood int) {
+
+	settings := GopherWorldSettings{
+		Dimensions:   Dimensions{500, 500},
+		Population:   Population{0, 0},
+		NumberOfFood: numberOfFood,
+	}
+
+	gw := create(settings)
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		position := geometry.NewCoordinate(rand.Intn(settings.Width), rand.Intn(settings.Height))
+		gw.Search(position, 25, 25, 1, SearchForFood)
+	}
+}
+
+func BenchmarkSpiralTileSearch_Dense(b *testing.B) {
+	benchmarkSearch(b, CreateGopherWorldSpiralSearch, 100000)
+}
+
+func BenchmarkSpiralTileSearch_Sparse(b *testing.B) {
+	benchmarkSearch(b, CreateGopherWorldSpiralSearch, 500)
+}
+
+func BenchmarkGridTileSearch_Dense(b *testing.B) {
+	benchmarkSearch(b, CreateGopherWorldGridPartition, 100000)
+}
+
+func BenchmarkGridTileSearch_Sparse(b *testing.B) {
+	benchmarkSearch(b, CreateGopherWorldGridPartition, 500)
+}
+
+func BenchmarkIndexedTileSearch_Dense(b *testing.B) {
+	benchmarkSearch(b, CreateGopherWorldIndexedSearch, 100000)
+}
+
+func BenchmarkIndexedTileSearch_Sparse(b *testing.B) {
+	benchmarkSearch(b, CreateGopherWorldIndexedSearch, 500)
+}
+
+func TestIndexedTileSearch_SearchTorus(t *testing.T) {
+
+	b2dc := NewBasic2DContainer(0, 0, 50, 50)
+	b2dc.SetTopology(geometry.Torus)
+	search := NewIndexedTileSearch(&b2dc, &b2dc, 0, 0, 50, 50)
+
+	search.InsertFood(-1, 0, &Food{})
+	search.InsertFood(10, 10, &Food{})
+
+	got := search.Search(geometry.Coordinates{X: 1, Y: 49}, 25, 25, 5, SearchForFood)
+	want := []geometry.Coordinates{{X: 49, Y: 0}, {X: 10, Y: 10}}
+
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("IndexedTileSearch.Search() = %v, want %v", got, want)
+	}
+}
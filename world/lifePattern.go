@@ -0,0 +1,236 @@
+package world
+
+import (
+	"bufio"
+	"fmt"
+	"gopherlife/geometry"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//LifePattern the alive cells of a Life pattern, relative to its bottom left corner. Files list their rows from the top
+//down, they are flipped so the pattern is the right way up in the world where y goes up
+type LifePattern struct {
+	Name string
+	Dimensions
+	Cells []geometry.Coordinates
+	//Rule the rule the pattern was written for, if the file gave one
+	Rule string
+}
+
+//LoadLifePattern Reads a LifePattern from an RLE file ending in .rle or a plaintext file ending in .cells. The pattern
+//is named after the file unless the file names it
+func LoadLifePattern(path string) (LifePattern, error) {
+
+	file, err := os.Open(path)
+
+	if err != nil {
+		return LifePattern{}, err
+	}
+
+	defer file.Close()
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".rle":
+		return ParseRLE(name, file)
+	case ".cells":
+		return ParseCells(name, file)
+	}
+
+	return LifePattern{}, fmt.Errorf("life pattern %q is not an .rle or .cells file", path)
+}
+
+//add Adds an alive cell to the pattern, growing it to fit
+func (pattern *LifePattern) add(x int, y int) {
+
+	pattern.Cells = append(pattern.Cells, geometry.NewCoordinate(x, y))
+
+	if x >= pattern.Width {
+		pattern.Width = x + 1
+	}
+
+	if y >= pattern.Height {
+		pattern.Height = y + 1
+	}
+}
+
+//ParseRLE Reads a LifePattern in the run length encoded format.
+//Lines starting with '#' are comments, '#N' names the pattern. The header line 'x = 3, y = 3, rule = B3/S23' gives the
+//size and rule. The cells follow as runs of a tag with an optional count before it, 'b' is dead, 'o' or any other
+//letter is alive, '$' ends a row and '!' ends the pattern
+func ParseRLE(name string, r io.Reader) (LifePattern, error) {
+
+	pattern := LifePattern{Name: name}
+	scanner := bufio.NewScanner(r)
+
+	x, y, count := 0, 0, 0
+	header := false
+
+	for scanner.Scan() {
+
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "#") {
+			if strings.HasPrefix(line, "#N") && strings.TrimSpace(line[2:]) != "" {
+				pattern.Name = strings.TrimSpace(line[2:])
+			}
+			continue
+		}
+
+		if !header && strings.HasPrefix(line, "x") {
+			header = true
+			if err := pattern.parseRLEHeader(line); err != nil {
+				return LifePattern{}, err
+			}
+			continue
+		}
+
+		for _, c := range line {
+			switch {
+			case c >= '0' && c <= '9':
+				count = count*10 + int(c-'0')
+			case c == '!':
+				pattern.flip()
+				return pattern, pattern.check()
+			case c == ' ' || c == '\t':
+			default:
+				run := count
+				if run == 0 {
+					run = 1
+				}
+				count = 0
+
+				switch {
+				case c == '$':
+					x = 0
+					y += run
+				case c == 'b' || c == '.':
+					x += run
+				case c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z':
+					for i := 0; i < run; i++ {
+						pattern.add(x, y)
+						x++
+					}
+				default:
+					return LifePattern{}, fmt.Errorf("life pattern %q has an unknown tag %q", name, c)
+				}
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return LifePattern{}, err
+	}
+
+	pattern.flip()
+
+	return pattern, pattern.check()
+}
+
+//parseRLEHeader Reads the size and rule from an RLE header line
+func (pattern *LifePattern) parseRLEHeader(line string) error {
+
+	for _, field := range strings.Split(line, ",") {
+
+		parts := strings.SplitN(field, "=", 2)
+
+		if len(parts) != 2 {
+			return fmt.Errorf("life pattern %q has a bad header %q", pattern.Name, line)
+		}
+
+		key, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+
+		switch key {
+		case "x", "y":
+			size, err := strconv.Atoi(value)
+			if err != nil || size < 0 {
+				return fmt.Errorf("life pattern %q has a bad size %q", pattern.Name, value)
+			}
+			if key == "x" {
+				pattern.Width = size
+			} else {
+				pattern.Height = size
+			}
+		case "rule":
+			pattern.Rule = value
+		}
+	}
+
+	return nil
+}
+
+//ParseCells Reads a LifePattern in the plaintext format.
+//Lines starting with '!' are comments, '!Name:' names the pattern. Each other line is a row of cells, 'O' or '*' is
+//alive and '.' is dead
+func ParseCells(name string, r io.Reader) (LifePattern, error) {
+
+	pattern := LifePattern{Name: name}
+	scanner := bufio.NewScanner(r)
+
+	//rows the number of rows up to the last one that is not blank, blank lines at the end of the file are not part of
+	//the pattern
+	y, rows := 0, 0
+
+	for scanner.Scan() {
+
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if strings.HasPrefix(line, "!") {
+			if strings.HasPrefix(line, "!Name:") && strings.TrimSpace(line[6:]) != "" {
+				pattern.Name = strings.TrimSpace(line[6:])
+			}
+			continue
+		}
+
+		for x, c := range line {
+			switch c {
+			case 'O', 'o', '*':
+				pattern.add(x, y)
+			case '.', ' ':
+			default:
+				return LifePattern{}, fmt.Errorf("life pattern %q has an unknown cell %q", name, c)
+			}
+		}
+
+		y++
+
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		rows = y
+
+		if len(line) > pattern.Width {
+			pattern.Width = len(line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return LifePattern{}, err
+	}
+
+	pattern.Height = rows
+	pattern.flip()
+
+	return pattern, pattern.check()
+}
+
+//flip Turns the rows read from the top down into rows counted from the bottom up
+func (pattern *LifePattern) flip() {
+	for i, cell := range pattern.Cells {
+		pattern.Cells[i] = geometry.NewCoordinate(cell.GetX(), pattern.Height-1-cell.GetY())
+	}
+}
+
+//check Returns an error if the pattern has no alive cells
+func (pattern *LifePattern) check() error {
+	if len(pattern.Cells) == 0 {
+		return fmt.Errorf("life pattern %q is empty", pattern.Name)
+	}
+	return nil
+}
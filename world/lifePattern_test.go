@@ -0,0 +1,102 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParseRLE(t *testing.T) {
+
+	rle := "#N Blinker Pair\n#C two blinkers\nx = 7, y = 1, rule = B3/S23\n3o\n b3o!"
+
+	pattern, err := ParseRLE("file", strings.NewReader(rle))
+
+	if err != nil {
+		t.Fatalf("ParseRLE() error = %v", err)
+	}
+
+	if pattern.Name != "Blinker Pair" || pattern.Rule != "B3/S23" {
+		t.Errorf("ParseRLE() Name = %q Rule = %q, want \"Blinker Pair\" and \"B3/S23\"", pattern.Name, pattern.Rule)
+	}
+
+	if pattern.Width != 7 || pattern.Height != 1 || len(pattern.Cells) != 6 {
+		t.Errorf("ParseRLE() = %dx%d with %d cells, want 7x1 with 6", pattern.Width, pattern.Height, len(pattern.Cells))
+	}
+
+	if _, err := ParseRLE("bad", strings.NewReader("x = 1, y = 1\n3?!")); err == nil {
+		t.Errorf("ParseRLE() with an unknown tag did not return an error")
+	}
+}
+
+func TestParseCells(t *testing.T) {
+
+	cells := "!Name: Glider\n!\n.O.\n..O\nOOO\n"
+
+	pattern, err := ParseCells("file", strings.NewReader(cells))
+
+	if err != nil {
+		t.Fatalf("ParseCells() error = %v", err)
+	}
+
+	if pattern.Name != "Glider" || pattern.Width != 3 || pattern.Height != 3 || len(pattern.Cells) != 5 {
+		t.Errorf("ParseCells() = %q %dx%d with %d cells, want \"Glider\" 3x3 with 5", pattern.Name, pattern.Width, pattern.Height, len(pattern.Cells))
+	}
+
+	if _, err := ParseCells("empty", strings.NewReader("!Name: Empty\n...\n")); err == nil {
+		t.Errorf("ParseCells() of an empty pattern did not return an error")
+	}
+}
+
+func TestParseRLE_GliderOrientation(t *testing.T) {
+
+	fromRLE, err := ParseRLE("glider", strings.NewReader("x = 3, y = 3\nbo$2bo$3o!"))
+
+	if err != nil {
+		t.Fatalf("ParseRLE() error = %v", err)
+	}
+
+	fromCells, err := ParseCells("glider", strings.NewReader(".O.\n..O\nOOO\n\n  \n"))
+
+	if err != nil {
+		t.Fatalf("ParseCells() error = %v", err)
+	}
+
+	//The top row of the file is the highest row in the world, where y goes up
+	want := []geometry.Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 2}}
+
+	for _, pattern := range []LifePattern{fromRLE, fromCells} {
+
+		if pattern.Width != 3 || pattern.Height != 3 {
+			t.Errorf("Glider = %dx%d, want 3x3 without the blank lines at the end", pattern.Width, pattern.Height)
+		}
+
+		got := append([]geometry.Coordinates{}, pattern.Cells...)
+		sort.Slice(got, func(i, j int) bool { return got[i].Y*3+got[i].X < got[j].Y*3+got[j].X })
+
+		if len(got) != len(want) {
+			t.Fatalf("Glider cells = %v, want %v", got, want)
+		}
+
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("Glider cells = %v, want %v", got, want)
+				break
+			}
+		}
+	}
+
+	//Drawn as in the file the glider heads down and to the right, so in the world it moves right and down in y
+	lifeWorld := NewLifeWorld(LifeWorldSettings{Dimensions: Dimensions{Width: 9, Height: 9}, Rule: Life, Pattern: &fromRLE})
+
+	for i := 0; i < 4; i++ {
+		lifeWorld.Update()
+	}
+
+	for _, cell := range want {
+		if x, y := cell.X+3+1, cell.Y+3-1; !lifeWorld.IsAlive(x, y) {
+			t.Errorf("Glider after four generations is missing the cell at %v,%v", x, y)
+		}
+	}
+}
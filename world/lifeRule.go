@@ -0,0 +1,103 @@
+package world
+
+import (
+	"fmt"
+	"strings"
+)
+
+//LifeRule a rule for Life-like cellular automata in B/S notation. A dead cell is born if the number of its alive
+//neighbours is in Birth, an alive cell survives if the number is in Survive
+type LifeRule struct {
+	Name    string
+	Birth   [9]bool
+	Survive [9]bool
+}
+
+//Named LifeRules
+var (
+	Life     = mustParseLifeRule("Life", "B3/S23")
+	HighLife = mustParseLifeRule("HighLife", "B36/S23")
+	//Seamaze grows into long maze like corridors
+	Seamaze     = mustParseLifeRule("Seamaze", "B3/S12345")
+	DayAndNight = mustParseLifeRule("Day & Night", "B3678/S34678")
+)
+
+//LifeRules every named LifeRule
+var LifeRules = []LifeRule{Life, HighLife, Seamaze, DayAndNight}
+
+//Next Returns 1 if the cell is alive next generation and 0 if it is dead
+func (rule LifeRule) Next(state uint8, alive int) uint8 {
+
+	if state == 0 && rule.Birth[alive] || state != 0 && rule.Survive[alive] {
+		return 1
+	}
+
+	return 0
+}
+
+//String Returns the rule in B/S notation
+func (rule LifeRule) String() string {
+
+	b, s := "", ""
+
+	for i := 0; i < 9; i++ {
+		if rule.Birth[i] {
+			b += fmt.Sprint(i)
+		}
+		if rule.Survive[i] {
+			s += fmt.Sprint(i)
+		}
+	}
+
+	return "B" + b + "/S" + s
+}
+
+//ParseLifeRule Returns the LifeRule with the given name, such as "HighLife", or written in B/S notation, such as
+//"B36/S23". Letters are not case sensitive
+func ParseLifeRule(rule string) (LifeRule, error) {
+
+	rule = strings.TrimSpace(rule)
+
+	for _, named := range LifeRules {
+		if strings.EqualFold(named.Name, rule) {
+			return named, nil
+		}
+	}
+
+	return parseBSRule(rule)
+}
+
+//parseBSRule Returns the LifeRule written in B/S notation
+func parseBSRule(rule string) (LifeRule, error) {
+
+	parts := strings.Split(strings.ToUpper(rule), "/")
+
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], "B") || !strings.HasPrefix(parts[1], "S") {
+		return LifeRule{}, fmt.Errorf("life rule %q is not in B/S notation", rule)
+	}
+
+	parsed := LifeRule{Name: strings.ToUpper(rule)}
+
+	for i, counts := range []*[9]bool{&parsed.Birth, &parsed.Survive} {
+		for _, c := range parts[i][1:] {
+			if c < '0' || c > '8' {
+				return LifeRule{}, fmt.Errorf("life rule %q has a neighbour count %q that is not between 0 and 8", rule, c)
+			}
+			counts[c-'0'] = true
+		}
+	}
+
+	return parsed, nil
+}
+
+func mustParseLifeRule(name string, rule string) LifeRule {
+
+	parsed, err := parseBSRule(rule)
+
+	if err != nil {
+		panic(err)
+	}
+
+	parsed.Name = name
+	return parsed
+}
@@ -0,0 +1,90 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"math/rand"
+	"time"
+)
+
+//LifeWorldSettings sets up a LifeWorld
+type LifeWorldSettings struct {
+	Dimensions
+	Rule     LifeRule
+	Topology geometry.Topology
+	//Density the percentage of cells that start alive when there is no Pattern
+	Density int
+	//Pattern if set it is placed in the middle of the world instead of filling it randomly
+	Pattern *LifePattern
+}
+
+//LifeWorld a Life-like cellular automaton
+type LifeWorld struct {
+	LifeWorldSettings
+	Automaton
+}
+
+//NewLifeWorld Returns a LifeWorld holding the Pattern, or randomly filled if there is none
+func NewLifeWorld(settings LifeWorldSettings) LifeWorld {
+
+	lifeWorld := LifeWorld{
+		LifeWorldSettings: settings,
+		Automaton:         NewAutomaton(settings.Width, settings.Height, settings.Topology, settings.Rule),
+	}
+
+	lifeWorld.LifeWorldSettings.Dimensions = lifeWorld.Automaton.Dimensions()
+
+	if settings.Pattern != nil {
+		lifeWorld.Place(settings.Pattern, (lifeWorld.Width-settings.Pattern.Width)/2, (lifeWorld.Height-settings.Pattern.Height)/2)
+	} else {
+		lifeWorld.Randomise(settings.Density)
+	}
+
+	return lifeWorld
+}
+
+//Update Moves the world on by one generation
+func (lifeWorld *LifeWorld) Update() bool {
+	lifeWorld.Step()
+	return true
+}
+
+//TickRate Returns the time between each Update
+func (lifeWorld *LifeWorld) TickRate() time.Duration {
+	return time.Millisecond * FrameSpeedMultiplier * 5
+}
+
+//IsAlive Returns true if the cell is alive
+func (lifeWorld *LifeWorld) IsAlive(x int, y int) bool {
+	state, _ := lifeWorld.Cell(x, y)
+	return state != 0
+}
+
+//Toggle Brings a dead cell to life or kills an alive cell
+func (lifeWorld *LifeWorld) Toggle(x int, y int) {
+	if lifeWorld.IsAlive(x, y) {
+		lifeWorld.SetCell(x, y, 0)
+	} else {
+		lifeWorld.SetCell(x, y, 1)
+	}
+}
+
+//Place Brings the cells of the pattern to life with its bottom left corner at x and y
+func (lifeWorld *LifeWorld) Place(pattern *LifePattern, x int, y int) {
+	for _, cell := range pattern.Cells {
+		lifeWorld.SetCell(x+cell.GetX(), y+cell.GetY(), 1)
+	}
+}
+
+//Randomise Kills every cell then brings the given percentage of them to life
+func (lifeWorld *LifeWorld) Randomise(density int) {
+
+	lifeWorld.Clear()
+
+	for y := 0; y < lifeWorld.Height; y++ {
+		for x := 0; x < lifeWorld.Width; x++ {
+			if rand.Intn(100) < density {
+				lifeWorld.SetCell(x, y, 1)
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func aliveCells(lifeWorld *LifeWorld) []geometry.Coordinates {
+
+	cells := []geometry.Coordinates{}
+
+	for y := 0; y < lifeWorld.Height; y++ {
+		for x := 0; x < lifeWorld.Width; x++ {
+			if lifeWorld.IsAlive(x, y) {
+				cells = append(cells, geometry.NewCoordinate(x, y))
+			}
+		}
+	}
+
+	return cells
+}
+
+func TestLifeWorld_Blinker(t *testing.T) {
+
+	blinker := LifePattern{Dimensions: Dimensions{Width: 3, Height: 1}, Cells: []geometry.Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}}
+	lifeWorld := NewLifeWorld(LifeWorldSettings{Dimensions: Dimensions{Width: 5, Height: 5}, Rule: Life, Pattern: &blinker})
+
+	lifeWorld.Update()
+
+	want := []geometry.Coordinates{{X: 2, Y: 1}, {X: 2, Y: 2}, {X: 2, Y: 3}}
+	got := aliveCells(&lifeWorld)
+
+	if len(got) != len(want) {
+		t.Fatalf("Blinker after one generation = %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Blinker after one generation = %v, want %v", got, want)
+		}
+	}
+
+	lifeWorld.Update()
+
+	if lifeWorld.Population != 3 || !lifeWorld.IsAlive(1, 2) || lifeWorld.Generation != 2 {
+		t.Errorf("Blinker did not return after two generations")
+	}
+}
+
+func TestLifeWorld_GliderOnTorus(t *testing.T) {
+
+	glider, err := ParseRLE("glider", strings.NewReader("x = 3, y = 3\nbo$2bo$3o!"))
+
+	if err != nil {
+		t.Fatalf("ParseRLE() error = %v", err)
+	}
+
+	lifeWorld := NewLifeWorld(LifeWorldSettings{Dimensions: Dimensions{Width: 8, Height: 8}, Rule: Life, Topology: geometry.Torus, Pattern: &glider})
+	start := aliveCells(&lifeWorld)
+
+	//A glider moves one cell diagonally every four generations, so it comes back round an 8x8 torus after 32
+	for i := 0; i < 32; i++ {
+		lifeWorld.Update()
+	}
+
+	got := aliveCells(&lifeWorld)
+	sort.Slice(got, func(i, j int) bool { return got[i].Y*8+got[i].X < got[j].Y*8+got[j].X })
+
+	if len(got) != len(start) {
+		t.Fatalf("Glider after going round the torus = %v, want %v", got, start)
+	}
+
+	for i := range start {
+		if got[i] != start[i] {
+			t.Errorf("Glider after going round the torus = %v, want %v", got, start)
+		}
+	}
+}
+
+func TestParseLifeRule(t *testing.T) {
+
+	tests := []struct {
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{"B3/S23", "B3/S23", false},
+		{"highlife", "B36/S23", false},
+		{"Day & Night", "B3678/S34678", false},
+		{"Seamaze", "B3/S12345", false},
+		{"b2/s", "B2/S", false},
+		{"B9/S23", "", true},
+		{"23/3", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			got, err := ParseLifeRule(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLifeRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("ParseLifeRule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
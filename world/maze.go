@@ -0,0 +1,858 @@
+package world
+
+import (
+	"container/heap"
+	"gopherlife/geometry"
+	"math/rand"
+	"time"
+)
+
+//MazeGenerator the algorithm a MazeWorld carves its maze with. Every algorithm carves a perfect maze, where there is
+//exactly one route between any two cells
+type MazeGenerator int
+
+const (
+	//RecursiveBacktracker carves a random walk, backing up to the last cell with uncarved neighbours when it gets stuck
+	RecursiveBacktracker MazeGenerator = iota
+	//Prims grows the maze from a random cell on its frontier
+	Prims
+	//Kruskals joins random neighbouring cells that are not yet connected
+	Kruskals
+	//Wilsons adds loop-erased random walks that end on the maze
+	Wilsons
+)
+
+func (generator MazeGenerator) String() string {
+	switch generator {
+	case Prims:
+		return "Prim's"
+	case Kruskals:
+		return "Kruskal's"
+	case Wilsons:
+		return "Wilson's"
+	default:
+		return "Recursive Backtracker"
+	}
+}
+
+//MazeSolver the algorithm a MazeWorld finds its way from the start to the end of its maze with
+type MazeSolver int
+
+const (
+	//BreadthFirst searches every cell one step away, then every cell two steps away and so on
+	BreadthFirst MazeSolver = iota
+	//DepthFirst follows a passage as far as it goes before backing up
+	DepthFirst
+	//AStar searches the cells that look closest to the end first
+	AStar
+	//WallFollower walks with its right hand on the wall
+	WallFollower
+)
+
+func (solver MazeSolver) String() string {
+	switch solver {
+	case DepthFirst:
+		return "Depth First"
+	case AStar:
+		return "A*"
+	case WallFollower:
+		return "Wall Follower"
+	default:
+		return "Breadth First"
+	}
+}
+
+//MazePhase what a MazeWorld is animating
+type MazePhase int
+
+const (
+	Generating MazePhase = iota
+	Solving
+	Solved
+)
+
+func (phase MazePhase) String() string {
+	switch phase {
+	case Solving:
+		return "Solving"
+	case Solved:
+		return "Solved"
+	default:
+		return "Generating"
+	}
+}
+
+//MazeTile what is drawn on a tile of a MazeWorld
+type MazeTile int
+
+const (
+	MazeWall MazeTile = iota
+	//MazeUncarved a cell the generator has not reached yet
+	MazeUncarved
+	MazePassage
+	//MazeCarving a cell the generator is working on
+	MazeCarving
+	//MazeFrontier a cell the solver has found but not yet searched from
+	MazeFrontier
+	//MazeVisited a cell the solver has searched from
+	MazeVisited
+	MazePath
+	MazeStart
+	MazeEnd
+)
+
+//mazeDirections the directions a passage can lead from a cell
+var mazeDirections = []geometry.Direction{geometry.Up, geometry.Right, geometry.Down, geometry.Left}
+
+//opposite Returns the Direction pointing the other way
+func opposite(d geometry.Direction) geometry.Direction {
+	return d.TurnClockWise90().TurnClockWise90()
+}
+
+//MazeWorldSettings sets up a MazeWorld. The Dimensions are the number of cells of the maze, it is drawn with a wall
+//between and around every cell
+type MazeWorldSettings struct {
+	Dimensions
+	Seeded
+	Generator MazeGenerator
+	Solver    MazeSolver
+	//StepsPerUpdate the number of steps of the generator or solver animated each Update
+	StepsPerUpdate int
+}
+
+//MazeWorld carves a maze one step at a time, then solves it one step at a time from the bottom left cell to the top
+//right cell
+type MazeWorld struct {
+	MazeWorldSettings
+	Phase MazePhase
+	Steps int
+	//Path the cells from the start to the end, once the maze is solved
+	Path []geometry.Coordinates
+
+	surface  geometry.Surface
+	passages []uint8
+	carved   []bool
+	start    int
+	end      int
+
+	generator mazeGenerator
+	solver    mazeSolver
+	search    mazeSearch
+	onPath    []bool
+	random    *rand.Rand
+}
+
+//NewMazeWorld Returns a MazeWorld that is ready to start carving. Its generator works on the MazeWorld through the
+//pointer, so it must not be copied
+func NewMazeWorld(settings MazeWorldSettings) *MazeWorld {
+
+	if settings.Width < 1 {
+		settings.Width = 1
+	}
+
+	if settings.Height < 1 {
+		settings.Height = 1
+	}
+
+	if settings.StepsPerUpdate < 1 {
+		settings.StepsPerUpdate = 1
+	}
+
+	random := settings.random()
+
+	cells := settings.Width * settings.Height
+
+	mazeWorld := &MazeWorld{
+		MazeWorldSettings: settings,
+		surface:           geometry.NewSurface(0, 0, settings.Width*2+1, settings.Height*2+1, geometry.Bounded),
+		passages:          make([]uint8, cells),
+		carved:            make([]bool, cells),
+		end:               cells - 1,
+		onPath:            make([]bool, cells),
+		random:            random,
+	}
+
+	switch settings.Generator {
+	case Prims:
+		mazeWorld.generator = newPrims(mazeWorld)
+	case Kruskals:
+		mazeWorld.generator = newKruskals(mazeWorld)
+	case Wilsons:
+		mazeWorld.generator = newWilsons(mazeWorld)
+	default:
+		mazeWorld.generator = newBacktracker(mazeWorld)
+	}
+
+	return mazeWorld
+}
+
+//Surface Returns the shape of the tiles the maze is drawn with
+func (mazeWorld *MazeWorld) Surface() *geometry.Surface {
+	return &mazeWorld.surface
+}
+
+//index Returns the index of the cell at x and y, false if it is not in the maze
+func (mazeWorld *MazeWorld) index(x int, y int) (int, bool) {
+
+	if x < 0 || y < 0 || x >= mazeWorld.Width || y >= mazeWorld.Height {
+		return 0, false
+	}
+
+	return y*mazeWorld.Width + x, true
+}
+
+//coordinates Returns the position of the cell
+func (mazeWorld *MazeWorld) coordinates(cell int) geometry.Coordinates {
+	return geometry.NewCoordinate(cell%mazeWorld.Width, cell/mazeWorld.Width)
+}
+
+//neighbour Returns the cell next to the cell in the Direction, false if it is off the maze
+func (mazeWorld *MazeWorld) neighbour(cell int, d geometry.Direction) (int, bool) {
+	return mazeWorld.index(d.AddToPoint(cell%mazeWorld.Width, cell/mazeWorld.Width))
+}
+
+//IsOpen Returns true if there is a passage from the cell at x and y in the Direction
+func (mazeWorld *MazeWorld) IsOpen(x int, y int, d geometry.Direction) bool {
+
+	if cell, ok := mazeWorld.index(x, y); ok {
+		return mazeWorld.passages[cell]&(1<<uint(d)) != 0
+	}
+
+	return false
+}
+
+//isOpen Returns true if there is a passage from the cell in the Direction
+func (mazeWorld *MazeWorld) isOpen(cell int, d geometry.Direction) bool {
+	return mazeWorld.passages[cell]&(1<<uint(d)) != 0
+}
+
+//carve Opens a passage from the cell to its neighbour in the Direction and adds both to the maze. Returns the neighbour
+func (mazeWorld *MazeWorld) carve(cell int, d geometry.Direction) int {
+
+	next, _ := mazeWorld.neighbour(cell, d)
+
+	mazeWorld.passages[cell] |= 1 << uint(d)
+	mazeWorld.passages[next] |= 1 << uint(opposite(d))
+	mazeWorld.carved[cell] = true
+	mazeWorld.carved[next] = true
+
+	return next
+}
+
+//directions Returns the directions from the cell to the neighbours that are, or are not, carved
+func (mazeWorld *MazeWorld) directions(cell int, carved bool) []geometry.Direction {
+
+	directions := []geometry.Direction{}
+
+	for _, d := range mazeDirections {
+		if next, ok := mazeWorld.neighbour(cell, d); ok && mazeWorld.carved[next] == carved {
+			directions = append(directions, d)
+		}
+	}
+
+	return directions
+}
+
+//randomDirection Returns one of the directions at random
+func (mazeWorld *MazeWorld) randomDirection(directions []geometry.Direction) geometry.Direction {
+	return directions[mazeWorld.random.Intn(len(directions))]
+}
+
+//Update Animates StepsPerUpdate steps of carving the maze, then of solving it
+func (mazeWorld *MazeWorld) Update() bool {
+
+	for i := 0; i < mazeWorld.StepsPerUpdate; i++ {
+		mazeWorld.Step()
+	}
+
+	return true
+}
+
+//TickRate Returns the time between each Update
+func (mazeWorld *MazeWorld) TickRate() time.Duration {
+	return time.Millisecond * FrameSpeedMultiplier * 2
+}
+
+//Step Takes one step of the generator or the solver. Once the maze is solved it does nothing
+func (mazeWorld *MazeWorld) Step() {
+
+	switch mazeWorld.Phase {
+	case Generating:
+		if !mazeWorld.generator.step() {
+			mazeWorld.Phase = Solving
+			mazeWorld.startSolving()
+		}
+	case Solving:
+		if !mazeWorld.solver.step() {
+			mazeWorld.Phase = Solved
+			mazeWorld.tracePath()
+		}
+	default:
+		return
+	}
+
+	mazeWorld.Steps++
+}
+
+//startSolving Sets up the solver to search from the start cell
+func (mazeWorld *MazeWorld) startSolving() {
+
+	search := &mazeWorld.search
+	cells := len(mazeWorld.passages)
+
+	search.cameFrom = make([]int, cells)
+	search.visited = make([]bool, cells)
+	search.frontier = make([]bool, cells)
+
+	for i := range search.cameFrom {
+		search.cameFrom[i] = -1
+	}
+
+	search.cameFrom[mazeWorld.start] = mazeWorld.start
+	search.frontier[mazeWorld.start] = true
+
+	switch mazeWorld.Solver {
+	case DepthFirst:
+		mazeWorld.solver = &queueSolver{MazeWorld: mazeWorld, queue: []int{mazeWorld.start}, lastInFirstOut: true}
+	case AStar:
+		mazeWorld.solver = newAStarSolver(mazeWorld)
+	case WallFollower:
+		mazeWorld.solver = &wallFollower{MazeWorld: mazeWorld, position: mazeWorld.start, facing: geometry.Up}
+	default:
+		mazeWorld.solver = &queueSolver{MazeWorld: mazeWorld, queue: []int{mazeWorld.start}}
+	}
+}
+
+//tracePath Follows the cells the solver came from back from the end to the start
+func (mazeWorld *MazeWorld) tracePath() {
+
+	if mazeWorld.search.cameFrom[mazeWorld.end] < 0 {
+		return
+	}
+
+	path := []geometry.Coordinates{}
+
+	for cell := mazeWorld.end; ; cell = mazeWorld.search.cameFrom[cell] {
+		path = append([]geometry.Coordinates{mazeWorld.coordinates(cell)}, path...)
+		mazeWorld.onPath[cell] = true
+		if cell == mazeWorld.start {
+			break
+		}
+	}
+
+	mazeWorld.Path = path
+}
+
+//Tile Returns what to draw at x and y. Cells are drawn on the tiles with odd x and y, the tiles between them are
+//passages or walls. Returns false if x and y are off the maze
+func (mazeWorld *MazeWorld) Tile(x int, y int) (MazeTile, bool) {
+
+	if !mazeWorld.surface.Contains(x, y) {
+		return MazeWall, false
+	}
+
+	oddX, oddY := x%2 == 1, y%2 == 1
+
+	switch {
+	case oddX && oddY:
+		cell, _ := mazeWorld.index(x/2, y/2)
+		return mazeWorld.cellTile(cell), true
+	case oddX:
+		if cell, ok := mazeWorld.index(x/2, y/2-1); ok && mazeWorld.isOpen(cell, geometry.Up) {
+			next, _ := mazeWorld.neighbour(cell, geometry.Up)
+			return mazeWorld.passageTile(cell, next), true
+		}
+	case oddY:
+		if cell, ok := mazeWorld.index(x/2-1, y/2); ok && mazeWorld.isOpen(cell, geometry.Right) {
+			next, _ := mazeWorld.neighbour(cell, geometry.Right)
+			return mazeWorld.passageTile(cell, next), true
+		}
+	}
+
+	return MazeWall, true
+}
+
+//cellTile Returns what to draw for the cell
+func (mazeWorld *MazeWorld) cellTile(cell int) MazeTile {
+
+	switch {
+	case cell == mazeWorld.start && mazeWorld.Phase != Generating:
+		return MazeStart
+	case cell == mazeWorld.end && mazeWorld.Phase != Generating:
+		return MazeEnd
+	case mazeWorld.onPath[cell]:
+		return MazePath
+	case mazeWorld.Phase == Generating && mazeWorld.generator.isActive(cell):
+		return MazeCarving
+	case mazeWorld.Phase != Generating && mazeWorld.search.frontier[cell]:
+		return MazeFrontier
+	case mazeWorld.Phase != Generating && mazeWorld.search.visited[cell]:
+		return MazeVisited
+	case !mazeWorld.carved[cell]:
+		return MazeUncarved
+	}
+
+	return MazePassage
+}
+
+//passageTile Returns what to draw for the open passage between two cells, it takes on the look of the cells it joins
+func (mazeWorld *MazeWorld) passageTile(cell int, next int) MazeTile {
+
+	if mazeWorld.onPath[cell] && mazeWorld.onPath[next] {
+		return MazePath
+	}
+
+	a, b := mazeWorld.cellTile(cell), mazeWorld.cellTile(next)
+
+	switch {
+	case a == MazeCarving && b == MazeCarving:
+		return MazeCarving
+	case mazeWorld.Phase == Generating:
+		return MazePassage
+	case mazeWorld.search.visited[cell] && mazeWorld.search.visited[next]:
+		return MazeVisited
+	case mazeWorld.search.cameFrom[cell] >= 0 && mazeWorld.search.cameFrom[next] >= 0:
+		return MazeFrontier
+	}
+
+	return MazePassage
+}
+
+//mazeGenerator carves a maze one step at a time
+type mazeGenerator interface {
+	//step Carves a little more of the maze. Returns false once the maze is finished
+	step() bool
+	//isActive Returns true if the generator is working on the cell
+	isActive(cell int) bool
+}
+
+//backtracker carves with a RecursiveBacktracker, the stack holds the walk back to the first cell
+type backtracker struct {
+	*MazeWorld
+	stack   []int
+	onStack []bool
+}
+
+func newBacktracker(mazeWorld *MazeWorld) *backtracker {
+
+	first := mazeWorld.random.Intn(len(mazeWorld.passages))
+	mazeWorld.carved[first] = true
+
+	generator := backtracker{MazeWorld: mazeWorld, stack: []int{first}, onStack: make([]bool, len(mazeWorld.passages))}
+	generator.onStack[first] = true
+
+	return &generator
+}
+
+func (generator *backtracker) step() bool {
+
+	if len(generator.stack) == 0 {
+		return false
+	}
+
+	current := generator.stack[len(generator.stack)-1]
+	directions := generator.directions(current, false)
+
+	if len(directions) == 0 {
+		generator.stack = generator.stack[:len(generator.stack)-1]
+		generator.onStack[current] = false
+		return len(generator.stack) > 0
+	}
+
+	next := generator.carve(current, generator.randomDirection(directions))
+	generator.stack = append(generator.stack, next)
+	generator.onStack[next] = true
+
+	return true
+}
+
+func (generator *backtracker) isActive(cell int) bool {
+	return generator.onStack[cell]
+}
+
+//prims carves with Prim's algorithm, the frontier holds the cells next to the maze that are not yet part of it
+type prims struct {
+	*MazeWorld
+	frontier   []int
+	inFrontier []bool
+}
+
+func newPrims(mazeWorld *MazeWorld) *prims {
+
+	generator := prims{MazeWorld: mazeWorld, inFrontier: make([]bool, len(mazeWorld.passages))}
+
+	first := mazeWorld.random.Intn(len(mazeWorld.passages))
+	mazeWorld.carved[first] = true
+	generator.addFrontier(first)
+
+	return &generator
+}
+
+//addFrontier Adds the uncarved neighbours of the cell to the frontier
+func (generator *prims) addFrontier(cell int) {
+	for _, d := range generator.directions(cell, false) {
+		if next, _ := generator.neighbour(cell, d); !generator.inFrontier[next] {
+			generator.inFrontier[next] = true
+			generator.frontier = append(generator.frontier, next)
+		}
+	}
+}
+
+func (generator *prims) step() bool {
+
+	if len(generator.frontier) == 0 {
+		return false
+	}
+
+	i := generator.random.Intn(len(generator.frontier))
+	cell := generator.frontier[i]
+
+	generator.frontier[i] = generator.frontier[len(generator.frontier)-1]
+	generator.frontier = generator.frontier[:len(generator.frontier)-1]
+	generator.inFrontier[cell] = false
+
+	generator.carve(cell, generator.randomDirection(generator.directions(cell, true)))
+	generator.addFrontier(cell)
+
+	return len(generator.frontier) > 0
+}
+
+func (generator *prims) isActive(cell int) bool {
+	return generator.inFrontier[cell]
+}
+
+//mazeWall a wall between a cell and its neighbour in a Direction
+type mazeWall struct {
+	cell int
+	d    geometry.Direction
+}
+
+//kruskals carves with Kruskal's algorithm. Each cell starts in a set of its own, knocking down a wall between two
+//sets joins them, until every cell is in the same set
+type kruskals struct {
+	*MazeWorld
+	walls   []mazeWall
+	sets    []int
+	last    [2]int
+	hasLast bool
+}
+
+func newKruskals(mazeWorld *MazeWorld) *kruskals {
+
+	generator := kruskals{MazeWorld: mazeWorld, sets: make([]int, len(mazeWorld.passages))}
+
+	for cell := range generator.sets {
+
+		generator.sets[cell] = cell
+
+		for _, d := range []geometry.Direction{geometry.Up, geometry.Right} {
+			if _, ok := mazeWorld.neighbour(cell, d); ok {
+				generator.walls = append(generator.walls, mazeWall{cell: cell, d: d})
+			}
+		}
+	}
+
+	mazeWorld.random.Shuffle(len(generator.walls), func(i, j int) {
+		generator.walls[i], generator.walls[j] = generator.walls[j], generator.walls[i]
+	})
+
+	if len(generator.sets) == 1 {
+		mazeWorld.carved[0] = true
+	}
+
+	return &generator
+}
+
+//find Returns the set the cell is in
+func (generator *kruskals) find(cell int) int {
+	for generator.sets[cell] != cell {
+		generator.sets[cell] = generator.sets[generator.sets[cell]]
+		cell = generator.sets[cell]
+	}
+	return cell
+}
+
+func (generator *kruskals) step() bool {
+
+	for len(generator.walls) > 0 {
+
+		wall := generator.walls[len(generator.walls)-1]
+		generator.walls = generator.walls[:len(generator.walls)-1]
+
+		next, _ := generator.neighbour(wall.cell, wall.d)
+		a, b := generator.find(wall.cell), generator.find(next)
+
+		if a != b {
+			generator.sets[a] = b
+			generator.carve(wall.cell, wall.d)
+			generator.last = [2]int{wall.cell, next}
+			generator.hasLast = true
+			return true
+		}
+	}
+
+	return false
+}
+
+func (generator *kruskals) isActive(cell int) bool {
+	return generator.hasLast && (generator.last[0] == cell || generator.last[1] == cell)
+}
+
+//wilsons carves with Wilson's algorithm. A random walk starts from a cell outside the maze and wanders until it hits
+//the maze, then the walk, with any loops it made erased, is carved into the maze
+type wilsons struct {
+	*MazeWorld
+	remaining []int
+	walkStart int
+	walking   int
+	//heading the way the walk last left each cell, walking into a cell again overwrites it which erases the loop
+	heading []geometry.Direction
+	onWalk  []bool
+}
+
+func newWilsons(mazeWorld *MazeWorld) *wilsons {
+
+	generator := wilsons{
+		MazeWorld: mazeWorld,
+		remaining: mazeWorld.random.Perm(len(mazeWorld.passages)),
+		walkStart: -1,
+		heading:   make([]geometry.Direction, len(mazeWorld.passages)),
+		onWalk:    make([]bool, len(mazeWorld.passages)),
+	}
+
+	mazeWorld.carved[generator.remaining[0]] = true
+
+	return &generator
+}
+
+func (generator *wilsons) step() bool {
+
+	if generator.walkStart < 0 {
+
+		for len(generator.remaining) > 0 && generator.carved[generator.remaining[0]] {
+			generator.remaining = generator.remaining[1:]
+		}
+
+		if len(generator.remaining) == 0 {
+			return false
+		}
+
+		generator.walkStart = generator.remaining[0]
+		generator.walking = generator.walkStart
+		generator.markWalk()
+
+		return true
+	}
+
+	d := generator.randomDirection(append(generator.directions(generator.walking, false), generator.directions(generator.walking, true)...))
+	generator.heading[generator.walking] = d
+	next, _ := generator.neighbour(generator.walking, d)
+
+	if generator.carved[next] {
+		for cell, joined := generator.walkStart, false; !joined; {
+			following, _ := generator.neighbour(cell, generator.heading[cell])
+			joined = generator.carved[following]
+			cell = generator.carve(cell, generator.heading[cell])
+		}
+		generator.walkStart = -1
+		generator.markWalk()
+		return true
+	}
+
+	generator.walking = next
+	generator.markWalk()
+
+	return true
+}
+
+//markWalk Marks the cells on the loop-erased walk from its start to the cell being walked from
+func (generator *wilsons) markWalk() {
+
+	for i := range generator.onWalk {
+		generator.onWalk[i] = false
+	}
+
+	if generator.walkStart < 0 {
+		return
+	}
+
+	cell := generator.walkStart
+	generator.onWalk[cell] = true
+
+	for cell != generator.walking {
+		cell, _ = generator.neighbour(cell, generator.heading[cell])
+		generator.onWalk[cell] = true
+	}
+}
+
+func (generator *wilsons) isActive(cell int) bool {
+	return generator.onWalk[cell]
+}
+
+//mazeSearch what a solver has found so far. cameFrom holds the cell each cell was first reached from, or -1 if it
+//has not been reached
+type mazeSearch struct {
+	cameFrom []int
+	visited  []bool
+	frontier []bool
+}
+
+//mazeSolver searches a maze one step at a time
+type mazeSolver interface {
+	//step Searches from one more cell. Returns false once the end has been reached or there is nowhere left to search
+	step() bool
+}
+
+//visit Marks the cell as searched and adds the neighbours it has passages to, that have not been reached, to the
+//frontier. Returns the neighbours added
+func (mazeWorld *MazeWorld) visit(cell int) []int {
+
+	search := &mazeWorld.search
+	search.frontier[cell] = false
+	search.visited[cell] = true
+
+	found := []int{}
+
+	for _, d := range mazeDirections {
+		if next, ok := mazeWorld.neighbour(cell, d); ok && mazeWorld.isOpen(cell, d) && search.cameFrom[next] < 0 {
+			search.cameFrom[next] = cell
+			search.frontier[next] = true
+			found = append(found, next)
+		}
+	}
+
+	return found
+}
+
+//queueSolver searches BreadthFirst, or DepthFirst if it takes the last cell added to the queue instead of the first
+type queueSolver struct {
+	*MazeWorld
+	queue          []int
+	lastInFirstOut bool
+}
+
+func (solver *queueSolver) step() bool {
+
+	if len(solver.queue) == 0 || solver.search.visited[solver.end] {
+		return false
+	}
+
+	var cell int
+
+	if solver.lastInFirstOut {
+		cell = solver.queue[len(solver.queue)-1]
+		solver.queue = solver.queue[:len(solver.queue)-1]
+	} else {
+		cell = solver.queue[0]
+		solver.queue = solver.queue[1:]
+	}
+
+	solver.queue = append(solver.queue, solver.visit(cell)...)
+
+	return cell != solver.end
+}
+
+//aStarSolver searches the cell with the shortest distance walked from the start plus the distance left to the end
+type aStarSolver struct {
+	*MazeWorld
+	open     mazeCellHeap
+	walked   []int
+	distance func(int) int
+}
+
+func newAStarSolver(mazeWorld *MazeWorld) *aStarSolver {
+
+	end := mazeWorld.coordinates(mazeWorld.end)
+
+	solver := aStarSolver{
+		MazeWorld: mazeWorld,
+		walked:    make([]int, len(mazeWorld.passages)),
+		distance: func(cell int) int {
+			c := mazeWorld.coordinates(cell)
+			return geometry.Abs(end.X-c.X) + geometry.Abs(end.Y-c.Y)
+		},
+	}
+
+	heap.Push(&solver.open, mazeCell{cell: mazeWorld.start, priority: solver.distance(mazeWorld.start)})
+
+	return &solver
+}
+
+func (solver *aStarSolver) step() bool {
+
+	if solver.open.Len() == 0 || solver.search.visited[solver.end] {
+		return false
+	}
+
+	cell := heap.Pop(&solver.open).(mazeCell).cell
+
+	for _, next := range solver.visit(cell) {
+		solver.walked[next] = solver.walked[cell] + 1
+		heap.Push(&solver.open, mazeCell{cell: next, priority: solver.walked[next] + solver.distance(next)})
+	}
+
+	return cell != solver.end
+}
+
+//mazeCell a cell waiting to be searched by the aStarSolver
+type mazeCell struct {
+	cell     int
+	priority int
+}
+
+//mazeCellHeap a heap of cells with the lowest priority first
+type mazeCellHeap []mazeCell
+
+func (h mazeCellHeap) Len() int            { return len(h) }
+func (h mazeCellHeap) Less(i, j int) bool  { return h[i].priority < h[j].priority }
+func (h mazeCellHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *mazeCellHeap) Push(x interface{}) { *h = append(*h, x.(mazeCell)) }
+
+func (h *mazeCellHeap) Pop() interface{} {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+//wallFollower walks with its right hand on the wall, turning right whenever it can. In a perfect maze it visits every
+//cell it needs to on the way to the end
+type wallFollower struct {
+	*MazeWorld
+	position int
+	facing   geometry.Direction
+}
+
+func (solver *wallFollower) step() bool {
+
+	solver.search.frontier[solver.position] = false
+	solver.search.visited[solver.position] = true
+
+	if solver.position == solver.end {
+		return false
+	}
+
+	for _, d := range []geometry.Direction{
+		solver.facing.TurnClockWise90(),
+		solver.facing,
+		solver.facing.TurnAntiClockWise90(),
+		opposite(solver.facing),
+	} {
+		if solver.isOpen(solver.position, d) {
+
+			next, _ := solver.neighbour(solver.position, d)
+
+			if solver.search.cameFrom[next] < 0 {
+				solver.search.cameFrom[next] = solver.position
+			}
+
+			solver.position = next
+			solver.facing = d
+			solver.search.frontier[next] = true
+
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,125 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"testing"
+)
+
+var mazeGenerators = []MazeGenerator{RecursiveBacktracker, Prims, Kruskals, Wilsons}
+var mazeSolvers = []MazeSolver{BreadthFirst, DepthFirst, AStar, WallFollower}
+
+//solveMaze Steps the MazeWorld until it is solved, failing if it takes far longer than it should
+func solveMaze(t *testing.T, mazeWorld *MazeWorld) {
+
+	for i := 0; mazeWorld.Phase != Solved; i++ {
+		if i > 100*mazeWorld.Width*mazeWorld.Height {
+			t.Fatalf("%v maze solved with %v did not finish", mazeWorld.Generator, mazeWorld.Solver)
+		}
+		mazeWorld.Step()
+	}
+}
+
+func TestMazeWorld_GeneratorsCarvePerfectMazes(t *testing.T) {
+
+	for _, generator := range mazeGenerators {
+
+		mazeWorld := NewMazeWorld(MazeWorldSettings{Dimensions: Dimensions{Width: 12, Height: 9}, Generator: generator, Seeded: Seeded{Seed: 1}})
+		solveMaze(t, mazeWorld)
+
+		passages := 0
+		for y := 0; y < mazeWorld.Height; y++ {
+			for x := 0; x < mazeWorld.Width; x++ {
+				if mazeWorld.IsOpen(x, y, geometry.Up) {
+					passages++
+				}
+				if mazeWorld.IsOpen(x, y, geometry.Right) {
+					passages++
+				}
+			}
+		}
+
+		//A maze with a single route between every pair of cells is a tree, with one passage fewer than it has cells
+		if cells := mazeWorld.Width * mazeWorld.Height; passages != cells-1 {
+			t.Errorf("%v carved %d passages between %d cells, want %d", generator, passages, cells, cells-1)
+		}
+
+		for cell, carved := range mazeWorld.carved {
+			if !carved {
+				t.Errorf("%v did not carve cell %v", generator, mazeWorld.coordinates(cell))
+				break
+			}
+		}
+	}
+}
+
+func TestMazeWorld_SolversFindThePath(t *testing.T) {
+
+	var want []geometry.Coordinates
+
+	for _, solver := range mazeSolvers {
+
+		mazeWorld := NewMazeWorld(MazeWorldSettings{Dimensions: Dimensions{Width: 15, Height: 10}, Solver: solver, Seeded: Seeded{Seed: 2}})
+		solveMaze(t, mazeWorld)
+
+		path := mazeWorld.Path
+
+		if len(path) == 0 || path[0] != geometry.NewCoordinate(0, 0) || path[len(path)-1] != geometry.NewCoordinate(14, 9) {
+			t.Fatalf("%v path = %v, want it to go from (0, 0) to (14, 9)", solver, path)
+		}
+
+		for i := 1; i < len(path); i++ {
+
+			joined := false
+			for _, d := range mazeDirections {
+				if x, y := d.AddToPoint(path[i-1].X, path[i-1].Y); x == path[i].X && y == path[i].Y {
+					joined = mazeWorld.IsOpen(path[i-1].X, path[i-1].Y, d)
+				}
+			}
+
+			if !joined {
+				t.Fatalf("%v path goes through a wall between %v and %v", solver, path[i-1], path[i])
+			}
+		}
+
+		//A perfect maze has only one route from the start to the end
+		if want == nil {
+			want = path
+		} else if len(path) != len(want) {
+			t.Errorf("%v path is %d cells long, want %d", solver, len(path), len(want))
+		}
+	}
+}
+
+func TestMazeWorld_Tile(t *testing.T) {
+
+	mazeWorld := NewMazeWorld(MazeWorldSettings{Dimensions: Dimensions{Width: 2, Height: 1}, Seeded: Seeded{Seed: 1}})
+
+	if tile, _ := mazeWorld.Tile(1, 1); tile != MazeUncarved && tile != MazeCarving {
+		t.Errorf("Cell before carving = %v, want it uncarved", tile)
+	}
+
+	solveMaze(t, mazeWorld)
+
+	//The two cells and the passage between them make up the whole path
+	if tile, _ := mazeWorld.Tile(2, 1); tile != MazePath {
+		t.Errorf("Passage between the cells = %v, want MazePath", tile)
+	}
+
+	if tile, _ := mazeWorld.Tile(1, 1); tile != MazeStart {
+		t.Errorf("Start cell = %v, want MazeStart", tile)
+	}
+
+	if tile, _ := mazeWorld.Tile(3, 1); tile != MazeEnd {
+		t.Errorf("End cell = %v, want MazeEnd", tile)
+	}
+
+	for _, corner := range [][2]int{{0, 0}, {2, 2}, {4, 0}} {
+		if tile, _ := mazeWorld.Tile(corner[0], corner[1]); tile != MazeWall {
+			t.Errorf("Tile%v = %v, want MazeWall", corner, tile)
+		}
+	}
+
+	if _, ok := mazeWorld.Tile(5, 0); ok {
+		t.Errorf("Tile(5, 0) is on a maze 5 tiles wide")
+	}
+}
@@ -0,0 +1,111 @@
+package world
+
+//PheromoneField the strength of a scent laid on every tile of a grid. Each Step the scent fades away and spreads to
+//the tiles next to it
+type PheromoneField struct {
+	Dimensions
+	values []float64
+	next   []float64
+}
+
+//NewPheromoneField Returns a PheromoneField with no scent on any tile
+func NewPheromoneField(d Dimensions) PheromoneField {
+	return PheromoneField{
+		Dimensions: d,
+		values:     make([]float64, d.Width*d.Height),
+		next:       make([]float64, d.Width*d.Height),
+	}
+}
+
+//contains Returns true if x and y are on the field
+func (field *PheromoneField) contains(x int, y int) bool {
+	return x >= 0 && y >= 0 && x < field.Width && y < field.Height
+}
+
+//Value Returns the strength of the scent at x and y, zero if it is off the field
+func (field *PheromoneField) Value(x int, y int) float64 {
+
+	if !field.contains(x, y) {
+		return 0
+	}
+
+	return field.values[y*field.Width+x]
+}
+
+//Deposit Adds the amount of scent to the tile at x and y
+func (field *PheromoneField) Deposit(x int, y int, amount float64) {
+	if field.contains(x, y) {
+		field.values[y*field.Width+x] += amount
+	}
+}
+
+//Max Returns the strongest scent on the field
+func (field *PheromoneField) Max() float64 {
+
+	max := 0.0
+
+	for _, value := range field.values {
+		if value > max {
+			max = value
+		}
+	}
+
+	return max
+}
+
+//Total Returns the sum of the scent on every tile
+func (field *PheromoneField) Total() float64 {
+
+	total := 0.0
+
+	for _, value := range field.values {
+		total += value
+	}
+
+	return total
+}
+
+//Step Spreads the diffusion fraction of the scent on each tile evenly between the tiles next to it, then fades every
+//tile by the evaporation fraction. Scent that would spread off the field stays where it is
+func (field *PheromoneField) Step(evaporation float64, diffusion float64) {
+
+	for i := range field.next {
+		field.next[i] = 0
+	}
+
+	for y := 0; y < field.Height; y++ {
+		for x := 0; x < field.Width; x++ {
+
+			value := field.values[y*field.Width+x]
+
+			if value == 0 {
+				continue
+			}
+
+			share := value * diffusion / 4
+			kept := value - value*diffusion
+
+			for _, n := range [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
+				if nx, ny := x+n[0], y+n[1]; field.contains(nx, ny) {
+					field.next[ny*field.Width+nx] += share
+				} else {
+					kept += share
+				}
+			}
+
+			field.next[y*field.Width+x] += kept
+		}
+	}
+
+	for i, value := range field.next {
+		if value *= 1 - evaporation; value < minimumPheromone {
+			value = 0
+		}
+		field.next[i] = value
+	}
+
+	field.values, field.next = field.next, field.values
+}
+
+//minimumPheromone scent weaker than this is removed, so faint scent does not linger forever
+const minimumPheromone = 0.001
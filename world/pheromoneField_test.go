@@ -0,0 +1,55 @@
+package world
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPheromoneField_DiffusionKeepsTotal(t *testing.T) {
+
+	field := NewPheromoneField(Dimensions{Width: 5, Height: 5})
+	field.Deposit(0, 0, 10)
+	field.Deposit(2, 2, 10)
+
+	for i := 0; i < 10; i++ {
+		field.Step(0, 0.5)
+	}
+
+	if total := field.Total(); math.Abs(total-20) > 0.01 {
+		t.Errorf("PheromoneField.Total() = %v after diffusing, want 20", total)
+	}
+
+	if field.Value(2, 2) >= 10 || field.Value(2, 3) == 0 {
+		t.Errorf("Pheromone did not spread from (2, 2)")
+	}
+}
+
+func TestPheromoneField_Evaporation(t *testing.T) {
+
+	field := NewPheromoneField(Dimensions{Width: 3, Height: 3})
+	field.Deposit(1, 1, 1)
+
+	field.Step(0.5, 0)
+
+	if value := field.Value(1, 1); value != 0.5 {
+		t.Errorf("PheromoneField.Value(1, 1) = %v, want 0.5 after half evaporates", value)
+	}
+
+	for i := 0; i < 20; i++ {
+		field.Step(0.5, 0)
+	}
+
+	if field.Max() != 0 {
+		t.Errorf("PheromoneField.Max() = %v, want faint pheromone to be removed", field.Max())
+	}
+}
+
+func TestPheromoneField_OffField(t *testing.T) {
+
+	field := NewPheromoneField(Dimensions{Width: 3, Height: 3})
+	field.Deposit(-1, 5, 1)
+
+	if field.Value(-1, 5) != 0 || field.Total() != 0 {
+		t.Errorf("Pheromone deposited off the field was kept")
+	}
+}
@@ -0,0 +1,372 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"math/rand"
+	"time"
+)
+
+//Material what a SandTile is made of
+type Material uint8
+
+//Materials of a SandWorld
+const (
+	Empty Material = iota
+	Sand
+	Water
+	Stone
+	Fire
+	Smoke
+	Wood
+	Oil
+)
+
+//Materials every Material in the order they are numbered
+var Materials = []Material{Empty, Sand, Water, Stone, Fire, Smoke, Wood, Oil}
+
+func (material Material) String() string {
+	switch material {
+	case Sand:
+		return "Sand"
+	case Water:
+		return "Water"
+	case Stone:
+		return "Stone"
+	case Fire:
+		return "Fire"
+	case Smoke:
+		return "Smoke"
+	case Wood:
+		return "Wood"
+	case Oil:
+		return "Oil"
+	}
+	return "Empty"
+}
+
+//density Returns how heavy the Material is. A falling Material swaps places with a lighter one below it. Stone and Wood
+//never move
+func (material Material) density() int {
+	switch material {
+	case Smoke, Fire:
+		return 0
+	case Empty:
+		return 1
+	case Oil:
+		return 2
+	case Water:
+		return 3
+	case Sand:
+		return 5
+	}
+	return 10
+}
+
+//isMovable Returns true if the Material can be pushed out of the way by a heavier one
+func (material Material) isMovable() bool {
+	return material != Stone && material != Wood
+}
+
+//isFlammable Returns true if Fire spreads to the Material
+func (material Material) isFlammable() bool {
+	return material == Wood || material == Oil
+}
+
+//Lifetimes of the Materials that burn out or fade away, in updates
+const (
+	fireLifetime  = 30
+	smokeLifetime = 60
+)
+
+//Chances out of 100 of things happening to a tile each update
+const (
+	burnChance     = 10
+	fireRiseChance = 30
+	smokeChance    = 40
+)
+
+//SandTile a single tile of a SandWorld
+type SandTile struct {
+	Material
+	//Shade a small random amount each tile is lightened or darkened by when drawn, so piles of a Material have texture
+	Shade uint8
+	//Life the number of updates left before Fire or Smoke burns out
+	Life uint8
+	//clock the parity of the last update that moved the tile, so a tile moved into a row that is yet to be scanned is
+	//not moved twice
+	clock bool
+}
+
+//SandWorldSettings sets up a SandWorld
+type SandWorldSettings struct {
+	Dimensions
+	Seeded
+}
+
+//SandWorld a falling sand world. Each tile holds a Material that follows its own rules each update, sand falls and
+//piles up, liquids fall and spread sideways, gases rise and fire burns what is around it. The y axis points up, so
+//gravity pulls towards row zero
+type SandWorld struct {
+	SandWorldSettings
+
+	surface geometry.Surface
+	tiles   []SandTile
+	frame   int
+	random  *rand.Rand
+}
+
+//NewSandWorld Returns an empty SandWorld
+func NewSandWorld(settings SandWorldSettings) SandWorld {
+
+	if settings.Width < 1 {
+		settings.Width = 1
+	}
+
+	if settings.Height < 1 {
+		settings.Height = 1
+	}
+
+	random := settings.random()
+
+	return SandWorld{
+		SandWorldSettings: settings,
+		surface:           geometry.NewSurface(0, 0, settings.Width, settings.Height, geometry.Bounded),
+		tiles:             make([]SandTile, settings.Width*settings.Height),
+		random:            random,
+	}
+}
+
+//Surface Returns the shape of the World
+func (sandWorld *SandWorld) Surface() *geometry.Surface {
+	return &sandWorld.surface
+}
+
+//Tile Returns the tile at x and y, false if it is off the World
+func (sandWorld *SandWorld) Tile(x int, y int) (SandTile, bool) {
+
+	if !sandWorld.surface.Contains(x, y) {
+		return SandTile{Material: Stone}, false
+	}
+
+	return sandWorld.tiles[y*sandWorld.Width+x], true
+}
+
+//tile Returns the tile at x and y so it can be changed, nil if it is off the World
+func (sandWorld *SandWorld) tile(x int, y int) *SandTile {
+
+	if !sandWorld.surface.Contains(x, y) {
+		return nil
+	}
+
+	return &sandWorld.tiles[y*sandWorld.Width+x]
+}
+
+//newTile Returns a tile of the Material with a random shade and a full life
+func (sandWorld *SandWorld) newTile(material Material) SandTile {
+
+	tile := SandTile{Material: material, Shade: uint8(sandWorld.random.Intn(32)), clock: sandWorld.clock()}
+
+	switch material {
+	case Fire:
+		tile.Life = uint8(fireLifetime/2 + sandWorld.random.Intn(fireLifetime/2))
+	case Smoke:
+		tile.Life = uint8(smokeLifetime/2 + sandWorld.random.Intn(smokeLifetime/2))
+	}
+
+	return tile
+}
+
+//Set Fills the tile with the Material. Returns false if it is off the World
+func (sandWorld *SandWorld) Set(x int, y int, material Material) bool {
+
+	if tile := sandWorld.tile(x, y); tile != nil {
+		*tile = sandWorld.newTile(material)
+		return true
+	}
+
+	return false
+}
+
+//Paint Fills every tile within the radius of x and y with the Material. Painting over a tile that is not empty only
+//replaces it when painting Empty, so a brush can be dragged over a pile without flattening it
+func (sandWorld *SandWorld) Paint(x int, y int, radius int, material Material) {
+
+	for _, c := range geometry.CirclePoints(geometry.NewCoordinate(x, y), radius) {
+		if tile := sandWorld.tile(c.GetX(), c.GetY()); tile != nil && (material == Empty || tile.Material == Empty) {
+			*tile = sandWorld.newTile(material)
+		}
+	}
+}
+
+//Count Returns the number of tiles of the Material
+func (sandWorld *SandWorld) Count(material Material) int {
+
+	count := 0
+
+	for _, tile := range sandWorld.tiles {
+		if tile.Material == material {
+			count++
+		}
+	}
+
+	return count
+}
+
+//clock Returns the parity of the current update
+func (sandWorld *SandWorld) clock() bool {
+	return sandWorld.frame%2 == 1
+}
+
+//Update Applies the rule of each tile's Material once. Rows are scanned from the bottom up, and the direction each row
+//is scanned in alternates every update so nothing drifts to one side
+func (sandWorld *SandWorld) Update() bool {
+
+	sandWorld.frame++
+	clock := sandWorld.clock()
+	leftToRight := sandWorld.frame%2 == 0
+
+	for y := 0; y < sandWorld.Height; y++ {
+		for i := 0; i < sandWorld.Width; i++ {
+
+			x := i
+			if !leftToRight {
+				x = sandWorld.Width - 1 - i
+			}
+
+			tile := sandWorld.tile(x, y)
+
+			if tile.Material == Empty || tile.clock == clock {
+				continue
+			}
+
+			tile.clock = clock
+
+			switch tile.Material {
+			case Sand:
+				sandWorld.fall(x, y, false)
+			case Water, Oil:
+				sandWorld.fall(x, y, true)
+			case Fire:
+				sandWorld.burn(x, y)
+			case Smoke:
+				sandWorld.rise(x, y)
+			}
+		}
+	}
+
+	return true
+}
+
+//TickRate Returns the time between each Update
+func (sandWorld *SandWorld) TickRate() time.Duration {
+	return time.Millisecond * FrameSpeedMultiplier * 3
+}
+
+//randomSide Returns -1 or 1 at random
+func (sandWorld *SandWorld) randomSide() int {
+	if sandWorld.random.Intn(2) == 0 {
+		return -1
+	}
+	return 1
+}
+
+//swap Swaps the tiles at x, y and tx, ty
+func (sandWorld *SandWorld) swap(x int, y int, tx int, ty int) {
+	a, b := sandWorld.tile(x, y), sandWorld.tile(tx, ty)
+	*a, *b = *b, *a
+}
+
+//sinksInto Returns true if the tile at x and y can move into tx and ty because what is there is lighter
+func (sandWorld *SandWorld) sinksInto(x int, y int, tx int, ty int) bool {
+	target := sandWorld.tile(tx, ty)
+	return target != nil && target.isMovable() && target.density() < sandWorld.tile(x, y).density()
+}
+
+//fall Moves the tile down, or diagonally down if it is blocked. Liquids that cannot fall spread sideways
+func (sandWorld *SandWorld) fall(x int, y int, liquid bool) {
+
+	side := sandWorld.randomSide()
+
+	for _, move := range [][2]int{{0, -1}, {side, -1}, {-side, -1}} {
+		if sandWorld.sinksInto(x, y, x+move[0], y+move[1]) {
+			sandWorld.swap(x, y, x+move[0], y+move[1])
+			return
+		}
+	}
+
+	if liquid {
+		for _, dx := range []int{side, -side} {
+			if target := sandWorld.tile(x+dx, y); target != nil && target.Material == Empty {
+				sandWorld.swap(x, y, x+dx, y)
+				return
+			}
+		}
+	}
+}
+
+//rise Moves the gas up, or diagonally or sideways if it is blocked, and fades it away as it goes
+func (sandWorld *SandWorld) rise(x int, y int) {
+
+	tile := sandWorld.tile(x, y)
+
+	if tile.Life == 0 {
+		*tile = sandWorld.newTile(Empty)
+		return
+	}
+
+	tile.Life--
+
+	side := sandWorld.randomSide()
+
+	for _, move := range [][2]int{{0, 1}, {side, 1}, {-side, 1}, {side, 0}} {
+		if target := sandWorld.tile(x+move[0], y+move[1]); target != nil && target.Material == Empty {
+			sandWorld.swap(x, y, x+move[0], y+move[1])
+			return
+		}
+	}
+}
+
+//burn Spreads the fire to the flammable tiles around it and flickers it upwards. Fire next to water is put out, fire
+//that has burnt out leaves smoke behind
+func (sandWorld *SandWorld) burn(x int, y int) {
+
+	tile := sandWorld.tile(x, y)
+
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+
+			neighbour := sandWorld.tile(x+dx, y+dy)
+
+			if neighbour == nil || dx == 0 && dy == 0 {
+				continue
+			}
+
+			if neighbour.Material == Water {
+				*tile = sandWorld.newTile(Smoke)
+				return
+			}
+
+			if neighbour.isFlammable() && sandWorld.random.Intn(100) < burnChance {
+				*neighbour = sandWorld.newTile(Fire)
+			}
+		}
+	}
+
+	if tile.Life == 0 {
+		if sandWorld.random.Intn(100) < smokeChance {
+			*tile = sandWorld.newTile(Smoke)
+		} else {
+			*tile = sandWorld.newTile(Empty)
+		}
+		return
+	}
+
+	tile.Life--
+
+	if sandWorld.random.Intn(100) < fireRiseChance {
+		tx := x + sandWorld.randomSide()
+		if above := sandWorld.tile(tx, y+1); above != nil && above.Material == Empty {
+			sandWorld.swap(x, y, tx, y+1)
+		}
+	}
+}
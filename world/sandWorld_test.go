@@ -0,0 +1,98 @@
+package world
+
+import "testing"
+
+func TestSandWorld_SandPilesUp(t *testing.T) {
+
+	sandWorld := NewSandWorld(SandWorldSettings{Dimensions: Dimensions{Width: 5, Height: 5}, Seeded: Seeded{Seed: 1}})
+	sandWorld.Set(2, 4, Sand)
+	sandWorld.Set(2, 3, Sand)
+
+	for i := 0; i < 10; i++ {
+		sandWorld.Update()
+	}
+
+	if tile, _ := sandWorld.Tile(2, 0); tile.Material != Sand {
+		t.Errorf("Tile (2, 0) = %v, want Sand to have fallen to the bottom", tile.Material)
+	}
+
+	//The second grain cannot rest on the first so it slides off diagonally
+	left, _ := sandWorld.Tile(1, 0)
+	right, _ := sandWorld.Tile(3, 0)
+
+	if left.Material != Sand && right.Material != Sand {
+		t.Errorf("Second grain of Sand did not slide off the first")
+	}
+
+	if sandWorld.Count(Sand) != 2 {
+		t.Errorf("SandWorld.Count(Sand) = %d, want 2", sandWorld.Count(Sand))
+	}
+}
+
+func TestSandWorld_SandSinksInWater(t *testing.T) {
+
+	sandWorld := NewSandWorld(SandWorldSettings{Dimensions: Dimensions{Width: 1, Height: 3}, Seeded: Seeded{Seed: 1}})
+	sandWorld.Set(0, 0, Water)
+	sandWorld.Set(0, 1, Sand)
+
+	sandWorld.Update()
+
+	bottom, _ := sandWorld.Tile(0, 0)
+	above, _ := sandWorld.Tile(0, 1)
+
+	if bottom.Material != Sand || above.Material != Water {
+		t.Errorf("Sand on Water = %v under %v, want Sand under Water", bottom.Material, above.Material)
+	}
+}
+
+func TestSandWorld_WaterSpreads(t *testing.T) {
+
+	sandWorld := NewSandWorld(SandWorldSettings{Dimensions: Dimensions{Width: 9, Height: 3}, Seeded: Seeded{Seed: 1}})
+	sandWorld.Paint(4, 1, 1, Water)
+
+	for i := 0; i < 50; i++ {
+		sandWorld.Update()
+	}
+
+	bottom := 0
+	for x := 0; x < 9; x++ {
+		if tile, _ := sandWorld.Tile(x, 0); tile.Material == Water {
+			bottom++
+		}
+	}
+
+	if water := sandWorld.Count(Water); bottom != water {
+		t.Errorf("%d of %d Water tiles are on the bottom row, want all of them to have spread out along it", bottom, water)
+	}
+}
+
+func TestSandWorld_FireBurnsWood(t *testing.T) {
+
+	sandWorld := NewSandWorld(SandWorldSettings{Dimensions: Dimensions{Width: 10, Height: 3}, Seeded: Seeded{Seed: 1}})
+
+	for x := 0; x < 10; x++ {
+		sandWorld.Set(x, 0, Wood)
+	}
+
+	sandWorld.Set(0, 1, Fire)
+
+	for i := 0; i < 1000; i++ {
+		sandWorld.Update()
+	}
+
+	if sandWorld.Count(Wood) == 10 || sandWorld.Count(Fire) != 0 {
+		t.Errorf("After burning there are %d Wood and %d Fire, want some Wood burnt and the Fire out", sandWorld.Count(Wood), sandWorld.Count(Fire))
+	}
+}
+
+func TestSandWorld_SmokeRises(t *testing.T) {
+
+	sandWorld := NewSandWorld(SandWorldSettings{Dimensions: Dimensions{Width: 1, Height: 5}, Seeded: Seeded{Seed: 1}})
+	sandWorld.Set(0, 0, Smoke)
+
+	sandWorld.Update()
+
+	if tile, _ := sandWorld.Tile(0, 1); tile.Material != Smoke {
+		t.Errorf("Tile (0, 1) = %v, want the Smoke to have risen", tile.Material)
+	}
+}
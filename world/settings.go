@@ -1,11 +1,19 @@
 package world
 
 import (
+	"gopherlife/metrics"
 	"gopherlife/timer"
+	"math/rand"
+	"time"
 )
 
 const FrameSpeedMultiplier = 7
 
+//TickRater a world that declares how long there should be between each of its Updates
+type TickRater interface {
+	TickRate() time.Duration
+}
+
 type Dimensions struct {
 	Width  int
 	Height int
@@ -17,6 +25,35 @@ type Population struct {
 	MaxPopulation     int
 }
 
+//Partition the size of the partitions a World is split into so it can be searched quickly. Sizes less than one use the
+//DefaultPartitionSize. Adaptive partitions are split and merged as the number of entities in the World changes
+type Partition struct {
+	PartitionWidth     int
+	PartitionHeight    int
+	AdaptivePartitions bool
+}
+
+//Seeded the seed of the random numbers a World uses, so the same settings build the same World again. Zero picks a
+//random seed when the World is built
+type Seeded struct {
+	Seed int64
+}
+
+//random Picks a random seed if there is not one yet, then Returns random numbers from the seed
+func (seeded *Seeded) random() *rand.Rand {
+
+	if seeded.Seed == 0 {
+		seeded.Seed = rand.Int63()
+	}
+
+	return rand.New(rand.NewSource(seeded.Seed))
+}
+
+//worldMetrics Returns the metrics of the world with the name, kept apart from those of every other world
+func worldMetrics(world string) metrics.Scope {
+	return metrics.Default.Scope("world", world)
+}
+
 //Diagnostics is used primarily by the 'GopherWorld' struct and is used to track
 //how long different parts of the 'Update' method take
 type Diagnostics struct {
@@ -24,4 +61,30 @@ type Diagnostics struct {
 	InputStopWatch   timer.StopWatch
 	GopherStopWatch  timer.StopWatch
 	ProcessStopWatch timer.StopWatch
+	//TickStopWatch the time from the start of one 'Update' to the start of the next
+	TickStopWatch timer.StopWatch
+
+	GophersAlive *metrics.Gauge
+}
+
+//NewDiagnostics Returns Diagnostics whose stopwatches also record every duration into the Default metrics of the
+//world with the name, so that percentiles of each part of the 'Update' method are kept and served on the metrics
+//endpoint
+func NewDiagnostics(world string) Diagnostics {
+
+	var d Diagnostics
+
+	scope := worldMetrics(world)
+
+	d.InputStopWatch.Histogram = scope.DurationHistogram("gopherworld_input_seconds",
+		"Time spent processing queued actions each update")
+	d.GopherStopWatch.Histogram = scope.DurationHistogram("gopherworld_gophers_seconds",
+		"Time spent acting for every gopher each update")
+	d.ProcessStopWatch.Histogram = scope.DurationHistogram("gopherworld_update_seconds",
+		"Time spent on each update")
+	d.TickStopWatch.Histogram = scope.DurationHistogram("gopherworld_tick_seconds",
+		"Time from the start of each update to the start of the next")
+	d.GophersAlive = scope.Gauge("gopherworld_gophers_alive", "Number of gophers alive after the last update")
+
+	return d
 }
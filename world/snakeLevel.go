@@ -0,0 +1,104 @@
+package world
+
+import (
+	"bufio"
+	"fmt"
+	"gopherlife/geometry"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//Characters used in the Snake level text format
+const (
+	snakeLevelWall    = '#'
+	snakeLevelStart   = 'S'
+	snakeLevelEmpty   = '.'
+	snakeLevelSpace   = ' '
+	snakeLevelComment = ";"
+)
+
+//SnakeLevel A layout of walls and a starting position for the Snake
+type SnakeLevel struct {
+	Name string
+	Dimensions
+	Walls    []geometry.Coordinates
+	Start    geometry.Coordinates
+	HasStart bool
+}
+
+//LoadSnakeLevel Reads a SnakeLevel from the given file. The level is named after the file
+func LoadSnakeLevel(path string) (SnakeLevel, error) {
+
+	file, err := os.Open(path)
+
+	if err != nil {
+		return SnakeLevel{}, err
+	}
+
+	defer file.Close()
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	return ParseSnakeLevel(name, file)
+}
+
+//ParseSnakeLevel Reads a SnakeLevel from a simple text map.
+//Each line is a row of tiles, the first line being the top of the world.
+//'#' is a wall, 'S' is where the Snake's head starts and '.' or ' ' is empty.
+//Lines starting with ';' are comments. Rows shorter than the widest row are padded with empty tiles
+func ParseSnakeLevel(name string, r io.Reader) (SnakeLevel, error) {
+
+	level := SnakeLevel{Name: name}
+
+	rows := []string{}
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if strings.HasPrefix(line, snakeLevelComment) {
+			continue
+		}
+
+		rows = append(rows, line)
+
+		if len(line) > level.Width {
+			level.Width = len(line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return SnakeLevel{}, err
+	}
+
+	level.Height = len(rows)
+
+	if level.Width == 0 || level.Height == 0 {
+		return SnakeLevel{}, fmt.Errorf("snake level %q is empty", name)
+	}
+
+	for i, row := range rows {
+
+		y := level.Height - 1 - i
+
+		for x, char := range row {
+			switch char {
+			case snakeLevelWall:
+				level.Walls = append(level.Walls, geometry.NewCoordinate(x, y))
+			case snakeLevelStart:
+				if level.HasStart {
+					return SnakeLevel{}, fmt.Errorf("snake level %q has more than one start", name)
+				}
+				level.Start = geometry.NewCoordinate(x, y)
+				level.HasStart = true
+			case snakeLevelEmpty, snakeLevelSpace:
+			default:
+				return SnakeLevel{}, fmt.Errorf("snake level %q has unknown tile %q at line %d", name, char, i+1)
+			}
+		}
+	}
+
+	return level, nil
+}
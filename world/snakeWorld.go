@@ -2,14 +2,21 @@ package world
 
 import (
 	"gopherlife/geometry"
-	"gopherlife/timer"
 	"math/rand"
 	"time"
 )
 
 type SnakeWorldSettings struct {
 	Dimensions
+	Seeded
 	SpeedReduction int
+
+	//Topology removes the border walls from the joined edges, the snake leaves one joined edge and appears on the opposite edge
+	Topology geometry.Topology
+	//PowerUps allows power-up food to be placed alongside normal food
+	PowerUps bool
+	//Level optional layout of walls and starting position, the level's dimensions replace Dimensions
+	Level *SnakeLevel
 }
 
 type SnakeWorld struct {
@@ -25,7 +32,15 @@ type SnakeWorld struct {
 	IsGameOver bool
 	Score      int
 
-	FrameTimer timer.StopWatch
+	//ActivePowerUps the number of ticks remaining for each active power-up
+	ActivePowerUps map[SnakeFoodType]int
+	//powerUpOnMap the power-up food waiting to be eaten, if there is one, and the Tick it disappears on
+	powerUpOnMap     *SnakeFood
+	powerUpExpiresOn int
+
+	//Tick the number of times the snake has moved
+	Tick   int
+	random *rand.Rand
 }
 
 type SnakeWorldTile struct {
@@ -33,6 +48,9 @@ type SnakeWorldTile struct {
 	SnakePart *SnakePart
 	SnakeWall *SnakeWall
 	SnakeFood *SnakeFood
+
+	//sharedSnakeParts the SnakeParts underneath the SnakePart, that the snake passed over while it was a ghost
+	sharedSnakeParts []*SnakePart
 }
 
 func NewSnakeTileGrid(x int, y int, width int, height int) [][]*SnakeWorldTile {
@@ -54,48 +72,78 @@ func NewSnakeTileGrid(x int, y int, width int, height int) [][]*SnakeWorldTile {
 
 //NewEmptySnakeWorld Creates an Empty Snake Map (No Snake, No Wall)
 func NewEmptySnakeWorld(settings SnakeWorldSettings) SnakeWorld {
-	r := geometry.NewRectangle(0, 0, settings.Width, settings.Height)
-	baq := NewFiniteActionQueue(1)
+
+	if settings.Level != nil {
+		settings.Dimensions = settings.Level.Dimensions
+	}
+
+	random := settings.random()
+
+	surface := geometry.NewSurface(0, 0, settings.Width, settings.Height, settings.Topology)
+	baq := NewFiniteActionQueue(1, "snake")
 
 	SnakeWorld := SnakeWorld{
 		grid:               NewSnakeTileGrid(0, 0, settings.Width, settings.Height),
-		Container:          &r,
+		Container:          &surface,
 		ActionQueuer:       &baq,
 		SnakeWorldSettings: settings,
 		IsGameOver:         false,
+		ActivePowerUps:     make(map[SnakeFoodType]int),
+		random:             random,
 	}
 
 	return SnakeWorld
 }
 
-//NewSnakeWorld Creates a SnakeWorld with a Snake and Walls surrounding the edges
+//NewSnakeWorld Creates a SnakeWorld with a Snake and Walls surrounding the edges. If the settings contain a Level
+//the Level's walls are added and the Snake starts at the Level's starting position. Edges joined by the Topology have no border
 func NewSnakeWorld(settings SnakeWorldSettings) SnakeWorld {
 
 	SnakeWorld := NewEmptySnakeWorld(settings)
 
+	startX, startY := SnakeWorld.Width/2, SnakeWorld.Height/2-2
+
+	if level := SnakeWorld.Level; level != nil {
+		for _, wall := range level.Walls {
+			SnakeWorld.InsertSnakeWall(wall.GetX(), wall.GetY(), &SnakeWall{})
+		}
+
+		if level.HasStart {
+			startX, startY = level.Start.GetX(), level.Start.GetY()
+		}
+	}
+
 	snakeHead := SnakePart{}
-	startX, startY := settings.Width/2, settings.Height/2-2
 	SnakeWorld.InsertSnakePart(startX, startY, &snakeHead)
 
 	snakePartToAttachTo := &snakeHead
 
 	for i := 0; i < 5; i++ {
 		snakePartInStomach := SnakePart{}
-		x, y := snakePartToAttachTo.GetX(), snakePartToAttachTo.GetY()-1
-		SnakeWorld.InsertSnakePart(x, y, &snakePartInStomach)
+		x, y := SnakeWorld.wrap(snakePartToAttachTo.GetX(), snakePartToAttachTo.GetY()-1)
+
+		//The tail stops short if it runs into a wall or off the world
+		if !SnakeWorld.InsertSnakePart(x, y, &snakePartInStomach) {
+			break
+		}
+
 		snakePartToAttachTo.AttachToBack(&snakePartInStomach)
 
 		snakePartToAttachTo = &snakePartInStomach
 	}
 
-	for i := 0; i < settings.Width; i++ {
-		SnakeWorld.InsertSnakeWall(i, 0, &SnakeWall{})
-		SnakeWorld.InsertSnakeWall(i, settings.Height-1, &SnakeWall{})
+	if !SnakeWorld.Topology.WrapsY() {
+		for i := 0; i < SnakeWorld.Width; i++ {
+			SnakeWorld.InsertSnakeWall(i, 0, &SnakeWall{})
+			SnakeWorld.InsertSnakeWall(i, SnakeWorld.Height-1, &SnakeWall{})
+		}
 	}
 
-	for i := 0; i < settings.Height; i++ {
-		SnakeWorld.InsertSnakeWall(0, i, &SnakeWall{})
-		SnakeWorld.InsertSnakeWall(settings.Width-1, i, &SnakeWall{})
+	if !SnakeWorld.Topology.WrapsX() {
+		for i := 0; i < SnakeWorld.Height; i++ {
+			SnakeWorld.InsertSnakeWall(0, i, &SnakeWall{})
+			SnakeWorld.InsertSnakeWall(SnakeWorld.Width-1, i, &SnakeWall{})
+		}
 	}
 
 	SnakeWorld.AddNewSnakeFoodToMap()
@@ -108,53 +156,146 @@ func NewSnakeWorld(settings SnakeWorldSettings) SnakeWorld {
 
 func (sw *SnakeWorld) Update() bool {
 
-	sw.FrameTimer.Start()
-
 	sw.Process()
 
 	if sw.IsGameOver {
 		return false
 	}
 
+	sw.tickPowerUps()
+	sw.expirePowerUpFood()
+
 	if !sw.MoveSnake() {
 		sw.IsGameOver = true
 	}
 
-	for sw.FrameTimer.GetCurrentElaspedTime() < time.Millisecond*FrameSpeedMultiplier*time.Duration(sw.SpeedReduction) {
-	}
+	sw.Tick++
 
 	return true
 }
 
+//TickRate Returns the time between each Update, the FrameDuration
+func (sw *SnakeWorld) TickRate() time.Duration {
+	return sw.FrameDuration()
+}
+
+//FrameDuration Returns how long a single frame should last, taking into account any active speed power-ups
+func (sw *SnakeWorld) FrameDuration() time.Duration {
+
+	duration := time.Millisecond * FrameSpeedMultiplier * time.Duration(sw.SpeedReduction)
+
+	if sw.HasPowerUp(SpeedBoostFood) {
+		duration /= 2
+	}
+
+	if sw.HasPowerUp(SlowDownFood) {
+		duration *= 2
+	}
+
+	return duration
+}
+
+//HasPowerUp Returns true if the given power-up is currently active
+func (sw *SnakeWorld) HasPowerUp(foodType SnakeFoodType) bool {
+	return sw.ActivePowerUps[foodType] > 0
+}
+
+func (sw *SnakeWorld) tickPowerUps() {
+	for foodType, ticks := range sw.ActivePowerUps {
+		if ticks <= 1 {
+			delete(sw.ActivePowerUps, foodType)
+		} else {
+			sw.ActivePowerUps[foodType] = ticks - 1
+		}
+	}
+}
+
+//expirePowerUpFood Removes the power-up food from the map once it has been left uneaten for its lifetime
+func (sw *SnakeWorld) expirePowerUpFood() {
+	if sw.powerUpOnMap != nil && sw.Tick >= sw.powerUpExpiresOn {
+		sw.RemoveSnakeFood(sw.powerUpOnMap.GetX(), sw.powerUpOnMap.GetY())
+	}
+}
+
+//ApplyPowerUp Activates the power-up of the given food type. Speed Boost and Slow Down cancel each other out
+//and Shrink takes effect instantly
+func (sw *SnakeWorld) ApplyPowerUp(foodType SnakeFoodType) {
+
+	switch foodType {
+	case SpeedBoostFood:
+		delete(sw.ActivePowerUps, SlowDownFood)
+	case SlowDownFood:
+		delete(sw.ActivePowerUps, SpeedBoostFood)
+	case ShrinkFood:
+		sw.ShrinkSnake(snakeShrinkAmount)
+	}
+
+	if duration := foodType.Duration(); duration > 0 {
+		sw.ActivePowerUps[foodType] = duration
+	}
+}
+
+//ShrinkSnake Removes up to amount SnakeParts from the tail of the Snake. The Snake is never shorter than two SnakeParts.
+//Growth still in the stomach of a removed SnakePart is passed on to the new tail
+func (sw *SnakeWorld) ShrinkSnake(amount int) {
+
+	tail := sw.SnakeHead
+	length := 1
+
+	for tail.snakePartBehind != nil {
+		tail = tail.snakePartBehind
+		length++
+	}
+
+	for i := 0; i < amount && length > 2; i++ {
+		sw.removeGivenSnakePart(tail.GetX(), tail.GetY(), tail)
+		front := tail.snakePartInFront
+		front.snakePartBehind = nil
+		if tail.HasPartInStomach() {
+			front.swallow(tail.snakePartInStomach)
+		}
+		tail = front
+		length--
+	}
+}
+
+//Surface Returns how the edges of the world are joined together
+func (sw *SnakeWorld) Surface() *geometry.Surface {
+	return surfaceOf(sw.Container)
+}
+
+//wrap Converts x and y so they lie within the world across any edges joined by the Topology
+func (sw *SnakeWorld) wrap(x int, y int) (int, int) {
+	return wrap(sw.Container, x, y)
+}
+
 func (sw *SnakeWorld) MoveSnake() bool {
 
 	currentSnakePart := sw.SnakeHead
 	currentSnakePart.PassOnFood()
 
-	nextX, nextY := sw.Direction.AddToPoint(currentSnakePart.GetX(), currentSnakePart.GetY())
-
-	hasFood := sw.HasSnakeFood(nextX, nextY)
+	nextX, nextY := sw.wrap(sw.Direction.AddToPoint(currentSnakePart.GetX(), currentSnakePart.GetY()))
 
-	//newPartPassedDownThisFrame := false
+	food, hasFood := sw.SnakeFood(nextX, nextY)
 
 	for {
 
 		prevX, prevY := currentSnakePart.GetX(), currentSnakePart.GetY()
-		sw.RemoveSnakePart(prevX, prevY)
+		sw.removeGivenSnakePart(prevX, prevY, currentSnakePart)
 
-		inserted := sw.InsertSnakePart(nextX, nextY, currentSnakePart)
+		inserted := sw.moveSnakePartTo(nextX, nextY, currentSnakePart)
 
 		if !inserted {
-			sw.InsertSnakePart(prevX, prevY, currentSnakePart)
+			if tile, ok := sw.Tile(prevX, prevY); ok {
+				tile.shareSnakePart(currentSnakePart)
+			}
 			return false
 		}
 
 		if hasFood && currentSnakePart.snakePartInFront == nil {
 			if sw.RemoveSnakeFood(nextX, nextY) {
-				currentSnakePart.snakePartInStomach = &SnakePart{}
 				hasFood = false
-				sw.AddNewSnakeFoodToMap()
-				sw.Score += 10
+				sw.eat(food, currentSnakePart)
 			}
 		}
 		nextX, nextY = prevX, prevY
@@ -170,6 +311,39 @@ func (sw *SnakeWorld) MoveSnake() bool {
 
 }
 
+//eat Handles the SnakeHead eating food. Normal food grows the snake, power-up food applies its effect
+func (sw *SnakeWorld) eat(food *SnakeFood, head *SnakePart) {
+
+	if food.Type != NormalFood {
+		sw.ApplyPowerUp(food.Type)
+		sw.Score += 5
+		return
+	}
+
+	head.snakePartInStomach = &SnakePart{}
+	sw.AddNewSnakeFoodToMap()
+	sw.Score += 10
+
+	if sw.SnakeWorldSettings.PowerUps && sw.powerUpOnMap == nil && sw.random.Intn(3) == 0 {
+		sw.AddNewPowerUpToMap(powerUpFoodTypes[sw.random.Intn(len(powerUpFoodTypes))])
+	}
+}
+
+//moveSnakePartTo Inserts the SnakePart at x and y. The head is blocked by other SnakeParts unless Ghost mode is active,
+//when it shares their tile. The rest of the snake follows the head into tiles it may be sharing, so always shares them
+func (sw *SnakeWorld) moveSnakePartTo(x int, y int, sp *SnakePart) bool {
+
+	if sp.snakePartInFront == nil && !sw.HasPowerUp(GhostFood) {
+		return sw.InsertSnakePart(x, y, sp)
+	}
+
+	if tile, ok := sw.Tile(x, y); ok {
+		return tile.shareSnakePart(sp)
+	}
+
+	return false
+}
+
 func (smt *SnakeWorld) ChangeDirection(d geometry.Direction) {
 
 	setDirection := func(d geometry.Direction) {
@@ -195,14 +369,24 @@ func (smt *SnakeWorld) ChangeDirection(d geometry.Direction) {
 
 }
 
+//AddNewSnakeFoodToMap Places normal food in a random empty tile
 func (sw *SnakeWorld) AddNewSnakeFoodToMap() bool {
+	return sw.addSnakeFoodToMap(&SnakeFood{Type: NormalFood})
+}
 
-	xrange, yrange := rand.Perm(sw.Width), rand.Perm(sw.Height)
+//AddNewPowerUpToMap Places power-up food of the given type in a random empty tile
+func (sw *SnakeWorld) AddNewPowerUpToMap(foodType SnakeFoodType) bool {
+	return sw.addSnakeFoodToMap(&SnakeFood{Type: foodType})
+}
+
+func (sw *SnakeWorld) addSnakeFoodToMap(sf *SnakeFood) bool {
+
+	xrange, yrange := sw.random.Perm(sw.Width), sw.random.Perm(sw.Height)
 
 	for i := 0; i < sw.Width; i++ {
 		for j := 0; j < sw.Height; j++ {
 			newX, newY := xrange[i], yrange[j]
-			if sw.InsertSnakeFood(newX, newY, &SnakeFood{}) {
+			if sw.InsertSnakeFood(newX, newY, sf) {
 				return true
 			}
 		}
@@ -212,6 +396,7 @@ func (sw *SnakeWorld) AddNewSnakeFoodToMap() bool {
 }
 
 func (smt *SnakeWorld) Tile(x int, y int) (*SnakeWorldTile, bool) {
+	x, y = smt.wrap(x, y)
 	if smt.Contains(x, y) {
 		return smt.grid[x][y], true
 	}
@@ -227,7 +412,13 @@ func (smt *SnakeWorld) InsertSnakePart(x int, y int, sp *SnakePart) bool {
 
 func (smt *SnakeWorld) InsertSnakeFood(x int, y int, sf *SnakeFood) bool {
 	if tile, ok := smt.Tile(x, y); ok {
-		return tile.InsertSnakeFood(sf)
+		if tile.InsertSnakeFood(sf) {
+			if sf.Type != NormalFood {
+				smt.powerUpOnMap = sf
+				smt.powerUpExpiresOn = smt.Tick + snakePowerUpFoodLifetime
+			}
+			return true
+		}
 	}
 	return false
 }
@@ -247,8 +438,17 @@ func (smt *SnakeWorld) RemoveSnakePart(x int, y int) bool {
 	return false
 }
 
+func (smt *SnakeWorld) removeGivenSnakePart(x int, y int, sp *SnakePart) {
+	if tile, ok := smt.Tile(x, y); ok {
+		tile.removeGivenSnakePart(sp)
+	}
+}
+
 func (smt *SnakeWorld) RemoveSnakeFood(x int, y int) bool {
 	if tile, ok := smt.Tile(x, y); ok {
+		if tile.SnakeFood != nil && tile.SnakeFood.Type != NormalFood {
+			smt.powerUpOnMap = nil
+		}
 		tile.RemoveSnakeFood()
 		return true
 	}
@@ -256,10 +456,16 @@ func (smt *SnakeWorld) RemoveSnakeFood(x int, y int) bool {
 }
 
 func (smt *SnakeWorld) HasSnakeFood(x int, y int) bool {
-	if tile, ok := smt.Tile(x, y); ok {
-		return tile.SnakeFood != nil
+	_, ok := smt.SnakeFood(x, y)
+	return ok
+}
+
+//SnakeFood Returns the SnakeFood at x and y if there is any
+func (smt *SnakeWorld) SnakeFood(x int, y int) (*SnakeFood, bool) {
+	if tile, ok := smt.Tile(x, y); ok && tile.SnakeFood != nil {
+		return tile.SnakeFood, true
 	}
-	return false
+	return nil, false
 }
 
 func (smt *SnakeWorldTile) InsertWall(w *SnakeWall) bool {
@@ -290,8 +496,62 @@ func (smt *SnakeWorldTile) InsertSnakePart(sp *SnakePart) bool {
 	return false
 }
 
+//shareSnakePart Puts the SnakePart on the tile even if there is already a SnakePart on it, which stays on the tile
+//underneath. Returns false if there is a wall on the tile
+func (smt *SnakeWorldTile) shareSnakePart(sp *SnakePart) bool {
+
+	if smt.SnakeWall != nil {
+		return false
+	}
+
+	if smt.SnakePart != nil {
+		smt.sharedSnakeParts = append(smt.sharedSnakeParts, smt.SnakePart)
+	}
+
+	sp.SetXY(smt.GetX(), smt.GetY())
+	smt.SnakePart = sp
+	return true
+}
+
+//hasSnakePart Returns true if the SnakePart is on the tile, on top or underneath another SnakePart
+func (smt *SnakeWorldTile) hasSnakePart(sp *SnakePart) bool {
+
+	if smt.SnakePart == sp {
+		return true
+	}
+
+	for _, shared := range smt.sharedSnakeParts {
+		if shared == sp {
+			return true
+		}
+	}
+
+	return false
+}
+
+//removeGivenSnakePart Takes the SnakePart off the tile, the SnakePart it was sharing the tile with is left on it
+func (smt *SnakeWorldTile) removeGivenSnakePart(sp *SnakePart) {
+
+	if smt.SnakePart == sp {
+		smt.SnakePart = nil
+		if last := len(smt.sharedSnakeParts) - 1; last >= 0 {
+			smt.SnakePart = smt.sharedSnakeParts[last]
+			smt.sharedSnakeParts = smt.sharedSnakeParts[:last]
+		}
+		return
+	}
+
+	for i, shared := range smt.sharedSnakeParts {
+		if shared == sp {
+			smt.sharedSnakeParts = append(smt.sharedSnakeParts[:i], smt.sharedSnakeParts[i+1:]...)
+			return
+		}
+	}
+}
+
 func (smt *SnakeWorldTile) RemoveSnakePart() {
 	smt.SnakePart = nil
+	smt.sharedSnakeParts = nil
 }
 
 func (smt *SnakeWorldTile) RemoveSnakeFood() {
@@ -330,6 +590,16 @@ func (sp *SnakePart) PassOnFood() {
 	}
 }
 
+//swallow Adds the SnakePart to the end of the food in the stomach, each SnakePart in the stomach can hold the next
+func (sp *SnakePart) swallow(food *SnakePart) {
+
+	for sp.HasPartInStomach() {
+		sp = sp.snakePartInStomach
+	}
+
+	sp.snakePartInStomach = food
+}
+
 //HasPartInStomach Return true is there is a SnakePart inside the Stomach
 func (sp *SnakePart) HasPartInStomach() bool {
 	return sp.snakePartInStomach != nil
@@ -343,4 +613,53 @@ type SnakeWall struct {
 //SnakeFood Used in the Snake Game has an X and Y Coordinate
 type SnakeFood struct {
 	geometry.Coordinates
+	Type SnakeFoodType
+}
+
+//SnakeFoodType Normal food grows the Snake, every other type is a power-up
+type SnakeFoodType int
+
+//Snake food types
+const (
+	NormalFood SnakeFoodType = iota
+	SpeedBoostFood
+	SlowDownFood
+	ShrinkFood
+	GhostFood
+)
+
+const snakeShrinkAmount = 3
+
+//snakePowerUpFoodLifetime the number of ticks power-up food stays on the map before it disappears uneaten
+const snakePowerUpFoodLifetime = 100
+
+var powerUpFoodTypes = []SnakeFoodType{SpeedBoostFood, SlowDownFood, ShrinkFood, GhostFood}
+
+//Duration Returns the number of ticks a power-up lasts for. Instant power-ups return 0
+func (foodType SnakeFoodType) Duration() int {
+	switch foodType {
+	case SpeedBoostFood:
+		return 60
+	case SlowDownFood:
+		return 60
+	case GhostFood:
+		return 40
+	default:
+		return 0
+	}
+}
+
+func (foodType SnakeFoodType) String() string {
+	switch foodType {
+	case SpeedBoostFood:
+		return "Speed Boost"
+	case SlowDownFood:
+		return "Slow Down"
+	case ShrinkFood:
+		return "Shrink"
+	case GhostFood:
+		return "Ghost"
+	default:
+		return "Food"
+	}
 }
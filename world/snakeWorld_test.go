@@ -2,6 +2,7 @@ package world
 
 import (
 	"gopherlife/geometry"
+	"strings"
 	"testing"
 )
 
@@ -50,7 +51,7 @@ func TestSnakeWorld_ChangeDirection(t *testing.T) {
 
 func TestSnakeWorld_InsertSnakePart(t *testing.T) {
 
-	sw := NewEmptySnakeWorld(SnakeWorldSettings{Dimensions{10, 10}, 5})
+	sw := NewEmptySnakeWorld(SnakeWorldSettings{Dimensions: Dimensions{10, 10}, SpeedReduction: 5})
 
 	snakeFoodX, snakeFoodY := 5, 5
 	snakeWallX, snakeWallY := 6, 6
@@ -93,3 +94,223 @@ func TestSnakeWorld_InsertSnakePart(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSnakeLevel(t *testing.T) {
+
+	levelText := "; comment\n" +
+		"#####\n" +
+		"#.S.#\n" +
+		"#   #\n" +
+		"#####\n"
+
+	level, err := ParseSnakeLevel("test", strings.NewReader(levelText))
+
+	if err != nil {
+		t.Fatalf("ParseSnakeLevel() error = %v", err)
+	}
+
+	if level.Width != 5 || level.Height != 4 {
+		t.Errorf("ParseSnakeLevel() Dimensions = (%d, %d), want (5, 4)", level.Width, level.Height)
+	}
+
+	if !level.HasStart || level.Start != geometry.NewCoordinate(2, 2) {
+		t.Errorf("ParseSnakeLevel() Start = %v, want (2, 2)", level.Start)
+	}
+
+	if len(level.Walls) != 14 {
+		t.Errorf("ParseSnakeLevel() Walls = %d, want 14", len(level.Walls))
+	}
+
+	if _, err := ParseSnakeLevel("bad", strings.NewReader("#x#\n")); err == nil {
+		t.Errorf("ParseSnakeLevel() expected error for unknown tile")
+	}
+}
+
+func TestSnakeWorld_MoveSnakeTorus(t *testing.T) {
+
+	sw := NewSnakeWorld(SnakeWorldSettings{Dimensions: Dimensions{10, 10}, Topology: geometry.Torus})
+
+	for i := 0; i < sw.Height; i++ {
+		if !sw.MoveSnake() {
+			t.Fatalf("SnakeWorld.MoveSnake() failed on move %d", i)
+		}
+	}
+
+	if sw.SnakeHead.GetY() != sw.Height/2-2 {
+		t.Errorf("SnakeHead Y = %d, want %d", sw.SnakeHead.GetY(), sw.Height/2-2)
+	}
+}
+
+func TestSnakeWorld_MoveSnakeHorizontalCylinder(t *testing.T) {
+
+	sw := NewSnakeWorld(SnakeWorldSettings{Dimensions: Dimensions{10, 10}, Topology: geometry.HorizontalCylinder})
+
+	if tile, _ := sw.Tile(0, 5); tile.SnakeWall != nil {
+		t.Errorf("SnakeWall on the joined left edge, want none")
+	}
+
+	if tile, _ := sw.Tile(2, 0); tile.SnakeWall == nil {
+		t.Errorf("No SnakeWall on the top edge, want a wall")
+	}
+
+	moved := 0
+	for moved < sw.Height && sw.MoveSnake() {
+		moved++
+	}
+
+	if moved >= sw.Height {
+		t.Errorf("SnakeWorld.MoveSnake() moved %d times, want the snake to hit the top wall", moved)
+	}
+}
+
+//checkSnakeOnTiles Fails the test if any part of the snake is not on the tile at its position
+func checkSnakeOnTiles(t *testing.T, sw *SnakeWorld) int {
+
+	length := 0
+
+	for sp := sw.SnakeHead; sp != nil; sp = sp.snakePartBehind {
+		if tile, ok := sw.Tile(sp.GetX(), sp.GetY()); !ok || !tile.hasSnakePart(sp) {
+			t.Errorf("SnakePart %d at %v is not on its tile", length, sp.Coordinates)
+		}
+		length++
+	}
+
+	return length
+}
+
+func TestSnakeWorld_GhostSharesTiles(t *testing.T) {
+
+	sw := NewSnakeWorld(SnakeWorldSettings{Dimensions: Dimensions{10, 10}, Topology: geometry.Torus})
+	sw.ApplyPowerUp(GhostFood)
+
+	//Turning back on itself takes the head over the body, and the part after the one it passed over onto its tile
+	for _, direction := range []geometry.Direction{geometry.Right, geometry.Down, geometry.Left, geometry.Left} {
+
+		sw.Direction = direction
+
+		if !sw.MoveSnake() {
+			t.Fatalf("SnakeWorld.MoveSnake() %v as a ghost failed", direction)
+		}
+
+		checkSnakeOnTiles(t, &sw)
+	}
+}
+
+func TestNewSnakeWorld_TailStopsAtEdge(t *testing.T) {
+
+	sw := NewSnakeWorld(SnakeWorldSettings{Dimensions: Dimensions{10, 6}})
+
+	if length := checkSnakeOnTiles(t, &sw); length != 2 {
+		t.Errorf("Snake length = %d, want the tail to stop at the bottom edge after 2 parts", length)
+	}
+}
+
+func TestSnakeWorld_ApplyPowerUp(t *testing.T) {
+
+	sw := NewSnakeWorld(SnakeWorldSettings{Dimensions: Dimensions{20, 20}, SpeedReduction: 4})
+
+	sw.ApplyPowerUp(ShrinkFood)
+
+	length := 0
+	for sp := sw.SnakeHead; sp != nil; sp = sp.snakePartBehind {
+		length++
+	}
+
+	if length != 3 {
+		t.Errorf("Snake length after Shrink = %d, want 3", length)
+	}
+
+	normal := sw.FrameDuration()
+
+	sw.ApplyPowerUp(SpeedBoostFood)
+	if sw.FrameDuration() != normal/2 {
+		t.Errorf("FrameDuration() with Speed Boost = %v, want %v", sw.FrameDuration(), normal/2)
+	}
+
+	sw.ApplyPowerUp(SlowDownFood)
+	if sw.HasPowerUp(SpeedBoostFood) || sw.FrameDuration() != normal*2 {
+		t.Errorf("FrameDuration() with Slow Down = %v, want %v", sw.FrameDuration(), normal*2)
+	}
+
+	for i := 0; i < SlowDownFood.Duration(); i++ {
+		sw.tickPowerUps()
+	}
+
+	if sw.HasPowerUp(SlowDownFood) {
+		t.Errorf("Slow Down still active after %d ticks", SlowDownFood.Duration())
+	}
+}
+
+func TestSnakeWorld_ShrinkKeepsGrowth(t *testing.T) {
+
+	sw := NewSnakeWorld(SnakeWorldSettings{Dimensions: Dimensions{20, 20}, Topology: geometry.Torus})
+
+	tail := sw.SnakeHead
+	for tail.snakePartBehind != nil {
+		tail = tail.snakePartBehind
+	}
+
+	tail.snakePartInStomach = &SnakePart{}
+	sw.ApplyPowerUp(ShrinkFood)
+
+	for i := 0; i < 2; i++ {
+		if !sw.MoveSnake() {
+			t.Fatalf("SnakeWorld.MoveSnake() failed on move %d", i)
+		}
+	}
+
+	if length := checkSnakeOnTiles(t, &sw); length != 4 {
+		t.Errorf("Snake length after Shrink and moving = %d, want 4 with the food that was in the old tail", length)
+	}
+}
+
+func TestSnakeWorld_PowerUpFoodExpires(t *testing.T) {
+
+	sw := NewSnakeWorld(SnakeWorldSettings{Dimensions: Dimensions{20, 20}, Topology: geometry.Torus, PowerUps: true})
+
+	//Beside the column the snake moves up so it is never eaten
+	x, y := sw.SnakeHead.GetX()+5, sw.SnakeHead.GetY()
+	food := SnakeFood{Type: GhostFood}
+
+	if !sw.InsertSnakeFood(x, y, &food) {
+		t.Fatalf("SnakeWorld.InsertSnakeFood() = false, want true")
+	}
+
+	for i := 0; i < snakePowerUpFoodLifetime; i++ {
+		if tile, _ := sw.Tile(x, y); tile.SnakeFood != &food {
+			t.Fatalf("Power-up food disappeared after %d ticks, want it to last %d", i, snakePowerUpFoodLifetime)
+		}
+		sw.Update()
+	}
+
+	sw.Update()
+
+	if tile, _ := sw.Tile(x, y); tile.SnakeFood == &food || sw.powerUpOnMap != nil {
+		t.Errorf("Power-up food is still on the map after %d ticks", snakePowerUpFoodLifetime)
+	}
+}
+
+func TestNewSnakeWorld_Seed(t *testing.T) {
+
+	foodPosition := func(sw *SnakeWorld) geometry.Coordinates {
+		for x := 0; x < sw.Width; x++ {
+			for y := 0; y < sw.Height; y++ {
+				if sw.HasSnakeFood(x, y) {
+					return geometry.NewCoordinate(x, y)
+				}
+			}
+		}
+		return geometry.NewCoordinate(-1, -1)
+	}
+
+	settings := SnakeWorldSettings{Dimensions: Dimensions{30, 30}, Seeded: Seeded{Seed: 42}}
+	first, second := NewSnakeWorld(settings), NewSnakeWorld(settings)
+
+	if foodPosition(&first) != foodPosition(&second) {
+		t.Errorf("SnakeWorlds with the same Seed placed food at %v and %v", foodPosition(&first), foodPosition(&second))
+	}
+
+	if random := NewSnakeWorld(SnakeWorldSettings{Dimensions: Dimensions{30, 30}}); random.Seed == 0 {
+		t.Errorf("SnakeWorld without a Seed was not given one")
+	}
+}
@@ -0,0 +1,105 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"math"
+)
+
+//SpatialGrid buckets points with float positions into square cells so the points near a position can be found without
+//checking every point. Each point is stored as an index into a slice kept by the caller
+type SpatialGrid struct {
+	cellSize float64
+	columns  int
+	rows     int
+	surface  geometry.Surface
+	cells    [][]int
+}
+
+//NewSpatialGrid Returns an empty SpatialGrid covering the Dimensions with cells of the given size. Searches wrap across
+//the edges joined by the topology
+func NewSpatialGrid(d Dimensions, cellSize float64, topology geometry.Topology) SpatialGrid {
+
+	if cellSize < 1 {
+		cellSize = 1
+	}
+
+	columns := int(math.Ceil(float64(d.Width) / cellSize))
+	rows := int(math.Ceil(float64(d.Height) / cellSize))
+
+	if columns < 1 {
+		columns = 1
+	}
+
+	if rows < 1 {
+		rows = 1
+	}
+
+	return SpatialGrid{
+		cellSize: cellSize,
+		columns:  columns,
+		rows:     rows,
+		surface:  geometry.NewSurface(0, 0, columns, rows, topology),
+		cells:    make([][]int, columns*rows),
+	}
+}
+
+//cell Returns the column and row of the cell holding the position, clamped onto the grid
+func (grid *SpatialGrid) cell(position geometry.Vector) (int, int) {
+
+	column := int(math.Floor(position.X / grid.cellSize))
+	row := int(math.Floor(position.Y / grid.cellSize))
+
+	return clamp(column, 0, grid.columns-1), clamp(row, 0, grid.rows-1)
+}
+
+//Clear Removes every point
+func (grid *SpatialGrid) Clear() {
+	for i := range grid.cells {
+		grid.cells[i] = grid.cells[i][:0]
+	}
+}
+
+//Insert Adds the index of a point at the position
+func (grid *SpatialGrid) Insert(index int, position geometry.Vector) {
+	column, row := grid.cell(position)
+	grid.cells[row*grid.columns+column] = append(grid.cells[row*grid.columns+column], index)
+}
+
+//Near Calls found with the index of every point in the cells within the radius of the position. Some of the points may
+//be a little further away than the radius, callers check the exact distance themselves
+func (grid *SpatialGrid) Near(position geometry.Vector, radius float64, found func(index int)) {
+
+	column, row := grid.cell(position)
+	reach := int(math.Ceil(radius / grid.cellSize))
+
+	fromX, toX := span(column, reach, grid.columns)
+	fromY, toY := span(row, reach, grid.rows)
+
+	for y := fromY; y <= toY; y++ {
+		for x := fromX; x <= toX; x++ {
+			if cx, cy, ok := grid.surface.Wrap(x, y); ok {
+				for _, index := range grid.cells[cy*grid.columns+cx] {
+					found(index)
+				}
+			}
+		}
+	}
+}
+
+//span Returns the first and last cell within reach of the cell. A reach that covers every cell returns them all once,
+//so wrapping round a joined edge does not visit a cell twice
+func span(cell int, reach int, cells int) (int, int) {
+	if 2*reach+1 >= cells {
+		return 0, cells - 1
+	}
+	return cell - reach, cell + reach
+}
+
+func clamp(value int, min int, max int) int {
+	if value < min {
+		return min
+	} else if value > max {
+		return max
+	}
+	return value
+}
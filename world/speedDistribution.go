@@ -0,0 +1,53 @@
+package world
+
+import (
+	"math"
+	"math/rand"
+)
+
+//SpeedDistribution how the starting speeds of Colliders are chosen for a Temperature. Every distribution gives
+//Colliders an average kinetic energy equal to the Temperature
+type SpeedDistribution int
+
+const (
+	//ConstantSpeed every Collider of the same mass has the same speed
+	ConstantSpeed SpeedDistribution = iota
+	//UniformSpeed speeds are spread evenly between 0 and a maximum
+	UniformSpeed
+	//MaxwellBoltzmannSpeed speeds follow the distribution of an ideal gas in equilibrium
+	MaxwellBoltzmannSpeed
+)
+
+func (distribution SpeedDistribution) String() string {
+	switch distribution {
+	case UniformSpeed:
+		return "Uniform"
+	case MaxwellBoltzmannSpeed:
+		return "Maxwell-Boltzmann"
+	default:
+		return "Constant"
+	}
+}
+
+//Speed Returns a random speed, in tiles per tick, for a Collider of the given mass at the given Temperature
+func (distribution SpeedDistribution) Speed(temperature float64, mass float64) float64 {
+
+	if temperature <= 0 || mass <= 0 {
+		return 0
+	}
+
+	//In two dimensions the average kinetic energy, m v^2 / 2, is equal to the temperature
+	meanSquareSpeed := 2 * temperature / mass
+
+	switch distribution {
+	case UniformSpeed:
+		//The mean square of a speed spread evenly up to max is max^2 / 3
+		return rand.Float64() * math.Sqrt(3*meanSquareSpeed)
+	case MaxwellBoltzmannSpeed:
+		//Each component of the velocity is normally distributed with a variance of half the mean square speed
+		deviation := math.Sqrt(meanSquareSpeed / 2)
+		return math.Hypot(rand.NormFloat64()*deviation, rand.NormFloat64()*deviation)
+	default:
+		return math.Sqrt(meanSquareSpeed)
+	}
+}
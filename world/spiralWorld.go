@@ -3,7 +3,6 @@ package world
 import (
 	"gopherlife/geometry"
 	"gopherlife/names"
-	"gopherlife/timer"
 	"sync"
 	"time"
 )
@@ -26,17 +25,16 @@ type SpiralWorld struct {
 	*sync.WaitGroup
 
 	nextSpawnCount int
-
-	FrameTimer timer.StopWatch
 }
 
-func NewSpiralWorld(settings SpiralWorldSettings) SpiralWorld {
+//NewSpiralWorld Returns a SpiralWorld with a single gopher whose metrics are kept under the name
+func NewSpiralWorld(name string, settings SpiralWorldSettings) SpiralWorld {
 
 	spiralWorld := SpiralWorld{}
 
 	b2d := NewBasic2DContainer(0, 0, settings.Width, settings.Height)
 
-	qa := NewFiniteActionQueue(settings.MaxPopulation * 2)
+	qa := NewFiniteActionQueue(settings.MaxPopulation*2, name)
 	spiralWorld.ActionQueuer = &qa
 
 	spiralWorld.TileContainer = &b2d
@@ -56,8 +54,6 @@ func NewSpiralWorld(settings SpiralWorldSettings) SpiralWorld {
 
 func (spiralWorld *SpiralWorld) Update() bool {
 
-	spiralWorld.FrameTimer.Start()
-
 	numGophers := len(spiralWorld.ActiveActors)
 	secondChannel := make(chan *SpiralGopher, numGophers*2)
 	for i := 0; i < numGophers; i++ {
@@ -92,13 +88,15 @@ func (spiralWorld *SpiralWorld) Update() bool {
 	}
 	spiralWorld.Process()
 
-	for spiralWorld.FrameTimer.GetCurrentElaspedTime() < time.Millisecond*FrameSpeedMultiplier*time.Duration(2) {
-	}
-
 	return true
 
 }
 
+//TickRate Returns the time between each Update
+func (spiralWorld *SpiralWorld) TickRate() time.Duration {
+	return time.Millisecond * FrameSpeedMultiplier * 2
+}
+
 func (spiralWorld *SpiralWorld) MoveGopher(gopher *Gopher, moveX int, moveY int) bool {
 
 	currentPosition := geometry.Coordinates{X: gopher.Position.X, Y: gopher.Position.Y}
@@ -0,0 +1,506 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+//TrafficTile what is on a tile of a TrafficWorld
+type TrafficTile int
+
+const (
+	NoRoad TrafficTile = iota
+	Road
+	//Intersection a tile shared by a horizontal lane and a vertical road, cars may only enter it on a green light
+	Intersection
+	RoadBlock
+)
+
+//Car a car driving round one of the ring roads of a TrafficWorld
+type Car struct {
+	//Road the road the car is on, and Position how far along the road it is
+	Road     int
+	Position int
+	//Velocity the number of tiles the car moved on the last tick
+	Velocity int
+}
+
+//TrafficWorldSettings sets up a TrafficWorld
+type TrafficWorldSettings struct {
+	Dimensions
+	Seeded
+	//Lanes the number of lanes of the horizontal ring road through the middle of the world
+	Lanes int
+	//Intersections the number of single lane vertical ring roads crossing the horizontal road, each with a traffic light
+	Intersections int
+	//Density the fraction of road tiles with a car on when the world starts
+	Density float64
+	//MaxVelocity the most tiles a car can move in a tick
+	MaxVelocity int
+	//SlowdownChance the chance each tick that a car slows down for no reason
+	SlowdownChance float64
+	//LightCycle the number of ticks each traffic light stays green for one road before turning green for the other
+	LightCycle int
+}
+
+//TrafficSample the density of cars on the roads and the flow of cars along them on a tick. Flow is the number of cars
+//passing a point of the road each tick, the density times the mean velocity
+type TrafficSample struct {
+	Tick         int
+	Density      float64
+	Flow         float64
+	MeanVelocity float64
+}
+
+//maxTrafficSamples the number of ticks of TrafficSamples a TrafficWorld keeps
+const maxTrafficSamples = 1000
+
+//trafficRoad a ring of tiles cars drive along in order
+type trafficRoad struct {
+	tiles      []int
+	horizontal bool
+}
+
+//TrafficWorld cars driving round ring roads following the Nagel-Schreckenberg model. Each tick every car speeds up by
+//one, slows down to the gap in front of it, slows down by one more at random and then moves. Cars on the horizontal
+//road change lanes when the lane next to them has a bigger gap
+type TrafficWorld struct {
+	TrafficWorldSettings
+	Cars    []*Car
+	Tick    int
+	Samples []TrafficSample
+
+	surface  geometry.Surface
+	tiles    []TrafficTile
+	occupant []*Car
+	//lights the traffic light of each Intersection tile, -1 for every other tile
+	lights    []int
+	roads     []trafficRoad
+	roadTiles int
+	random    *rand.Rand
+}
+
+//NewTrafficWorld Returns a TrafficWorld with its roads laid out and cars placed on them at random
+func NewTrafficWorld(settings TrafficWorldSettings) TrafficWorld {
+
+	if settings.Width < 1 {
+		settings.Width = 1
+	}
+
+	if settings.Lanes < 1 {
+		settings.Lanes = 1
+	}
+
+	if settings.Height < settings.Lanes {
+		settings.Height = settings.Lanes
+	}
+
+	if settings.Intersections > settings.Width {
+		settings.Intersections = settings.Width
+	}
+
+	if settings.MaxVelocity < 1 {
+		settings.MaxVelocity = 1
+	}
+
+	random := settings.random()
+
+	cells := settings.Width * settings.Height
+
+	trafficWorld := TrafficWorld{
+		TrafficWorldSettings: settings,
+		surface:              geometry.NewSurface(0, 0, settings.Width, settings.Height, geometry.Torus),
+		tiles:                make([]TrafficTile, cells),
+		occupant:             make([]*Car, cells),
+		lights:               make([]int, cells),
+		random:               random,
+	}
+
+	for i := range trafficWorld.lights {
+		trafficWorld.lights[i] = -1
+	}
+
+	firstLane := (settings.Height - settings.Lanes) / 2
+
+	for lane := 0; lane < settings.Lanes; lane++ {
+
+		road := trafficRoad{horizontal: true}
+
+		for x := 0; x < settings.Width; x++ {
+			road.tiles = append(road.tiles, (firstLane+lane)*settings.Width+x)
+		}
+
+		trafficWorld.roads = append(trafficWorld.roads, road)
+	}
+
+	for light := 0; light < settings.Intersections; light++ {
+
+		road := trafficRoad{}
+		x := (2*light + 1) * settings.Width / (2 * settings.Intersections)
+
+		for y := 0; y < settings.Height; y++ {
+			road.tiles = append(road.tiles, y*settings.Width+x)
+		}
+
+		trafficWorld.roads = append(trafficWorld.roads, road)
+	}
+
+	for r, road := range trafficWorld.roads {
+		for _, i := range road.tiles {
+			if trafficWorld.tiles[i] == Road {
+				trafficWorld.tiles[i] = Intersection
+				trafficWorld.lights[i] = r - settings.Lanes
+			} else {
+				trafficWorld.tiles[i] = Road
+				trafficWorld.roadTiles++
+			}
+		}
+	}
+
+	trafficWorld.AddCars(int(math.Round(settings.Density * float64(trafficWorld.roadTiles))))
+
+	return trafficWorld
+}
+
+//Surface Returns the shape of the world
+func (trafficWorld *TrafficWorld) Surface() *geometry.Surface {
+	return &trafficWorld.surface
+}
+
+//index Returns where the tile is once it has been wrapped round the edges of the world
+func (trafficWorld *TrafficWorld) index(x int, y int) int {
+	x, y, _ = trafficWorld.surface.Wrap(x, y)
+	return y*trafficWorld.Width + x
+}
+
+//Tile Returns what is on the tile at x and y, and the car on it if there is one
+func (trafficWorld *TrafficWorld) Tile(x int, y int) (TrafficTile, *Car) {
+	i := trafficWorld.index(x, y)
+	return trafficWorld.tiles[i], trafficWorld.occupant[i]
+}
+
+//IsGreen Returns true if the traffic light of the intersection at x and y lets the horizontal road through, false if
+//it lets the vertical road through or there is no intersection there
+func (trafficWorld *TrafficWorld) IsGreen(x int, y int) bool {
+
+	light := trafficWorld.lights[trafficWorld.index(x, y)]
+
+	if light < 0 {
+		return false
+	}
+
+	return trafficWorld.isGreen(light, true)
+}
+
+//isGreen Returns true if the light lets the horizontal or vertical road through. Neighbouring lights are out of step
+//so the whole road does not stop at once
+func (trafficWorld *TrafficWorld) isGreen(light int, horizontal bool) bool {
+
+	if trafficWorld.LightCycle <= 0 {
+		return horizontal
+	}
+
+	return ((trafficWorld.Tick/trafficWorld.LightCycle+light)%2 == 0) == horizontal
+}
+
+//isFree Returns true if a car on the road can drive onto the tile
+func (trafficWorld *TrafficWorld) isFree(i int, road trafficRoad) bool {
+
+	switch trafficWorld.tiles[i] {
+	case RoadBlock, NoRoad:
+		return false
+	case Intersection:
+		if !trafficWorld.isGreen(trafficWorld.lights[i], road.horizontal) {
+			return false
+		}
+	}
+
+	return trafficWorld.occupant[i] == nil
+}
+
+//gapAhead Returns the number of free tiles in front of the position on the road, up to the MaxVelocity
+func (trafficWorld *TrafficWorld) gapAhead(road trafficRoad, position int) int {
+
+	for gap := 0; gap < trafficWorld.MaxVelocity; gap++ {
+		if !trafficWorld.isFree(road.tiles[(position+gap+1)%len(road.tiles)], road) {
+			return gap
+		}
+	}
+
+	return trafficWorld.MaxVelocity
+}
+
+//gapBehind Returns the number of empty tiles behind the position on the road, up to the MaxVelocity
+func (trafficWorld *TrafficWorld) gapBehind(road trafficRoad, position int) int {
+
+	for gap := 0; gap < trafficWorld.MaxVelocity; gap++ {
+		if trafficWorld.occupant[road.tiles[(position-gap-1+2*len(road.tiles))%len(road.tiles)]] != nil {
+			return gap
+		}
+	}
+
+	return trafficWorld.MaxVelocity
+}
+
+//AddCars Places up to the number of cars on random empty road tiles, away from intersections. Returns the number placed
+func (trafficWorld *TrafficWorld) AddCars(number int) int {
+
+	empty := []*Car{}
+
+	for r, road := range trafficWorld.roads {
+		for position, i := range road.tiles {
+			if trafficWorld.tiles[i] == Road && trafficWorld.occupant[i] == nil {
+				empty = append(empty, &Car{Road: r, Position: position})
+			}
+		}
+	}
+
+	trafficWorld.random.Shuffle(len(empty), func(i, j int) { empty[i], empty[j] = empty[j], empty[i] })
+
+	if number > len(empty) {
+		number = len(empty)
+	}
+
+	if number < 0 {
+		number = 0
+	}
+
+	for _, car := range empty[:number] {
+		trafficWorld.occupant[trafficWorld.roads[car.Road].tiles[car.Position]] = car
+		trafficWorld.Cars = append(trafficWorld.Cars, car)
+	}
+
+	return number
+}
+
+//RemoveCars Takes up to the number of cars off the roads at random. Returns the number removed
+func (trafficWorld *TrafficWorld) RemoveCars(number int) int {
+
+	if number > len(trafficWorld.Cars) {
+		number = len(trafficWorld.Cars)
+	}
+
+	for i := 0; i < number; i++ {
+		trafficWorld.removeCar(trafficWorld.random.Intn(len(trafficWorld.Cars)))
+	}
+
+	return number
+}
+
+//removeCar Takes the car at the index of Cars off the road
+func (trafficWorld *TrafficWorld) removeCar(index int) {
+
+	car := trafficWorld.Cars[index]
+	trafficWorld.occupant[trafficWorld.roads[car.Road].tiles[car.Position]] = nil
+
+	trafficWorld.Cars[index] = trafficWorld.Cars[len(trafficWorld.Cars)-1]
+	trafficWorld.Cars = trafficWorld.Cars[:len(trafficWorld.Cars)-1]
+}
+
+//ToggleBlock Blocks the road at x and y, or clears the block if there already is one. A car on the tile is taken off
+//the road. Returns false if there is no road there
+func (trafficWorld *TrafficWorld) ToggleBlock(x int, y int) bool {
+
+	i := trafficWorld.index(x, y)
+
+	switch trafficWorld.tiles[i] {
+	case RoadBlock:
+		trafficWorld.tiles[i] = Road
+		return true
+	case Road:
+		if car := trafficWorld.occupant[i]; car != nil {
+			for index := range trafficWorld.Cars {
+				if trafficWorld.Cars[index] == car {
+					trafficWorld.removeCar(index)
+					break
+				}
+			}
+		}
+		trafficWorld.tiles[i] = RoadBlock
+		return true
+	}
+
+	return false
+}
+
+//Update Changes lanes, then moves every car following the Nagel-Schreckenberg rules
+func (trafficWorld *TrafficWorld) Update() bool {
+
+	if trafficWorld.Lanes > 1 {
+		trafficWorld.changeLanes()
+	}
+
+	//Every velocity is worked out before any car moves, so cars only ever see where the others were
+	for _, car := range trafficWorld.Cars {
+
+		road := trafficWorld.roads[car.Road]
+
+		car.Velocity++
+		if car.Velocity > trafficWorld.MaxVelocity {
+			car.Velocity = trafficWorld.MaxVelocity
+		}
+
+		if gap := trafficWorld.gapAhead(road, car.Position); car.Velocity > gap {
+			car.Velocity = gap
+		}
+
+		if car.Velocity > 0 && trafficWorld.random.Float64() < trafficWorld.SlowdownChance {
+			car.Velocity--
+		}
+	}
+
+	for _, car := range trafficWorld.Cars {
+		trafficWorld.occupant[trafficWorld.roads[car.Road].tiles[car.Position]] = nil
+	}
+
+	for _, car := range trafficWorld.Cars {
+		road := trafficWorld.roads[car.Road]
+		car.Position = (car.Position + car.Velocity) % len(road.tiles)
+		trafficWorld.occupant[road.tiles[car.Position]] = car
+	}
+
+	trafficWorld.Tick++
+	trafficWorld.sample()
+
+	return true
+}
+
+//TickRate Returns the time between each Update
+func (trafficWorld *TrafficWorld) TickRate() time.Duration {
+	return time.Millisecond * FrameSpeedMultiplier * 4
+}
+
+//changeLanes Moves each car on the horizontal road into the lane beside it when the car is held up, the gap in the
+//other lane is bigger and there is room behind it there. Cars do not change lanes in an intersection
+func (trafficWorld *TrafficWorld) changeLanes() {
+
+	//Alternate which side is tried first so the traffic does not drift into one lane
+	side := 1
+	if trafficWorld.Tick%2 == 0 {
+		side = -1
+	}
+
+	for _, car := range trafficWorld.Cars {
+
+		road := trafficWorld.roads[car.Road]
+
+		if !road.horizontal {
+			continue
+		}
+
+		gap := trafficWorld.gapAhead(road, car.Position)
+
+		if gap > car.Velocity {
+			continue
+		}
+
+		for _, lane := range []int{car.Road + side, car.Road - side} {
+
+			if lane < 0 || lane >= trafficWorld.Lanes {
+				continue
+			}
+
+			other := trafficWorld.roads[lane]
+			target := other.tiles[car.Position]
+
+			if trafficWorld.tiles[target] != Road || trafficWorld.occupant[target] != nil {
+				continue
+			}
+
+			if trafficWorld.gapAhead(other, car.Position) > gap && trafficWorld.gapBehind(other, car.Position) >= trafficWorld.MaxVelocity {
+				trafficWorld.occupant[road.tiles[car.Position]] = nil
+				trafficWorld.occupant[target] = car
+				car.Road = lane
+				break
+			}
+		}
+	}
+}
+
+//sample Records the density and flow of the cars, keeping the last maxTrafficSamples ticks
+func (trafficWorld *TrafficWorld) sample() {
+
+	sample := TrafficSample{Tick: trafficWorld.Tick}
+
+	if trafficWorld.roadTiles > 0 {
+
+		velocities := 0
+		for _, car := range trafficWorld.Cars {
+			velocities += car.Velocity
+		}
+
+		sample.Density = float64(len(trafficWorld.Cars)) / float64(trafficWorld.roadTiles)
+		sample.Flow = float64(velocities) / float64(trafficWorld.roadTiles)
+
+		if len(trafficWorld.Cars) > 0 {
+			sample.MeanVelocity = float64(velocities) / float64(len(trafficWorld.Cars))
+		}
+	}
+
+	if len(trafficWorld.Samples) >= maxTrafficSamples {
+		trafficWorld.Samples = trafficWorld.Samples[1:]
+	}
+
+	trafficWorld.Samples = append(trafficWorld.Samples, sample)
+}
+
+//FundamentalDiagram the mean flow of traffic seen at each density. Densities are grouped into bins of the same width
+type FundamentalDiagram struct {
+	BinWidth float64
+	bins     map[int]*fundamentalDiagramBin
+}
+
+type fundamentalDiagramBin struct {
+	flow    float64
+	samples int
+}
+
+//FundamentalDiagramPoint the mean flow of the samples with a density in the bin starting at Density
+type FundamentalDiagramPoint struct {
+	Density float64
+	Flow    float64
+	Samples int
+}
+
+//NewFundamentalDiagram Returns an empty FundamentalDiagram with bins of the width
+func NewFundamentalDiagram(binWidth float64) FundamentalDiagram {
+	return FundamentalDiagram{BinWidth: binWidth, bins: map[int]*fundamentalDiagramBin{}}
+}
+
+//Add Adds the flow of the sample to the bin of its density
+func (diagram *FundamentalDiagram) Add(sample TrafficSample) {
+
+	//The small amount added stops a density on the edge of a bin, such as 0.15 in bins of 0.05, being rounded down
+	//into the bin before it
+	key := int(sample.Density/diagram.BinWidth + 1e-9)
+
+	bin, ok := diagram.bins[key]
+	if !ok {
+		bin = &fundamentalDiagramBin{}
+		diagram.bins[key] = bin
+	}
+
+	bin.flow += sample.Flow
+	bin.samples++
+}
+
+//Points Returns the mean flow of each bin that has samples, in order of density
+func (diagram *FundamentalDiagram) Points() []FundamentalDiagramPoint {
+
+	points := []FundamentalDiagramPoint{}
+
+	for key, bin := range diagram.bins {
+		points = append(points, FundamentalDiagramPoint{
+			Density: float64(key) * diagram.BinWidth,
+			Flow:    bin.flow / float64(bin.samples),
+			Samples: bin.samples,
+		})
+	}
+
+	sort.Slice(points, func(i, j int) bool { return points[i].Density < points[j].Density })
+
+	return points
+}
@@ -0,0 +1,167 @@
+package world
+
+import (
+	"math"
+	"testing"
+)
+
+//checkTraffic Fails if any car is not on the tile it thinks it is on, or shares a tile with another car
+func checkTraffic(t *testing.T, trafficWorld *TrafficWorld) {
+
+	cars := 0
+
+	for i, car := range trafficWorld.occupant {
+		if car == nil {
+			continue
+		}
+		cars++
+		if trafficWorld.roads[car.Road].tiles[car.Position] != i {
+			t.Fatalf("Car %+v is not on the tile it is drawn on", car)
+		}
+	}
+
+	if cars != len(trafficWorld.Cars) {
+		t.Fatalf("%d tiles have a car on, want %d cars", cars, len(trafficWorld.Cars))
+	}
+}
+
+func TestTrafficWorld_FreeFlow(t *testing.T) {
+
+	trafficWorld := NewTrafficWorld(TrafficWorldSettings{
+		Dimensions:  Dimensions{Width: 100, Height: 1},
+		Density:     0.05,
+		MaxVelocity: 5,
+		Seeded:      Seeded{Seed: 1},
+	})
+
+	for i := 0; i < 100; i++ {
+		trafficWorld.Update()
+	}
+
+	sample := trafficWorld.Samples[len(trafficWorld.Samples)-1]
+
+	if sample.MeanVelocity != 5 || math.Abs(sample.Flow-0.25) > 1e-9 {
+		t.Errorf("Sample = %+v, want sparse cars with no slowdowns to all drive at 5 with a flow of 0.25", sample)
+	}
+}
+
+func TestTrafficWorld_DensityOutOfRange(t *testing.T) {
+
+	for _, test := range []struct {
+		density float64
+		want    int
+	}{
+		{density: -0.5, want: 0},
+		{density: 1.5, want: 20},
+	} {
+
+		trafficWorld := NewTrafficWorld(TrafficWorldSettings{
+			Dimensions:  Dimensions{Width: 10, Height: 2},
+			Lanes:       2,
+			Density:     test.density,
+			MaxVelocity: 5,
+			Seeded:      Seeded{Seed: 1},
+		})
+
+		if len(trafficWorld.Cars) != test.want {
+			t.Errorf("Density %v placed %d cars, want %d", test.density, len(trafficWorld.Cars), test.want)
+		}
+
+		trafficWorld.Update()
+		checkTraffic(t, &trafficWorld)
+	}
+}
+
+func TestTrafficWorld_CarsNeverCollide(t *testing.T) {
+
+	trafficWorld := NewTrafficWorld(TrafficWorldSettings{
+		Dimensions:     Dimensions{Width: 60, Height: 20},
+		Lanes:          3,
+		Intersections:  3,
+		Density:        0.3,
+		MaxVelocity:    5,
+		SlowdownChance: 0.2,
+		LightCycle:     10,
+		Seeded:         Seeded{Seed: 1},
+	})
+
+	cars := len(trafficWorld.Cars)
+	trafficWorld.ToggleBlock(10, 10)
+
+	for i := 0; i < 300; i++ {
+		trafficWorld.Update()
+		checkTraffic(t, &trafficWorld)
+	}
+
+	if len(trafficWorld.Cars) > cars || len(trafficWorld.Cars) < cars-1 {
+		t.Errorf("%d cars on the road, want the %d placed less any under the block", len(trafficWorld.Cars), cars)
+	}
+}
+
+func TestTrafficWorld_RoadBlock(t *testing.T) {
+
+	trafficWorld := NewTrafficWorld(TrafficWorldSettings{
+		Dimensions:  Dimensions{Width: 20, Height: 1},
+		Density:     0.1,
+		MaxVelocity: 3,
+		Seeded:      Seeded{Seed: 1},
+	})
+
+	if !trafficWorld.ToggleBlock(0, 0) {
+		t.Fatalf("ToggleBlock() did not block the road")
+	}
+
+	for i := 0; i < 50; i++ {
+		trafficWorld.Update()
+	}
+
+	//Both cars queue up behind the block at the start of the ring
+	for x := 18; x <= 19; x++ {
+		if _, car := trafficWorld.Tile(x, 0); car == nil || car.Velocity != 0 {
+			t.Errorf("Tile(%d, 0) = %+v, want a stopped car queued behind the block", x, car)
+		}
+	}
+
+	trafficWorld.ToggleBlock(0, 0)
+
+	if tile, _ := trafficWorld.Tile(0, 0); tile != Road {
+		t.Errorf("Tile(0, 0) = %v after clearing the block, want Road", tile)
+	}
+}
+
+func TestTrafficWorld_RedLight(t *testing.T) {
+
+	trafficWorld := NewTrafficWorld(TrafficWorldSettings{
+		Dimensions:    Dimensions{Width: 9, Height: 9},
+		Intersections: 1,
+		Density:       1,
+		MaxVelocity:   2,
+		LightCycle:    1000,
+		Seeded:        Seeded{Seed: 1},
+	})
+
+	if !trafficWorld.IsGreen(4, 4) {
+		t.Fatalf("Light at (4, 4) is red for the horizontal road, want green")
+	}
+
+	for i := 0; i < 20; i++ {
+		trafficWorld.Update()
+		if _, car := trafficWorld.Tile(4, 4); car != nil && car.Road != 0 {
+			t.Fatalf("A car on the vertical road drove into the intersection on a red light")
+		}
+	}
+}
+
+func TestFundamentalDiagram_Points(t *testing.T) {
+
+	diagram := NewFundamentalDiagram(0.1)
+	diagram.Add(TrafficSample{Density: 0.25, Flow: 1})
+	diagram.Add(TrafficSample{Density: 0.21, Flow: 0.5})
+	diagram.Add(TrafficSample{Density: 0.05, Flow: 0.2})
+
+	points := diagram.Points()
+
+	if len(points) != 2 || points[0].Samples != 1 || points[1].Samples != 2 || points[1].Flow != 0.75 {
+		t.Errorf("FundamentalDiagram.Points() = %+v, want the two samples near 0.2 averaged", points)
+	}
+}
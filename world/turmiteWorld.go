@@ -0,0 +1,314 @@
+package world
+
+import (
+	"fmt"
+	"gopherlife/geometry"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//Turn how far a Turmite turns before it moves
+type Turn int
+
+//Turns in the order they are numbered in turmite transition tables
+const (
+	NoTurn    Turn = 1
+	TurnRight Turn = 2
+	UTurn     Turn = 4
+	TurnLeft  Turn = 8
+)
+
+//Apply Returns the Direction once it has been turned
+func (turn Turn) Apply(d geometry.Direction) geometry.Direction {
+
+	switch turn {
+	case TurnRight:
+		return d.TurnClockWise90()
+	case TurnLeft:
+		return d.TurnAntiClockWise90()
+	case UTurn:
+		return d.TurnClockWise90().TurnClockWise90()
+	}
+
+	return d
+}
+
+//TurmiteTransition what a Turmite does when it stands on a cell of a colour in a state. It paints the cell with Write,
+//turns and then moves into the Next state
+type TurmiteTransition struct {
+	Write uint8
+	Turn  Turn
+	Next  int
+}
+
+//TurmiteRule a transition table indexed by the state of the Turmite and then the colour of the cell it is on
+type TurmiteRule struct {
+	Name   string
+	Colors int
+	Table  [][]TurmiteTransition
+}
+
+//maxTurmiteColors the most colours a TurmiteRule can paint with
+const maxTurmiteColors = 256
+
+//turmiteTableTriple matches a single {write,turn,next} transition of a turmite table with its spaces removed
+var turmiteTableTriple = regexp.MustCompile(`\{(\d+),(\d+),(\d+)\}`)
+
+//turmiteTableState matches the transitions of a single state of a turmite table with its spaces removed
+var turmiteTableState = regexp.MustCompile(`\{\{\d+,\d+,\d+\}(,\{\d+,\d+,\d+\})*\}`)
+
+//turmiteTable matches a whole turmite table with its spaces removed, a list of states and nothing else
+var turmiteTable = regexp.MustCompile(`^\{` + turmiteTableState.String() + `(,` + turmiteTableState.String() + `)*\}$`)
+
+//ParseTurmiteRule Returns the TurmiteRule for either an ant rule such as "RLR", or a multi-state transition table
+//such as "{{{1,2,0},{0,8,0}}}"
+func ParseTurmiteRule(rule string) (TurmiteRule, error) {
+
+	rule = strings.TrimSpace(rule)
+
+	if strings.HasPrefix(rule, "{") {
+		return ParseTurmiteTable(rule)
+	}
+
+	return ParseAntRule(rule)
+}
+
+//ParseAntRule Returns the TurmiteRule of a Langton's ant with one colour for each letter of the rule. On a cell of
+//colour i the ant turns by letter i, 'L' left, 'R' right, 'N' not at all or 'U' around, and paints the cell colour i+1
+func ParseAntRule(rule string) (TurmiteRule, error) {
+
+	rule = strings.ToUpper(strings.TrimSpace(rule))
+
+	if len(rule) < 2 || len(rule) > maxTurmiteColors {
+		return TurmiteRule{}, fmt.Errorf("ant rule %q needs between 2 and %d letters", rule, maxTurmiteColors)
+	}
+
+	transitions := make([]TurmiteTransition, len(rule))
+
+	for i, c := range rule {
+
+		var turn Turn
+
+		switch c {
+		case 'L':
+			turn = TurnLeft
+		case 'R':
+			turn = TurnRight
+		case 'N':
+			turn = NoTurn
+		case 'U':
+			turn = UTurn
+		default:
+			return TurmiteRule{}, fmt.Errorf("ant rule %q has an unknown turn %q", rule, c)
+		}
+
+		transitions[i] = TurmiteTransition{Write: uint8((i + 1) % len(rule)), Turn: turn}
+	}
+
+	return TurmiteRule{Name: rule, Colors: len(rule), Table: [][]TurmiteTransition{transitions}}, nil
+}
+
+//ParseTurmiteTable Returns the TurmiteRule written as a transition table. The table lists each state in turn, each
+//state lists a {write, turn, next} transition for each colour. The turns are 1 for none, 2 right, 4 around and 8 left
+func ParseTurmiteTable(table string) (TurmiteRule, error) {
+
+	compact := strings.Join(strings.Fields(table), "")
+
+	if !strings.HasPrefix(compact, "{{{") || !strings.HasSuffix(compact, "}}}") {
+		return TurmiteRule{}, fmt.Errorf("turmite table %q must start with {{{ and end with }}}", table)
+	}
+
+	if !turmiteTable.MatchString(compact) {
+		return TurmiteRule{}, fmt.Errorf("turmite table %q must only list states of {write, turn, next} transitions", table)
+	}
+
+	rule := TurmiteRule{Name: compact}
+
+	for _, state := range turmiteTableState.FindAllString(compact[1:len(compact)-1], -1) {
+
+		transitions := []TurmiteTransition{}
+
+		for _, triple := range turmiteTableTriple.FindAllStringSubmatch(state, -1) {
+
+			numbers := [3]int{}
+
+			for i := range numbers {
+				number, err := strconv.Atoi(triple[i+1])
+				if err != nil {
+					return TurmiteRule{}, fmt.Errorf("turmite table %q has a bad number %q", table, triple[i+1])
+				}
+				numbers[i] = number
+			}
+
+			write, turn, next := numbers[0], numbers[1], numbers[2]
+
+			switch Turn(turn) {
+			case NoTurn, TurnRight, UTurn, TurnLeft:
+			default:
+				return TurmiteRule{}, fmt.Errorf("turmite table %q has an unknown turn %d", table, turn)
+			}
+
+			if write >= maxTurmiteColors {
+				return TurmiteRule{}, fmt.Errorf("turmite table %q writes colour %d, the most is %d", table, write, maxTurmiteColors-1)
+			}
+
+			transitions = append(transitions, TurmiteTransition{Write: uint8(write), Turn: Turn(turn), Next: next})
+		}
+
+		rule.Table = append(rule.Table, transitions)
+	}
+
+	if len(rule.Table) == 0 {
+		return TurmiteRule{}, fmt.Errorf("turmite table %q has no states", table)
+	}
+
+	rule.Colors = len(rule.Table[0])
+
+	for _, transitions := range rule.Table {
+
+		if len(transitions) != rule.Colors || rule.Colors == 0 {
+			return TurmiteRule{}, fmt.Errorf("turmite table %q must have the same number of colours in every state", table)
+		}
+
+		for _, transition := range transitions {
+			if int(transition.Write) >= rule.Colors || transition.Next >= len(rule.Table) {
+				return TurmiteRule{}, fmt.Errorf("turmite table %q refers to a colour or state it does not have", table)
+			}
+		}
+	}
+
+	return rule, nil
+}
+
+//Turmite a Turing machine that walks around a grid of coloured cells
+type Turmite struct {
+	Position geometry.Coordinates
+	Facing   geometry.Direction
+	State    int
+}
+
+//TurmiteWorldSettings sets up a TurmiteWorld
+type TurmiteWorldSettings struct {
+	Dimensions
+	Topology geometry.Topology
+	Rule     TurmiteRule
+	//InitialTurmites the number of Turmites placed at random when the world starts
+	InitialTurmites int
+	//StepsPerUpdate the number of steps every Turmite takes each Update
+	StepsPerUpdate int
+}
+
+//TurmiteWorld Turmites walking around and painting a grid of cells
+type TurmiteWorld struct {
+	TurmiteWorldSettings
+	grid     Automaton
+	Turmites []*Turmite
+	Steps    int
+}
+
+//NewTurmiteWorld Returns a TurmiteWorld of cells of colour zero with the Turmites placed at random
+func NewTurmiteWorld(settings TurmiteWorldSettings) TurmiteWorld {
+
+	turmiteWorld := TurmiteWorld{
+		TurmiteWorldSettings: settings,
+		grid:                 NewAutomaton(settings.Width, settings.Height, settings.Topology, nil),
+	}
+
+	turmiteWorld.TurmiteWorldSettings.Dimensions = turmiteWorld.grid.Dimensions()
+
+	if turmiteWorld.StepsPerUpdate < 1 {
+		turmiteWorld.StepsPerUpdate = 1
+	}
+
+	for i := 0; i < settings.InitialTurmites; i++ {
+		turmiteWorld.AddTurmite(rand.Intn(turmiteWorld.Width), rand.Intn(turmiteWorld.Height))
+	}
+
+	return turmiteWorld
+}
+
+//Surface Returns the shape of the world
+func (turmiteWorld *TurmiteWorld) Surface() *geometry.Surface {
+	return turmiteWorld.grid.Surface()
+}
+
+//Cell Returns the colour of the cell, false if it is off the grid
+func (turmiteWorld *TurmiteWorld) Cell(x int, y int) (uint8, bool) {
+	return turmiteWorld.grid.Cell(x, y)
+}
+
+//AddTurmite Adds a Turmite facing up in its first state. Returns false if the position is off the grid
+func (turmiteWorld *TurmiteWorld) AddTurmite(x int, y int) bool {
+
+	x, y, ok := turmiteWorld.Surface().Wrap(x, y)
+
+	if !ok {
+		return false
+	}
+
+	turmiteWorld.Turmites = append(turmiteWorld.Turmites, &Turmite{
+		Position: geometry.NewCoordinate(x, y),
+		Facing:   geometry.Up,
+	})
+
+	return true
+}
+
+//HasTurmite Returns true if there is a Turmite on the cell
+func (turmiteWorld *TurmiteWorld) HasTurmite(x int, y int) bool {
+	for _, turmite := range turmiteWorld.Turmites {
+		if turmite.Position.GetX() == x && turmite.Position.GetY() == y {
+			return true
+		}
+	}
+	return false
+}
+
+//Update Moves every Turmite on by StepsPerUpdate steps
+func (turmiteWorld *TurmiteWorld) Update() bool {
+
+	for i := 0; i < turmiteWorld.StepsPerUpdate; i++ {
+		turmiteWorld.Step()
+	}
+
+	return true
+}
+
+//TickRate Returns the time between each Update
+func (turmiteWorld *TurmiteWorld) TickRate() time.Duration {
+	return time.Millisecond * FrameSpeedMultiplier * 2
+}
+
+//Step Each Turmite in turn paints its cell, turns, changes state and moves forward one cell. A Turmite that would walk
+//off an edge that is not joined turns around instead
+func (turmiteWorld *TurmiteWorld) Step() {
+
+	table := turmiteWorld.Rule.Table
+
+	for _, turmite := range turmiteWorld.Turmites {
+
+		x, y := turmite.Position.GetX(), turmite.Position.GetY()
+		color, _ := turmiteWorld.grid.Cell(x, y)
+
+		if turmite.State >= len(table) || int(color) >= len(table[turmite.State]) {
+			continue
+		}
+
+		transition := table[turmite.State][color]
+
+		turmiteWorld.grid.SetCell(x, y, transition.Write)
+		turmite.Facing = transition.Turn.Apply(turmite.Facing)
+		turmite.State = transition.Next
+
+		if nx, ny, ok := turmiteWorld.Surface().Wrap(turmite.Facing.AddToPoint(x, y)); ok {
+			turmite.Position = geometry.NewCoordinate(nx, ny)
+		} else {
+			turmite.Facing = UTurn.Apply(turmite.Facing)
+		}
+	}
+
+	turmiteWorld.Steps++
+}
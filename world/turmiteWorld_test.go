@@ -0,0 +1,87 @@
+package world
+
+import (
+	"gopherlife/geometry"
+	"testing"
+)
+
+func TestParseTurmiteRule(t *testing.T) {
+
+	tests := []struct {
+		rule       string
+		wantStates int
+		wantColors int
+		wantErr    bool
+	}{
+		{"RL", 1, 2, false},
+		{"llrr", 1, 4, false},
+		{"{{{1, 2, 0}, {0, 8, 0}}}", 1, 2, false},
+		{"{{{1,2,1},{0,8,0}},{{1,2,1},{1,1,0}}}", 2, 2, false},
+		{"RX", 0, 0, true},
+		{"R", 0, 0, true},
+		{"{{{1,2,1},{0,8,0}}}", 0, 0, true},
+		{"{{{1,3,0},{0,8,0}}}", 0, 0, true},
+		{"{{{1,2,0},x{0,8,0}}}", 0, 0, true},
+		{"{{{1,2,0,5},{0,8,0}}}", 0, 0, true},
+		{"{{{1,2,0},{0,8,0}}x,{{1,2,0},{0,8,0}}}", 0, 0, true},
+		{"{{{1,2,0},{0,8,0}},}}", 0, 0, true},
+		{"{{{1,2,0},{0,8,99999999999999999999}}}", 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			got, err := ParseTurmiteRule(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTurmiteRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (len(got.Table) != tt.wantStates || got.Colors != tt.wantColors) {
+				t.Errorf("ParseTurmiteRule() = %d states %d colours, want %d and %d", len(got.Table), got.Colors, tt.wantStates, tt.wantColors)
+			}
+		})
+	}
+}
+
+func TestTurmiteWorld_LangtonsAnt(t *testing.T) {
+
+	rule, _ := ParseAntRule("RL")
+	turmiteWorld := NewTurmiteWorld(TurmiteWorldSettings{Dimensions: Dimensions{Width: 5, Height: 5}, Rule: rule})
+	turmiteWorld.AddTurmite(2, 2)
+
+	//Four right turns on white cells walk the ant round a square back to where it started
+	for i := 0; i < 4; i++ {
+		turmiteWorld.Step()
+	}
+
+	ant := turmiteWorld.Turmites[0]
+
+	if ant.Position != geometry.NewCoordinate(2, 2) || ant.Facing != geometry.Up {
+		t.Errorf("Ant after four steps is at %v facing %v, want (2, 2) facing up", ant.Position, ant.Facing)
+	}
+
+	for _, cell := range []geometry.Coordinates{{X: 2, Y: 2}, {X: 3, Y: 2}, {X: 3, Y: 1}, {X: 2, Y: 1}} {
+		if color, _ := turmiteWorld.Cell(cell.X, cell.Y); color != 1 {
+			t.Errorf("Cell %v has colour %d, want 1", cell, color)
+		}
+	}
+
+	//On a painted cell the ant turns left and paints it back
+	turmiteWorld.Step()
+
+	if color, _ := turmiteWorld.Cell(2, 2); color != 0 || ant.Facing != geometry.Left {
+		t.Errorf("Ant on a painted cell left colour %d facing %v, want 0 facing left", color, ant.Facing)
+	}
+}
+
+func TestTurmiteWorld_BoundedEdge(t *testing.T) {
+
+	rule, _ := ParseAntRule("NN")
+	turmiteWorld := NewTurmiteWorld(TurmiteWorldSettings{Dimensions: Dimensions{Width: 3, Height: 3}, Rule: rule})
+	turmiteWorld.AddTurmite(1, 2)
+
+	turmiteWorld.Step()
+
+	ant := turmiteWorld.Turmites[0]
+
+	if ant.Position != geometry.NewCoordinate(1, 2) || ant.Facing != geometry.Down {
+		t.Errorf("Ant at a wall is at %v facing %v, want (1, 2) facing down", ant.Position, ant.Facing)
+	}
+}
@@ -0,0 +1,18 @@
+package antcolony
+
+import (
+	"gopherlife/controllers"
+	"gopherlife/registry"
+)
+
+func init() {
+	registry.Register(registry.World{
+		ID:          "ant-colony",
+		DisplayName: "Ant Colony",
+		Description: "Ants that find food and lay pheromone trails back to their nest",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewAntColonyController()
+			return &controller
+		},
+	})
+}
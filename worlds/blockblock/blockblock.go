@@ -0,0 +1,18 @@
+package blockblock
+
+import (
+	"gopherlife/controllers"
+	"gopherlife/registry"
+)
+
+func init() {
+	registry.Register(registry.World{
+		ID:          "block-block-revolution",
+		DisplayName: "Block Block Revolution",
+		Description: "A falling blocks game, fill rows to clear them",
+		New: func(dependencies registry.Dependencies) registry.RenderController {
+			controller := controllers.NewBlockBlockRevolutionController(dependencies.HighScores)
+			return &controller
+		},
+	})
+}
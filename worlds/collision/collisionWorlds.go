@@ -0,0 +1,48 @@
+package collision
+
+import (
+	"gopherlife/controllers"
+	"gopherlife/registry"
+)
+
+func init() {
+	registry.Register(registry.World{
+		ID:          "collision",
+		DisplayName: "Collision World",
+		Description: "Particles that bounce off each other and the walls",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewCollisionWorldController()
+			return &controller
+		},
+	})
+
+	registry.Register(registry.World{
+		ID:          "collision-diagonal",
+		DisplayName: "Collision World (Diagonal)",
+		Description: "Particles that move diagonally and bounce off each other",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewDiagonalCollisionWorldController()
+			return &controller
+		},
+	})
+
+	registry.Register(registry.World{
+		ID:          "collision-elastic",
+		DisplayName: "Collision World (Elastic)",
+		Description: "Particles of different masses in elastic collisions",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewElasticCollisionWorldController()
+			return &controller
+		},
+	})
+
+	registry.Register(registry.World{
+		ID:          "collision-slit",
+		DisplayName: "Collision World (Slit)",
+		Description: "Particles passing through a slit in a wall",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewSlitCollisionWorldController()
+			return &controller
+		},
+	})
+}
@@ -0,0 +1,18 @@
+package flocking
+
+import (
+	"gopherlife/controllers"
+	"gopherlife/registry"
+)
+
+func init() {
+	registry.Register(registry.World{
+		ID:          "flocking-boids",
+		DisplayName: "Flocking Boids",
+		Description: "Boids that flock together while avoiding predators and obstacles",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewFlockWorldController()
+			return &controller
+		},
+	})
+}
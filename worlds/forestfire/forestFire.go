@@ -0,0 +1,18 @@
+package forestfire
+
+import (
+	"gopherlife/controllers"
+	"gopherlife/registry"
+)
+
+func init() {
+	registry.Register(registry.World{
+		ID:          "forest-fire",
+		DisplayName: "Forest Fire",
+		Description: "Trees that grow, are struck by lightning and burn",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewForestFireController()
+			return &controller
+		},
+	})
+}
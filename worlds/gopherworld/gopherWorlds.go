@@ -0,0 +1,88 @@
+package gopherworld
+
+import (
+	"gopherlife/controllers"
+	"gopherlife/registry"
+)
+
+func init() {
+	registry.Register(registry.World{
+		ID:          "gopherworld-spiral-search",
+		DisplayName: "GopherWorld With Spiral Search",
+		Description: "Gophers that search for food by spiralling out from where they are",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewGopherWorldWithSpiralSearch()
+			return &controller
+		},
+	})
+
+	registry.Register(registry.World{
+		ID:          "gopherworld-partition",
+		DisplayName: "GopherWorld With Partition",
+		Description: "Gophers that search for food through a grid of partitions",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewGopherWorldWithParitionGridAndSearch()
+			return &controller
+		},
+	})
+
+	registry.Register(registry.World{
+		ID:          "gopherworld-indexed-search",
+		DisplayName: "GopherWorld With Indexed Search",
+		Description: "Gophers that search for food through an index of where it is",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewGopherWorldWithIndexedSearch()
+			return &controller
+		},
+	})
+
+	registry.Register(registry.World{
+		ID:          "gopherworld-infinite",
+		DisplayName: "GopherWorld (Infinite)",
+		Description: "Gophers in a world without edges that grows as they explore it",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewGopherWorldInfinite()
+			return &controller
+		},
+	})
+
+	registry.Register(registry.World{
+		ID:          "gopherworld-hex",
+		DisplayName: "GopherWorld (Hex)",
+		Description: "Gophers on a grid of hexagons",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewGopherWorldHex()
+			return &controller
+		},
+	})
+
+	registry.Register(registry.World{
+		ID:          "spiral",
+		DisplayName: "Black and White Spiral World",
+		Description: "A spiral drawn tile by tile",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewSpiralWorldController()
+			return &controller
+		},
+	})
+
+	registry.Register(registry.World{
+		ID:          "weird-spiral",
+		DisplayName: "Black and White Spiral World (Weird)",
+		Description: "A spiral drawn tile by tile in an odd order",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewWeirdSpiralWorldController()
+			return &controller
+		},
+	})
+
+	registry.Register(registry.World{
+		ID:          "fireworks",
+		DisplayName: "Fireworks!",
+		Description: "A crowded GopherWorld drawn as bursts of colour",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewFireWorksController()
+			return &controller
+		},
+	})
+}
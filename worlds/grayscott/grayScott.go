@@ -0,0 +1,18 @@
+package grayscott
+
+import (
+	"gopherlife/controllers"
+	"gopherlife/registry"
+)
+
+func init() {
+	registry.Register(registry.World{
+		ID:          "gray-scott",
+		DisplayName: "Reaction Diffusion (Gray-Scott)",
+		Description: "Two chemicals reacting and spreading out into patterns",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewGrayScottController()
+			return &controller
+		},
+	})
+}
@@ -0,0 +1,18 @@
+package life
+
+import (
+	"gopherlife/controllers"
+	"gopherlife/registry"
+)
+
+func init() {
+	registry.Register(registry.World{
+		ID:          "game-of-life",
+		DisplayName: "Game of Life",
+		Description: "Conway's Game of Life and other life-like cellular automata",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewLifeWorldController()
+			return &controller
+		},
+	})
+}
@@ -0,0 +1,18 @@
+package maze
+
+import (
+	"gopherlife/controllers"
+	"gopherlife/registry"
+)
+
+func init() {
+	registry.Register(registry.World{
+		ID:          "maze",
+		DisplayName: "Maze Generation and Solving",
+		Description: "Mazes carved by a generator then solved step by step",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewMazeWorldController()
+			return &controller
+		},
+	})
+}
@@ -0,0 +1,18 @@
+package sand
+
+import (
+	"gopherlife/controllers"
+	"gopherlife/registry"
+)
+
+func init() {
+	registry.Register(registry.World{
+		ID:          "falling-sand",
+		DisplayName: "Falling Sand",
+		Description: "Sand, water, fire and smoke painted onto a grid",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewSandWorldController()
+			return &controller
+		},
+	})
+}
@@ -0,0 +1,18 @@
+package snake
+
+import (
+	"gopherlife/controllers"
+	"gopherlife/registry"
+)
+
+func init() {
+	registry.Register(registry.World{
+		ID:          "elongating-gopher",
+		DisplayName: "Elongating Gopher",
+		Description: "A snake game, eat food to grow without running into yourself",
+		New: func(dependencies registry.Dependencies) registry.RenderController {
+			controller := controllers.NewSnakeWorldController(dependencies.HighScores)
+			return &controller
+		},
+	})
+}
@@ -0,0 +1,18 @@
+package traffic
+
+import (
+	"gopherlife/controllers"
+	"gopherlife/registry"
+)
+
+func init() {
+	registry.Register(registry.World{
+		ID:          "traffic",
+		DisplayName: "Traffic",
+		Description: "Cars on ring roads with traffic lights, following the Nagel-Schreckenberg rules",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewTrafficWorldController()
+			return &controller
+		},
+	})
+}
@@ -0,0 +1,18 @@
+package turmites
+
+import (
+	"gopherlife/controllers"
+	"gopherlife/registry"
+)
+
+func init() {
+	registry.Register(registry.World{
+		ID:          "turmites",
+		DisplayName: "Turmites",
+		Description: "Ants that turn and recolour the tiles they walk over",
+		New: func(registry.Dependencies) registry.RenderController {
+			controller := controllers.NewTurmiteWorldController()
+			return &controller
+		},
+	})
+}